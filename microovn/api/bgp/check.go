@@ -0,0 +1,59 @@
+package bgp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	ovnBgp "github.com/canonical/microovn/microovn/bgp"
+	"github.com/canonical/microovn/microovn/node"
+)
+
+// CheckEndpoint defines endpoint for /1.0/bgp/check
+var CheckEndpoint = rest.Endpoint{
+	Path: "bgp/check",
+	Post: rest.EndpointAction{Handler: checkConfig, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// checkConfig implements POST method for /1.0/bgp/check. It runs preflight checks of the BGP configuration
+// from the request body on the target member, without enabling the "bgp" service.
+//
+// This will return a response which contains result of each check.
+func checkConfig(s state.State, r *http.Request) response.Response {
+	var config types.ExtraBgpConfig
+	err := json.NewDecoder(r.Body).Decode(&config)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	hasBgp, err := node.HasServiceActive(r.Context(), s, types.SrvBgp)
+	if err != nil {
+		logger.Errorf("Failed to check if bgp is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	report := types.BgpCheckReport{Member: s.Name()}
+	if hasBgp {
+		report.Checks = append(report.Checks, types.BgpCheckResult{
+			Check:   types.BgpCheckService,
+			Message: "'bgp' service is already enabled on this member",
+		})
+		return response.SyncResponse(true, report)
+	}
+
+	report.Checks = append(report.Checks, types.BgpCheckResult{
+		Check:   types.BgpCheckService,
+		Passed:  true,
+		Message: "'bgp' service is not enabled yet",
+	})
+	report.Checks = append(report.Checks, ovnBgp.Preflight(r.Context(), s, config)...)
+
+	return response.SyncResponse(true, report)
+}
@@ -0,0 +1,95 @@
+package bgp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	ovnBgp "github.com/canonical/microovn/microovn/bgp"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/securitylog"
+)
+
+// ConfigEndpoint defines endpoint for /1.0/bgp
+var ConfigEndpoint = rest.Endpoint{
+	Path: "bgp",
+	Get:  rest.EndpointAction{Handler: getConfig, AllowUntrusted: false, ProxyTarget: false},
+	Put:  rest.EndpointAction{Handler: updateConfig, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// getConfig implements GET method for /1.0/bgp. It returns BGP configuration of each cluster member that
+// has "bgp" service enabled, as it is stored in the cluster database.
+func getConfig(s state.State, r *http.Request) response.Response {
+	bgpNodes, err := node.FindService(r.Context(), s, types.SrvBgp)
+	if err != nil {
+		logger.Errorf("Failed to find members with bgp service: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	memberConfigs, err := ovnBgp.MemberConfigs(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to get BGP configuration: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	configs := types.BgpConfigs{}
+	for _, bgpNode := range bgpNodes {
+		memberConfig, ok := memberConfigs[bgpNode.Name]
+		if !ok {
+			memberConfig = types.BgpMemberConfig{Member: bgpNode.Name}
+		} else {
+			memberConfig.Config.RedactSecrets()
+		}
+		configs = append(configs, memberConfig)
+	}
+
+	return response.SyncResponse(true, configs)
+}
+
+// updateConfig implements PUT method for /1.0/bgp. It applies changes in BGP configuration of the
+// target member without disabling the "bgp" service. Options that are not set in the request keep
+// their current value, per-connection and route policy options listed in the request's "unset" field
+// are cleared.
+//
+// This will return a response which contains the resulting BGP configuration, with secrets redacted.
+func updateConfig(s state.State, r *http.Request) response.Response {
+	var requestData types.BgpConfigUpdateRequest
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	hasBgp, err := node.HasServiceActive(r.Context(), s, types.SrvBgp)
+	if err != nil {
+		logger.Errorf("Failed to check if bgp is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasBgp {
+		return response.BadRequest(errors.New("'bgp' service is not enabled on this member"))
+	}
+
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "update_bgp_config", "node": s.Name()},
+		"Updating BGP configuration on node '%s'",
+		s.Name(),
+	)
+
+	config, err := ovnBgp.UpdateService(r.Context(), s, &requestData.Config, requestData.Unset)
+	if err != nil {
+		logger.Errorf("Failed to update BGP configuration: %s", err)
+		return response.InternalError(err)
+	}
+	config.RedactSecrets()
+
+	return response.SyncResponse(true, config)
+}
@@ -0,0 +1,56 @@
+package bgp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	ovnBgp "github.com/canonical/microovn/microovn/bgp"
+	"github.com/canonical/microovn/microovn/node"
+)
+
+// PreviewEndpoint defines endpoint for /1.0/bgp/preview
+var PreviewEndpoint = rest.Endpoint{
+	Path: "bgp/preview",
+	Post: rest.EndpointAction{Handler: previewConfig, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// previewConfig implements POST method for /1.0/bgp/preview. It accepts the same request as PUT method
+// for /1.0/bgp, but instead of applying the changes in BGP configuration of the target member, it only
+// reports changes of the network configuration that they would cause.
+//
+// This will return a response which contains the resulting BGP configuration, with secrets redacted, and
+// the network configuration changes.
+func previewConfig(s state.State, r *http.Request) response.Response {
+	var requestData types.BgpConfigUpdateRequest
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	hasBgp, err := node.HasServiceActive(r.Context(), s, types.SrvBgp)
+	if err != nil {
+		logger.Errorf("Failed to check if bgp is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasBgp {
+		return response.BadRequest(errors.New("'bgp' service is not enabled on this member"))
+	}
+
+	preview, err := ovnBgp.PreviewUpdate(r.Context(), s, &requestData.Config, requestData.Unset)
+	if err != nil {
+		logger.Errorf("Failed to preview BGP configuration update: %s", err)
+		return response.InternalError(err)
+	}
+	preview.Config.RedactSecrets()
+
+	return response.SyncResponse(true, preview)
+}
@@ -0,0 +1,121 @@
+package bgp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	"github.com/canonical/microcluster/v3/state"
+	"github.com/gorilla/mux"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	ovnBgp "github.com/canonical/microovn/microovn/bgp"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/securitylog"
+)
+
+// RoutersEndpoint defines endpoint for /1.0/bgp/routers
+var RoutersEndpoint = rest.Endpoint{
+	Path: "bgp/routers",
+	Get:  rest.EndpointAction{Handler: listRouters, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// RouterEndpoint defines endpoint for /1.0/bgp/routers/{router}
+var RouterEndpoint = rest.Endpoint{
+	Path:   "bgp/routers/{router}",
+	Put:    rest.EndpointAction{Handler: advertiseRouter, AllowUntrusted: false, ProxyTarget: true},
+	Delete: rest.EndpointAction{Handler: withdrawRouter, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// listRouters implements GET method for /1.0/bgp/routers. It returns user logical routers whose routes
+// are advertised through BGP speaker of any cluster member.
+func listRouters(s state.State, r *http.Request) response.Response {
+	routers, err := ovnBgp.AdvertisedRouters(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to list advertised logical routers: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	return response.SyncResponse(true, routers)
+}
+
+// advertiseRouter implements PUT method for /1.0/bgp/routers/{router}. It opts the user logical router
+// into route advertisement through BGP speaker of the target member.
+//
+// This will return a response which contains the advertised router.
+func advertiseRouter(s state.State, r *http.Request) response.Response {
+	routerName, err := url.PathUnescape(mux.Vars(r)["router"])
+	if err != nil {
+		logger.Errorf("Failed to get router: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	var requestData types.BgpRouterRequest
+	err = json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	err = types.ValidateRedistribute(requestData.Redistribute)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	hasBgp, err := node.HasServiceActive(r.Context(), s, types.SrvBgp)
+	if err != nil {
+		logger.Errorf("Failed to check if bgp is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasBgp {
+		return response.BadRequest(errors.New("'bgp' service is not enabled on this member"))
+	}
+
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "advertise_bgp_router", "node": s.Name(), "router": routerName},
+		"Advertising routes of logical router '%s' through BGP on node '%s'",
+		routerName,
+		s.Name(),
+	)
+
+	router, err := ovnBgp.AdvertiseRouter(r.Context(), s, routerName, requestData.Redistribute)
+	if err != nil {
+		logger.Errorf("Failed to advertise logical router '%s': %s", routerName, err)
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, router)
+}
+
+// withdrawRouter implements DELETE method for /1.0/bgp/routers/{router}. It stops advertising routes
+// of the user logical router, regardless of the member that advertises them.
+func withdrawRouter(s state.State, r *http.Request) response.Response {
+	routerName, err := url.PathUnescape(mux.Vars(r)["router"])
+	if err != nil {
+		logger.Errorf("Failed to get router: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "withdraw_bgp_router", "router": routerName},
+		"Withdrawing routes of logical router '%s' from BGP",
+		routerName,
+	)
+
+	err = ovnBgp.WithdrawRouter(r.Context(), s, routerName)
+	if err != nil {
+		logger.Errorf("Failed to withdraw logical router '%s': %s", routerName, err)
+		return response.InternalError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,111 @@
+// Package bgp provides the REST API endpoints for BGP integration.
+package bgp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	microTypes "github.com/canonical/microcluster/v3/microcluster/types"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	ovnBgp "github.com/canonical/microovn/microovn/bgp"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/node"
+)
+
+// StatusEndpoint defines endpoint for /1.0/bgp/status
+var StatusEndpoint = rest.Endpoint{
+	Path: "bgp/status",
+	Get:  rest.EndpointAction{Handler: getStatus, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// LocalStatusEndpoint defines endpoint for /1.0/bgp/status/local
+var LocalStatusEndpoint = rest.Endpoint{
+	Path: "bgp/status/local",
+	Get:  rest.EndpointAction{Handler: getLocalStatus, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// getLocalStatus implements GET method for /1.0/bgp/status/local. It returns state of BGP sessions
+// and routes learned by the BGP daemon on this MicroOVN member.
+func getLocalStatus(s state.State, r *http.Request) response.Response {
+	hasBgp, err := node.HasServiceActive(r.Context(), s, types.SrvBgp)
+	if err != nil {
+		logger.Errorf("Failed to check if bgp is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasBgp {
+		return response.BadRequest(errors.New("'bgp' service is not enabled on this member"))
+	}
+
+	return response.SyncResponse(true, ovnBgp.LocalStatus(r.Context(), s))
+}
+
+// getStatus implements GET method for /1.0/bgp/status. It returns state of BGP sessions and routes
+// learned by the BGP daemon from each MicroOVN member that has "bgp" service enabled. Members that
+// fail to respond are included in the response with an error message.
+func getStatus(s state.State, r *http.Request) response.Response {
+	bgpNodes, err := node.FindService(r.Context(), s, types.SrvBgp)
+	if err != nil {
+		logger.Errorf("Failed to find members with bgp service: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	status := types.BgpStatus{}
+	remoteNodes := make(map[string]string)
+	for _, bgpNode := range bgpNodes {
+		if bgpNode.Name == s.Name() {
+			status = append(status, ovnBgp.LocalStatus(r.Context(), s))
+			continue
+		}
+		remoteNodes[bgpNode.Address] = bgpNode.Name
+	}
+
+	if len(remoteNodes) == 0 {
+		return response.SyncResponse(true, status)
+	}
+
+	clusterClients, err := s.Connect().Cluster(false)
+	if err != nil {
+		logger.Errorf("Failed to get cluster clients: %v", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	var mu sync.Mutex
+	_ = clusterClients.Query(r.Context(), true, func(ctx context.Context, c microTypes.Client) error {
+		clientURL := c.URL()
+		clientAddr := fmt.Sprintf("%s:%s", clientURL.Hostname(), clientURL.Port())
+		mu.Lock()
+		member, ok := remoteNodes[clientAddr]
+		mu.Unlock()
+		if !ok {
+			return nil
+		}
+
+		memberStatus, err := microovnClient.GetLocalBgpStatus(ctx, c)
+		if err != nil {
+			logger.Errorf("Failed to get BGP status from member %s: %s", member, err)
+			memberStatus = types.BgpMemberStatus{Member: member, Error: err.Error()}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		status = append(status, memberStatus)
+		delete(remoteNodes, clientAddr)
+		return nil
+	})
+
+	// Members that could not be contacted at all
+	for _, member := range remoteNodes {
+		status = append(status, types.BgpMemberStatus{Member: member, Error: "member is not reachable"})
+	}
+
+	return response.SyncResponse(true, status)
+}
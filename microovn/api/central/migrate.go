@@ -0,0 +1,56 @@
+// Package central provides the REST API endpoints for management of OVN central services.
+package central
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn"
+)
+
+// MigrateInEndpoint defines endpoint for /1.0/central/migrate-in
+var MigrateInEndpoint = rest.Endpoint{
+	Path: "central/migrate-in",
+	Post: rest.EndpointAction{Handler: migrateIn, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// migrateIn implements POST method for /1.0/central/migrate-in. It seeds OVN central services on the
+// target member with the contents of an external OVN central cluster and enables "central" service on it.
+//
+// This will return a response which contains a WarningSet for the current desired state.
+func migrateIn(s state.State, r *http.Request) response.Response {
+	var requestData types.CentralMigrateInRequest
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	if requestData.NbRemote == "" || requestData.SbRemote == "" {
+		return response.BadRequest(errors.New("both Northbound and Southbound remotes are required"))
+	}
+
+	err = ovn.MigrateInCentral(r.Context(), s, requestData.NbRemote, requestData.SbRemote)
+	if err != nil {
+		logger.Errorf("Failed to migrate OVN central: %s", err)
+		return response.InternalError(err)
+	}
+
+	scr := types.ServiceControlResponse{}
+	scr.Warnings, err = node.ServiceWarnings(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to generate warnings for service: %s: %s", types.SrvCentral, err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+	scr.Message = "OVN central migrated"
+
+	return response.SyncResponse(true, scr)
+}
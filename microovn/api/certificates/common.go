@@ -28,10 +28,19 @@ func enabledOvnServices(ctx context.Context, s state.State) ([]string, error) {
 		wrappedError = errors.Join(wrappedError, fmt.Errorf("failed to lookup local services eligible for certificate refresh: %s", err))
 	}
 
+	hasRelay, err := node.HasServiceActive(ctx, s, types.SrvRelay)
+	if err != nil {
+		wrappedError = errors.Join(wrappedError, fmt.Errorf("failed to lookup local services eligible for certificate refresh: %s", err))
+	}
+
 	if hasCentral {
 		enabledServices = append(enabledServices, "ovnnb", "ovnsb", "ovn-northd")
 	}
 
+	if hasRelay {
+		enabledServices = append(enabledServices, "ovnsb-relay")
+	}
+
 	if hasSwitch {
 		enabledServices = append(enabledServices, "ovn-controller")
 	}
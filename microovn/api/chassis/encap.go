@@ -0,0 +1,98 @@
+package chassis
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/node"
+	ovnChassis "github.com/canonical/microovn/microovn/ovn/chassis"
+	"github.com/canonical/microovn/microovn/securitylog"
+)
+
+// EncapEndpoint defines endpoint for /1.0/chassis/encap
+var EncapEndpoint = rest.Endpoint{
+	Path: "chassis/encap",
+	Get:  rest.EndpointAction{Handler: getEncap, AllowUntrusted: false, ProxyTarget: true},
+	Put:  rest.EndpointAction{Handler: setEncap, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// requireChassis returns error response if the "chassis" service is not enabled on the local member.
+func requireChassis(s state.State, r *http.Request) response.Response {
+	hasChassis, err := node.HasServiceActive(r.Context(), s, types.SrvChassis)
+	if err != nil {
+		logger.Errorf("Failed to check if chassis is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasChassis {
+		return response.BadRequest(errors.New("'chassis' service is not enabled on this member"))
+	}
+	return nil
+}
+
+// getEncap implements GET method for /1.0/chassis/encap. It returns tunnel encapsulation of the chassis
+// of the target member.
+func getEncap(s state.State, r *http.Request) response.Response {
+	resp := requireChassis(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	encap, err := ovnChassis.GetEncap(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to get encapsulation of chassis: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	return response.SyncResponse(true, encap)
+}
+
+// setEncap implements PUT method for /1.0/chassis/encap. It changes tunnel encapsulation type and/or IPs
+// of the chassis of the target member.
+//
+// This will return a response which contains the resulting encapsulation of the chassis.
+func setEncap(s state.State, r *http.Request) response.Response {
+	var encap types.ChassisEncap
+	err := json.NewDecoder(r.Body).Decode(&encap)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	err = encap.Validate()
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if encap.Type == "" && len(encap.IPs) == 0 {
+		return response.BadRequest(errors.New("no encapsulation type or IP to set"))
+	}
+
+	resp := requireChassis(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "set_encap", "node": s.Name(), "type": encap.Type, "ips": encap.IPs},
+		"Changing encapsulation of chassis on node '%s'",
+		s.Name(),
+	)
+
+	encap, err = ovnChassis.SetEncap(r.Context(), s, encap)
+	if err != nil {
+		logger.Errorf("Failed to change encapsulation of chassis: %s", err)
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, encap)
+}
@@ -0,0 +1,117 @@
+// Package chassis provides the REST API endpoints for the OVN chassis configuration.
+package chassis
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/node"
+	ovnChassis "github.com/canonical/microovn/microovn/ovn/chassis"
+	"github.com/canonical/microovn/microovn/securitylog"
+)
+
+// GatewaysEndpoint defines endpoint for /1.0/chassis/gateways
+var GatewaysEndpoint = rest.Endpoint{
+	Path: "chassis/gateways",
+	Get:  rest.EndpointAction{Handler: listGateways, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// GatewayEndpoint defines endpoint for /1.0/chassis/gateway
+var GatewayEndpoint = rest.Endpoint{
+	Path:   "chassis/gateway",
+	Put:    rest.EndpointAction{Handler: enableGateway, AllowUntrusted: false, ProxyTarget: true},
+	Delete: rest.EndpointAction{Handler: disableGateway, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// listGateways implements GET method for /1.0/chassis/gateways. It returns gateway roles of all cluster
+// members that have "gateway" capability.
+func listGateways(s state.State, r *http.Request) response.Response {
+	gateways, err := ovnChassis.ListGateways(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to list gateway chassis: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	return response.SyncResponse(true, gateways)
+}
+
+// enableGateway implements PUT method for /1.0/chassis/gateway. It marks the chassis of the target member
+// as a gateway.
+//
+// This will return a response which contains the gateway role of the member.
+func enableGateway(s state.State, r *http.Request) response.Response {
+	var gateway types.ChassisGateway
+	err := json.NewDecoder(r.Body).Decode(&gateway)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	err = gateway.Validate()
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	hasChassis, err := node.HasServiceActive(r.Context(), s, types.SrvChassis)
+	if err != nil {
+		logger.Errorf("Failed to check if chassis is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasChassis {
+		return response.BadRequest(errors.New("'chassis' service is not enabled on this member"))
+	}
+
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "enable_gateway", "node": s.Name(), "priority": gateway.Priority},
+		"Enabling gateway role of chassis on node '%s'",
+		s.Name(),
+	)
+
+	gateway, err = ovnChassis.EnableGateway(r.Context(), s, gateway)
+	if err != nil {
+		logger.Errorf("Failed to enable gateway role of chassis: %s", err)
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, gateway)
+}
+
+// disableGateway implements DELETE method for /1.0/chassis/gateway. It stops marking the chassis of the
+// target member as a gateway.
+func disableGateway(s state.State, r *http.Request) response.Response {
+	hasGateway, err := node.HasServiceActive(r.Context(), s, types.SrvGateway)
+	if err != nil {
+		logger.Errorf("Failed to check if gateway is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasGateway {
+		return response.BadRequest(errors.New("chassis of this member is not a gateway"))
+	}
+
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "disable_gateway", "node": s.Name()},
+		"Disabling gateway role of chassis on node '%s'",
+		s.Name(),
+	)
+
+	err = ovnChassis.DisableGateway(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to disable gateway role of chassis: %s", err)
+		return response.InternalError(err)
+	}
+
+	return response.EmptySyncResponse
+}
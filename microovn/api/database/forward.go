@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	microTypes "github.com/canonical/microcluster/v3/microcluster/types"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/node"
+)
+
+// forwardQuery is a function that repeats the forwarded request using the client "c".
+type forwardQuery = func(ctx context.Context, c microTypes.Client) (any, error)
+
+// forwardToCentral forwards request to a host that runs "central" services. Each host that is
+// registered with "central" service is queried until one of them returns non-error response. First
+// successful response is returned to the caller.
+// If none of the "central" nodes return non-error message, this function returns response.ErrorResponse with code 500.
+func forwardToCentral(s state.State, r *http.Request, query forwardQuery) response.Response {
+	centralNodes, err := node.FindService(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to find central node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if len(centralNodes) == 0 {
+		return response.BadRequest(errors.New("no cluster member runs 'central' service"))
+	}
+
+	clusterClients, err := s.Connect().Cluster(false)
+	if err != nil {
+		logger.Errorf("Failed to get cluster clients: %v", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	for _, _client := range clusterClients {
+		for _, _node := range centralNodes {
+			clientURL := _client.URL()
+			clientAddr := fmt.Sprintf("%s:%s", clientURL.Hostname(), clientURL.Port())
+			if clientAddr != _node.Address {
+				continue
+			}
+
+			logger.Infof("Forwarding request '%s %s' to %s", r.Method, r.URL, _node.Name)
+			result, err := query(r.Context(), _client)
+			if err != nil {
+				logger.Errorf("Failed to forward request '%s %s' to node %s: %s", r.Method, r.URL, _node.Name, err)
+				continue
+			}
+			return response.SyncResponse(true, result)
+		}
+	}
+
+	logger.Error("None of the central nodes responded to the forwarded query")
+	return response.InternalError(errors.New("internal server error"))
+}
@@ -0,0 +1,75 @@
+// Package database provides the REST API endpoints for management of OVN central databases.
+package database
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	microTypes "github.com/canonical/microcluster/v3/microcluster/types"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn/raft"
+)
+
+// MembershipEndpoint defines endpoint for /1.0/database/membership
+var MembershipEndpoint = rest.Endpoint{
+	Path: "database/membership",
+	Get:  rest.EndpointAction{Handler: getMembership, AllowUntrusted: false, ProxyTarget: false},
+	Post: rest.EndpointAction{Handler: repairMembership, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// getMembership implements GET method for /1.0/database/membership. It returns RAFT servers of
+// OVN Northbound and Southbound databases and marks servers that do not belong to any member
+// with "central" service enabled as stale.
+func getMembership(s state.State, r *http.Request) response.Response {
+	return membership(s, r, false)
+}
+
+// repairMembership implements POST method for /1.0/database/membership. It kicks stale servers
+// from RAFT clusters of OVN Northbound and Southbound databases.
+func repairMembership(s state.State, r *http.Request) response.Response {
+	return membership(s, r, true)
+}
+
+// membership is a common implementation of the /1.0/database/membership endpoint. If the node
+// does not run "central" services, the request is forwarded to a node that does.
+func membership(s state.State, r *http.Request, repair bool) response.Response {
+	hasCentral, err := node.HasServiceActive(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to check if central is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasCentral {
+		logger.Info("This node does not run 'central' service. Request will be forwarded.")
+		return forwardMembership(s, r, repair)
+	}
+
+	report, err := raft.CheckMembership(r.Context(), s, repair)
+	if err != nil {
+		if errors.Is(err, raft.ErrExternalCentral) {
+			return response.BadRequest(err)
+		}
+		logger.Errorf("Failed to check RAFT membership of OVN central databases: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	return response.SyncResponse(true, &report)
+}
+
+// forwardMembership forwards membership request to a host that runs "central" services.
+func forwardMembership(s state.State, r *http.Request, repair bool) response.Response {
+	return forwardToCentral(s, r, func(ctx context.Context, c microTypes.Client) (any, error) {
+		if repair {
+			return microovnClient.RepairRaftMembership(ctx, c)
+		}
+		return microovnClient.GetRaftMembership(ctx, c)
+	})
+}
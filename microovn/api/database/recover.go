@@ -0,0 +1,42 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/raft"
+)
+
+// RecoverEndpoint defines endpoint for /1.0/database/recover
+var RecoverEndpoint = rest.Endpoint{
+	Path: "database/recover",
+	Post: rest.EndpointAction{Handler: recoverDatabase, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// recoverDatabase implements POST method for /1.0/database/recover. It re-creates OVN Northbound
+// and Southbound databases as single-member clusters on the target member and removes "central"
+// service from every other member. The request should be targeted at the member from which
+// the databases should be recovered.
+func recoverDatabase(s state.State, r *http.Request) response.Response {
+	var requestData types.DatabaseRecoverRequest
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	removedMembers, err := raft.RecoverCluster(r.Context(), s, requestData.Force)
+	if err != nil {
+		logger.Errorf("Failed to recover OVN central databases: %s", err)
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, types.DatabaseRecoverResponse{RemovedMembers: removedMembers})
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	microTypes "github.com/canonical/microcluster/v3/microcluster/types"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn/ovsdb"
+)
+
+// UpgradeEndpoint defines endpoint for /1.0/database/upgrade
+var UpgradeEndpoint = rest.Endpoint{
+	Path: "database/upgrade",
+	Get:  rest.EndpointAction{Handler: getUpgradeStatus, AllowUntrusted: false, ProxyTarget: false},
+	Put:  rest.EndpointAction{Handler: putUpgradeHold, AllowUntrusted: false, ProxyTarget: false},
+	Post: rest.EndpointAction{Handler: triggerUpgrade, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// getUpgradeStatus implements GET method for /1.0/database/upgrade. It returns schema upgrade status
+// of OVN central databases in the format of types.OvsdbUpgradeStatus. If the node does not run "central"
+// services, the request is forwarded to a node that does.
+func getUpgradeStatus(s state.State, r *http.Request) response.Response {
+	hasCentral, err := node.HasServiceActive(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to check if central is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasCentral {
+		logger.Info("This node does not run 'central' service. Request will be forwarded.")
+		return forwardToCentral(s, r, func(ctx context.Context, c microTypes.Client) (any, error) {
+			return microovnClient.GetOvsdbUpgradeStatus(ctx, c)
+		})
+	}
+
+	status, err := ovsdb.UpgradeStatus(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to get OVSDB schema upgrade status: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	return response.SyncResponse(true, &status)
+}
+
+// putUpgradeHold implements PUT method for /1.0/database/upgrade. It puts automatic schema upgrades
+// of OVN central databases on hold, or releases the hold.
+func putUpgradeHold(s state.State, r *http.Request) response.Response {
+	var requestData types.OvsdbUpgradeHoldRequest
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	err = ovsdb.SetUpgradeHold(r.Context(), s, requestData.Hold)
+	if err != nil {
+		logger.Errorf("Failed to set hold of OVSDB schema upgrades: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	return response.EmptySyncResponse
+}
+
+// triggerUpgrade implements POST method for /1.0/database/upgrade. It explicitly triggers schema
+// upgrade of OVN central databases and returns types.OvsdbUpgradeReport. If the node does not run
+// "central" services, the request is forwarded to a node that does.
+func triggerUpgrade(s state.State, r *http.Request) response.Response {
+	var requestData types.OvsdbUpgradeTriggerRequest
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	hasCentral, err := node.HasServiceActive(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to check if central is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	if !hasCentral {
+		logger.Info("This node does not run 'central' service. Request will be forwarded.")
+		return forwardToCentral(s, r, func(ctx context.Context, c microTypes.Client) (any, error) {
+			return microovnClient.TriggerOvsdbUpgrade(ctx, c, requestData.Force)
+		})
+	}
+
+	report, err := ovsdb.TriggerUpgrade(r.Context(), s, requestData.Force)
+	if err != nil {
+		logger.Errorf("Failed to trigger OVSDB schema upgrade: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	return response.SyncResponse(true, &report)
+}
@@ -3,7 +3,12 @@ package api
 
 import (
 	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microovn/microovn/api/bgp"
+	"github.com/canonical/microovn/microovn/api/central"
+	"github.com/canonical/microovn/microovn/api/chassis"
 	"github.com/canonical/microovn/microovn/api/config"
+	"github.com/canonical/microovn/microovn/api/database"
+	"github.com/canonical/microovn/microovn/api/networks"
 	"github.com/canonical/microovn/microovn/api/ovsdb"
 
 	"github.com/canonical/microovn/microovn/api/certificates"
@@ -30,6 +35,24 @@ var Server = map[string]rest.Server{
 					ovsdb.AllExpectedSchemaVersions,
 					ovsdb.ExpectedSchemaVersion,
 					config.ConfigEndoint,
+					database.MembershipEndpoint,
+					database.RecoverEndpoint,
+					database.UpgradeEndpoint,
+					central.MigrateInEndpoint,
+					bgp.ConfigEndpoint,
+					bgp.CheckEndpoint,
+					bgp.PreviewEndpoint,
+					bgp.StatusEndpoint,
+					bgp.LocalStatusEndpoint,
+					bgp.RoutersEndpoint,
+					bgp.RouterEndpoint,
+					networks.ExternalNetworksEndpoint,
+					networks.ExternalNetworkEndpoint,
+					networks.BridgeMappingsEndpoint,
+					networks.BridgeMappingEndpoint,
+					chassis.GatewaysEndpoint,
+					chassis.GatewayEndpoint,
+					chassis.EncapEndpoint,
 				},
 			},
 		},
@@ -2,6 +2,28 @@ package api
 
 var extensions = []string{
 	"custom_encapsulation_ip",
+	"raft_membership_repair",
+	"database_recover",
+	"database_upgrade_control",
+	"central_migrate_in",
+	"ovsdb_relay",
+	"bgp_status",
+	"bgp_config_update",
+	"bgp_config_persistence",
+	"bgp_explicit_neighbors",
+	"bgp_route_policy",
+	"bgp_routing_daemons",
+	"bgp_user_routers",
+	"bgp_allocations",
+	"bgp_graceful_restart",
+	"bgp_preflight_checks",
+	"bgp_evpn",
+	"bgp_network_backends",
+	"bgp_network_preview",
+	"external_networks",
+	"bridge_mappings",
+	"chassis_gateway",
+	"chassis_encap",
 }
 
 // Extensions returns the list of MicroOVN extensions.
@@ -0,0 +1,127 @@
+package networks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	"github.com/canonical/microcluster/v3/state"
+	"github.com/gorilla/mux"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/external"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/securitylog"
+)
+
+// BridgeMappingsEndpoint defines endpoint for /1.0/networks/bridge-mappings
+var BridgeMappingsEndpoint = rest.Endpoint{
+	Path: "networks/bridge-mappings",
+	Get:  rest.EndpointAction{Handler: listBridgeMappings, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// BridgeMappingEndpoint defines endpoint for /1.0/networks/bridge-mappings/{physnet}
+var BridgeMappingEndpoint = rest.Endpoint{
+	Path:   "networks/bridge-mappings/{physnet}",
+	Put:    rest.EndpointAction{Handler: setBridgeMapping, AllowUntrusted: false, ProxyTarget: true},
+	Delete: rest.EndpointAction{Handler: removeBridgeMapping, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// listBridgeMappings implements GET method for /1.0/networks/bridge-mappings. It returns bridge mappings
+// of all cluster members.
+func listBridgeMappings(s state.State, r *http.Request) response.Response {
+	mappings, err := external.ListBridgeMappings(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to list bridge mappings: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	return response.SyncResponse(true, mappings)
+}
+
+// setBridgeMapping implements PUT method for /1.0/networks/bridge-mappings/{physnet}. It maps physical
+// network to an OVS bridge on the target member. The mapping is applied immediately if "chassis" service
+// is active on the member, otherwise it's applied when the service is enabled.
+//
+// This will return a response which contains the stored mapping.
+func setBridgeMapping(s state.State, r *http.Request) response.Response {
+	physnet, err := url.PathUnescape(mux.Vars(r)["physnet"])
+	if err != nil {
+		logger.Errorf("Failed to get physical network: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	var mapping types.BridgeMapping
+	err = json.NewDecoder(r.Body).Decode(&mapping)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+	mapping.Physnet = physnet
+
+	err = mapping.Validate()
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	hasChassis, err := node.HasServiceActive(r.Context(), s, types.SrvChassis)
+	if err != nil {
+		logger.Errorf("Failed to check if chassis is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "set_bridge_mapping", "node": s.Name(), "physnet": physnet, "bridge": mapping.Bridge},
+		"Mapping physical network '%s' to bridge '%s' on node '%s'",
+		physnet,
+		mapping.Bridge,
+		s.Name(),
+	)
+
+	mapping, err = external.SetBridgeMapping(r.Context(), s, mapping, hasChassis)
+	if err != nil {
+		logger.Errorf("Failed to set bridge mapping of physical network '%s': %s", physnet, err)
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, mapping)
+}
+
+// removeBridgeMapping implements DELETE method for /1.0/networks/bridge-mappings/{physnet}. It removes
+// mapping of the physical network from the target member.
+func removeBridgeMapping(s state.State, r *http.Request) response.Response {
+	physnet, err := url.PathUnescape(mux.Vars(r)["physnet"])
+	if err != nil {
+		logger.Errorf("Failed to get physical network: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	hasChassis, err := node.HasServiceActive(r.Context(), s, types.SrvChassis)
+	if err != nil {
+		logger.Errorf("Failed to check if chassis is active on this node: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "remove_bridge_mapping", "node": s.Name(), "physnet": physnet},
+		"Removing mapping of physical network '%s' on node '%s'",
+		physnet,
+		s.Name(),
+	)
+
+	err = external.RemoveBridgeMapping(r.Context(), s, physnet, hasChassis)
+	if err != nil {
+		logger.Errorf("Failed to remove bridge mapping of physical network '%s': %s", physnet, err)
+		return response.InternalError(err)
+	}
+
+	return response.EmptySyncResponse
+}
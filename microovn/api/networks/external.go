@@ -0,0 +1,193 @@
+// Package networks provides the REST API endpoints for networks managed by MicroOVN.
+package networks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/microcluster/rest"
+	"github.com/canonical/microcluster/v3/microcluster/rest/response"
+	microTypes "github.com/canonical/microcluster/v3/microcluster/types"
+	"github.com/canonical/microcluster/v3/state"
+	"github.com/gorilla/mux"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/external"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/securitylog"
+)
+
+// ExternalNetworksEndpoint defines endpoint for /1.0/networks/external
+var ExternalNetworksEndpoint = rest.Endpoint{
+	Path: "networks/external",
+	Get:  rest.EndpointAction{Handler: listExternalNetworks, AllowUntrusted: false, ProxyTarget: false},
+	Post: rest.EndpointAction{Handler: addExternalNetwork, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// ExternalNetworkEndpoint defines endpoint for /1.0/networks/external/{name}
+var ExternalNetworkEndpoint = rest.Endpoint{
+	Path:   "networks/external/{name}",
+	Delete: rest.EndpointAction{Handler: removeExternalNetwork, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// listExternalNetworks implements GET method for /1.0/networks/external. It returns external networks
+// defined in the cluster.
+func listExternalNetworks(s state.State, r *http.Request) response.Response {
+	networks, err := external.List(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to list external networks: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	return response.SyncResponse(true, networks)
+}
+
+// applyLocally runs "apply" if "chassis" service is active on this member. Members without "chassis"
+// service have no use for external networks.
+func applyLocally(ctx context.Context, s state.State, apply func(ctx context.Context, s state.State) error) error {
+	hasChassis, err := node.HasServiceActive(ctx, s, types.SrvChassis)
+	if err != nil {
+		return fmt.Errorf("failed to check if chassis is active on this node: %w", err)
+	}
+	if !hasChassis {
+		return nil
+	}
+	return apply(ctx, s)
+}
+
+// notifyCluster forwards request to the rest of the cluster members with "notify" and returns list of
+// errors from members that failed to process it.
+func notifyCluster(ctx context.Context, s state.State, notify func(ctx context.Context, c microTypes.Client) error) ([]string, error) {
+	cluster, err := s.Connect().Cluster(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a client for every cluster member: %w", err)
+	}
+
+	var mu sync.Mutex
+	var memberErrors []string
+	err = cluster.Query(ctx, true, func(ctx context.Context, c microTypes.Client) error {
+		clientURL := c.URL()
+		err := notify(ctx, c)
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			memberErrors = append(memberErrors, fmt.Sprintf("Failed to apply change on cluster member with address %q: %s", clientURL.String(), err))
+		}
+		return nil
+	})
+	return memberErrors, err
+}
+
+// addExternalNetwork implements POST method for /1.0/networks/external. The initial member records the
+// external network in the cluster and notifies other members, each member then sets up the network
+// locally if it runs "chassis" service.
+//
+// This will return a response which contains the added network.
+func addExternalNetwork(s state.State, r *http.Request) response.Response {
+	var network types.ExternalNetwork
+	err := json.NewDecoder(r.Body).Decode(&network)
+	if err != nil {
+		logger.Errorf("Failed to decode request body: %s", err)
+		return response.BadRequest(errors.New("failed to decode request"))
+	}
+
+	responseData := types.ExternalNetworkResponse{Network: network}
+	if !microTypes.IsNotification(r) {
+		err = network.Validate()
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		securitylog.Log(
+			securitylog.CatAuthz,
+			securitylog.EventAdminActivity,
+			logger.Ctx{"action": "add_external_network", "network": network.Name, "interface": network.Interface},
+			"Adding external network '%s' on interface '%s'",
+			network.Name,
+			network.Interface,
+		)
+
+		responseData.Network, err = external.Add(r.Context(), s, network)
+		if err != nil {
+			logger.Errorf("Failed to add external network '%s': %s", network.Name, err)
+			return response.InternalError(err)
+		}
+
+		responseData.Errors, err = notifyCluster(r.Context(), s, func(ctx context.Context, c microTypes.Client) error {
+			_, err := microovnClient.AddExternalNetwork(ctx, c, responseData.Network)
+			return err
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	err = applyLocally(r.Context(), s, func(ctx context.Context, s state.State) error {
+		return external.SetupLocal(ctx, s, responseData.Network)
+	})
+	if err != nil {
+		logger.Errorf("Failed to set up external network '%s': %s", network.Name, err)
+		if microTypes.IsNotification(r) {
+			return response.InternalError(err)
+		}
+		responseData.Errors = append(responseData.Errors, fmt.Sprintf("Failed to apply change on cluster member %q: %s", s.Name(), err))
+	}
+
+	return response.SyncResponse(true, responseData)
+}
+
+// removeExternalNetwork implements DELETE method for /1.0/networks/external/{name}. The initial member
+// removes the external network from the cluster and notifies other members, each member then removes
+// the network locally.
+func removeExternalNetwork(s state.State, r *http.Request) response.Response {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		logger.Errorf("Failed to get network: %s", err)
+		return response.InternalError(errors.New("internal server error"))
+	}
+
+	responseData := types.ExternalNetworkResponse{Network: types.ExternalNetwork{Name: name}}
+	if !microTypes.IsNotification(r) {
+		securitylog.Log(
+			securitylog.CatAuthz,
+			securitylog.EventAdminActivity,
+			logger.Ctx{"action": "remove_external_network", "network": name},
+			"Removing external network '%s'",
+			name,
+		)
+
+		err = external.Remove(r.Context(), s, name)
+		if err != nil {
+			logger.Errorf("Failed to remove external network '%s': %s", name, err)
+			return response.InternalError(err)
+		}
+
+		responseData.Errors, err = notifyCluster(r.Context(), s, func(ctx context.Context, c microTypes.Client) error {
+			_, err := microovnClient.RemoveExternalNetwork(ctx, c, name)
+			return err
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	err = applyLocally(r.Context(), s, func(ctx context.Context, s state.State) error {
+		return external.TeardownLocal(ctx, s, name)
+	})
+	if err != nil {
+		logger.Errorf("Failed to tear down external network '%s': %s", name, err)
+		if microTypes.IsNotification(r) {
+			return response.InternalError(err)
+		}
+		responseData.Errors = append(responseData.Errors, fmt.Sprintf("Failed to apply change on cluster member %q: %s", s.Name(), err))
+	}
+
+	return response.SyncResponse(true, responseData)
+}
@@ -1,7 +1,6 @@
 package ovsdb
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -11,7 +10,6 @@ import (
 	"github.com/canonical/lxd/shared/logger"
 	"github.com/canonical/microcluster/v3/microcluster/rest"
 	"github.com/canonical/microcluster/v3/microcluster/rest/response"
-	microTypes "github.com/canonical/microcluster/v3/microcluster/types"
 	"github.com/canonical/microcluster/v3/state"
 	"github.com/gorilla/mux"
 
@@ -76,42 +74,12 @@ func getAllExpectedSchemaVersions(s state.State, r *http.Request) response.Respo
 		return errResponse
 	}
 
-	// Get local expected schema version and store it in the final result
-	localExpectedVersion, err := ovsdb.ExpectedOvsdbSchemaVersion(r.Context(), s, dbSpec)
+	responseData, err := ovsdb.MemberSchemaVersions(r.Context(), s, dbSpec)
 	if err != nil {
-		logger.Errorf("Failed to get expected OVSDB schema version for '%s' database: %s", dbSpec.FriendlyName, err)
-		return response.InternalError(errors.New("internal server error"))
-	}
-
-	responseData := types.OvsdbSchemaReport{
-		types.OvsdbSchemaVersionResult{
-			SchemaVersion: localExpectedVersion,
-			Host:          s.Address().Hostname(),
-			Error:         types.OvsdbSchemaFetchErrorNone,
-		},
-	}
-
-	// Get clients for each member in the cluster
-	clusterClient, err := s.Connect().Cluster(false)
-	if err != nil {
-		logger.Errorf("Failed to get a client for every cluster member: %s", err)
+		logger.Errorf("Failed to get expected OVSDB schema versions for '%s' database: %s", dbSpec.FriendlyName, err)
 		return response.InternalError(errors.New("internal server error"))
 	}
 
-	// Fetch expected schema versions from each cluster member.
-	_ = clusterClient.Query(r.Context(), true, func(ctx context.Context, c microTypes.Client) error {
-		clientURL := c.URL()
-		logger.Debugf("Fetching expected OVN %s schema version from '%s'", dbSpec.FriendlyName, clientURL.String())
-		nodeStatus := types.OvsdbSchemaVersionResult{Host: clientURL.Hostname()}
-
-		result, responseSuccess := microovnClient.GetExpectedOvsdbSchemaVersion(ctx, c, dbSpec)
-		nodeStatus.Error = responseSuccess
-		nodeStatus.SchemaVersion = result
-
-		responseData = append(responseData, nodeStatus)
-		return nil
-	})
-
 	return response.SyncResponse(true, &responseData)
 }
 
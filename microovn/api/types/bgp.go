@@ -0,0 +1,870 @@
+package types
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BgpProtocolStatus describes state of a single BGP protocol instance of the BGP daemon.
+type BgpProtocolStatus struct {
+	Name            string `json:"name" yaml:"name"`                         // Name of the protocol instance (e.g. "microovn_eth1")
+	State           string `json:"state" yaml:"state"`                       // State of the protocol instance (e.g. "up", "start")
+	Since           string `json:"since" yaml:"since"`                       // Time of the last state change
+	Info            string `json:"info" yaml:"info"`                         // Additional information, usually state of the BGP session (e.g. "Established")
+	NeighborAddress string `json:"neighbor_address" yaml:"neighbor_address"` // Address of the BGP neighbor, if known
+	NeighborAs      string `json:"neighbor_as" yaml:"neighbor_as"`           // AS number of the BGP neighbor, if known
+	ImportedRoutes  int    `json:"imported_routes" yaml:"imported_routes"`   // Number of prefixes received from the neighbor
+	ExportedRoutes  int    `json:"exported_routes" yaml:"exported_routes"`   // Number of prefixes exported to the neighbor
+}
+
+// BgpRoute describes a single route learned by the BGP daemon into the VRF table.
+type BgpRoute struct {
+	Prefix    string `json:"prefix" yaml:"prefix"`
+	Gateway   string `json:"gateway" yaml:"gateway"`
+	Interface string `json:"interface" yaml:"interface"`
+}
+
+// BgpMemberStatus describes state of BGP integration on a single MicroOVN cluster member.
+type BgpMemberStatus struct {
+	Member    string              `json:"member" yaml:"member"`
+	Protocols []BgpProtocolStatus `json:"protocols" yaml:"protocols"`
+	Routes    []BgpRoute          `json:"routes" yaml:"routes"`
+	Error     string              `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// BgpStatus is a list of BGP integration states of each MicroOVN cluster member that has "bgp"
+// service enabled.
+type BgpStatus []BgpMemberStatus
+
+// BgpMemberConfig describes BGP configuration of a single MicroOVN cluster member.
+type BgpMemberConfig struct {
+	Member string `json:"member" yaml:"member"`
+	// Config is BGP configuration applied on the member, with auto-selected values resolved. It is
+	// nil if the configuration is not known, e.g. because BGP was enabled without extra config.
+	Config   *ExtraBgpConfig `json:"config" yaml:"config"`
+	RouterID string          `json:"router_id,omitempty" yaml:"router_id,omitempty"`
+}
+
+// BgpConfigs is a list of BGP configurations of each MicroOVN cluster member that has "bgp" service
+// enabled.
+type BgpConfigs []BgpMemberConfig
+
+// Names of per-connection BGP options. In the BGP configuration, they are prefixed with the name of
+// the external connection's interface, e.g. "eth1.neighbor".
+const (
+	BgpPeerOptionNeighbor      = "neighbor"
+	BgpPeerOptionPeerAsn       = "peer_asn"
+	BgpPeerOptionPassword      = "password"
+	BgpPeerOptionAuth          = "auth"
+	BgpPeerOptionHoldTime      = "hold_time"
+	BgpPeerOptionKeepaliveTime = "keepalive_time"
+	BgpPeerOptionBfdMinRx      = "bfd_min_rx"
+	BgpPeerOptionBfdMinTx      = "bfd_min_tx"
+	BgpPeerOptionBfdMultiplier = "bfd_multiplier"
+)
+
+// Supported routing daemons that run BGP sessions.
+const (
+	BgpDaemonBird = "bird"
+	BgpDaemonFrr  = "frr"
+)
+
+// Supported backends that create network interfaces of the BGP redirect.
+const (
+	NetworkBackendNetplan = "netplan"
+	NetworkBackendNetlink = "netlink"
+)
+
+// Supported methods of BGP session authentication.
+const (
+	BgpAuthMd5 = "md5"
+	BgpAuthAo  = "ao"
+)
+
+// BgpRedactedSecret replaces secrets, like BGP session passwords, in API responses.
+const BgpRedactedSecret = "********"
+
+// bgpOptionSection is implemented by pointers to sections of the BGP configuration, like route policy,
+// whose options are all strings.
+type bgpOptionSection[T any] interface {
+	*T
+	// fields returns pointers to the fields that hold options of the section, keyed by the option name
+	fields() map[string]*string
+}
+
+// lookupBgpOption returns pointer to the field that holds option "name" of "kind" (e.g. "BGP policy").
+func lookupBgpOption(fields map[string]*string, kind string, name string) (*string, error) {
+	option, ok := fields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown %s option: %s", kind, name)
+	}
+	return option, nil
+}
+
+// setBgpOptions returns options that are set, keyed by the option name.
+func setBgpOptions(fields map[string]*string) map[string]string {
+	options := make(map[string]string)
+	for name, value := range fields {
+		if *value != "" {
+			options[name] = *value
+		}
+	}
+	return options
+}
+
+// mergeBgpOptions returns options that result from applying options set in "update" on top of "current".
+func mergeBgpOptions[T any, P bgpOptionSection[T]](current T, update T) T {
+	merged := current
+	mergedFields := P(&merged).fields()
+	for name, value := range setBgpOptions(P(&update).fields()) {
+		*mergedFields[name] = value
+	}
+	return merged
+}
+
+// unsetBgpOption clears option "name" of "kind" (e.g. "BGP policy").
+func unsetBgpOption(fields map[string]*string, kind string, name string) error {
+	option, err := lookupBgpOption(fields, kind, name)
+	if err != nil {
+		return err
+	}
+	*option = ""
+	return nil
+}
+
+// BgpPeerConfig holds peering options of a single external connection. Unset options use defaults
+// of the BGP daemon, without explicit neighbor, BGP peers are discovered dynamically.
+type BgpPeerConfig struct {
+	// Neighbor is an address of the BGP neighbor
+	Neighbor string `json:"neighbor,omitempty" yaml:"neighbor,omitempty"`
+	// PeerAsn is an AS number of the BGP neighbor, or one of "external" (default) and "internal"
+	PeerAsn string `json:"peer_asn,omitempty" yaml:"peer_asn,omitempty"`
+	// Password is used to authenticate the BGP session. It's stored in the cluster database encrypted
+	// with the cluster key.
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
+	// Auth is the session authentication method, "md5" (default) or "ao" (TCP-AO)
+	Auth string `json:"auth,omitempty" yaml:"auth,omitempty"`
+	// HoldTime is the BGP hold time in seconds
+	HoldTime string `json:"hold_time,omitempty" yaml:"hold_time,omitempty"`
+	// KeepaliveTime is the BGP keepalive time in seconds
+	KeepaliveTime string `json:"keepalive_time,omitempty" yaml:"keepalive_time,omitempty"`
+	// BfdMinRx is the minimal BFD receive interval in milliseconds
+	BfdMinRx string `json:"bfd_min_rx,omitempty" yaml:"bfd_min_rx,omitempty"`
+	// BfdMinTx is the minimal BFD transmit interval in milliseconds
+	BfdMinTx string `json:"bfd_min_tx,omitempty" yaml:"bfd_min_tx,omitempty"`
+	// BfdMultiplier is the number of missed BFD packets after which the session is declared down
+	BfdMultiplier string `json:"bfd_multiplier,omitempty" yaml:"bfd_multiplier,omitempty"`
+}
+
+// fields returns pointers to the fields that hold per-connection options, keyed by the option name.
+func (p *BgpPeerConfig) fields() map[string]*string {
+	return map[string]*string{
+		BgpPeerOptionNeighbor:      &p.Neighbor,
+		BgpPeerOptionPeerAsn:       &p.PeerAsn,
+		BgpPeerOptionPassword:      &p.Password,
+		BgpPeerOptionAuth:          &p.Auth,
+		BgpPeerOptionHoldTime:      &p.HoldTime,
+		BgpPeerOptionKeepaliveTime: &p.KeepaliveTime,
+		BgpPeerOptionBfdMinRx:      &p.BfdMinRx,
+		BgpPeerOptionBfdMinTx:      &p.BfdMinTx,
+		BgpPeerOptionBfdMultiplier: &p.BfdMultiplier,
+	}
+}
+
+// option returns pointer to the field that holds per-connection option "name".
+func (p *BgpPeerConfig) option(name string) (*string, error) {
+	return lookupBgpOption(p.fields(), "per-connection BGP config", name)
+}
+
+// options returns per-connection options that are set, keyed by the option name.
+func (p BgpPeerConfig) options() map[string]string {
+	return setBgpOptions(p.fields())
+}
+
+// Merge returns peering options that result from applying options set in "update" on top of "p".
+func (p BgpPeerConfig) Merge(update BgpPeerConfig) BgpPeerConfig {
+	return mergeBgpOptions(p, update)
+}
+
+// Unset clears per-connection option "name".
+func (p *BgpPeerConfig) Unset(name string) error {
+	return unsetBgpOption(p.fields(), "per-connection BGP config", name)
+}
+
+// IsEmpty returns "true" if no per-connection option is set.
+func (p BgpPeerConfig) IsEmpty() bool {
+	return len(p.options()) == 0
+}
+
+// validateUint validates that "value" of option "name" is a number within the range [min, max].
+func validateUint(name string, value string, min uint64, max uint64) error {
+	number, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || number < min || number > max {
+		return fmt.Errorf("option '%s' must be a number between %d and %d: %s", name, min, max, value)
+	}
+	return nil
+}
+
+// Validate ensures that peering options of the external connection "iface" have correct values.
+func (p BgpPeerConfig) Validate(iface string) error {
+	if p.Neighbor != "" {
+		_, err := netip.ParseAddr(p.Neighbor)
+		if err != nil {
+			return fmt.Errorf("option '%s.%s' is not a valid IP address: %s", iface, BgpPeerOptionNeighbor, p.Neighbor)
+		}
+	}
+
+	if p.PeerAsn != "" && p.PeerAsn != "external" && p.PeerAsn != "internal" {
+		err := validateUint(iface+"."+BgpPeerOptionPeerAsn, p.PeerAsn, 1, 4294967295)
+		if err != nil {
+			return fmt.Errorf("%w (or 'external'/'internal')", err)
+		}
+	}
+
+	if p.Auth != "" && p.Auth != BgpAuthMd5 && p.Auth != BgpAuthAo {
+		return fmt.Errorf("option '%s.%s' must be one of '%s', '%s': %s", iface, BgpPeerOptionAuth, BgpAuthMd5, BgpAuthAo, p.Auth)
+	}
+
+	if p.Auth != "" && p.Password == "" {
+		return fmt.Errorf("option '%s.%s' requires '%s.%s' to be set", iface, BgpPeerOptionAuth, iface, BgpPeerOptionPassword)
+	}
+
+	// Redacted password from the configuration returned by the API would replace the real password
+	if p.Password == BgpRedactedSecret {
+		return fmt.Errorf("option '%s.%s' must not be the redacted placeholder '%s'", iface, BgpPeerOptionPassword, BgpRedactedSecret)
+	}
+
+	// Password is rendered as a quoted string in the BGP daemon configuration
+	if strings.ContainsAny(p.Password, "\"\\\n") {
+		return fmt.Errorf("option '%s.%s' must not contain quotes, backslashes or newlines", iface, BgpPeerOptionPassword)
+	}
+
+	// Hold time of 0 disables keepalive messages, otherwise it has to be at least 3 seconds
+	if p.HoldTime != "" && p.HoldTime != "0" {
+		err := validateUint(iface+"."+BgpPeerOptionHoldTime, p.HoldTime, 3, 65535)
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.KeepaliveTime != "" {
+		err := validateUint(iface+"."+BgpPeerOptionKeepaliveTime, p.KeepaliveTime, 1, 65535)
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, value := range map[string]string{BgpPeerOptionBfdMinRx: p.BfdMinRx, BgpPeerOptionBfdMinTx: p.BfdMinTx} {
+		if value == "" {
+			continue
+		}
+		err := validateUint(iface+"."+name, value, 1, 60000)
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.BfdMultiplier != "" {
+		err := validateUint(iface+"."+BgpPeerOptionBfdMultiplier, p.BfdMultiplier, 1, 255)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BgpConfigUpdateRequest is a request to change BGP configuration of a cluster member.
+type BgpConfigUpdateRequest struct {
+	// Config contains options that should be changed, options that are not set keep their current value
+	Config ExtraBgpConfig `json:"config" yaml:"config"`
+	// Unset contains per-connection (e.g. "eth1.password"), route policy, graceful restart and EVPN options
+	// that should be cleared
+	Unset []string `json:"unset,omitempty" yaml:"unset,omitempty"`
+}
+
+// BgpNetworkDiff is a change of network configuration "Name" that would be applied on a cluster member.
+type BgpNetworkDiff struct {
+	// Name of the network configuration, e.g. the netplan file
+	Name string `json:"name" yaml:"name"`
+	// Diff contains lines that would be removed ("-"), added ("+") or kept (" ")
+	Diff string `json:"diff" yaml:"diff"`
+}
+
+// BgpConfigPreview describes changes that an update of BGP configuration would make on a cluster member,
+// without applying them.
+type BgpConfigPreview struct {
+	Member string `json:"member" yaml:"member"`
+	// Config is the BGP configuration that would result from the update
+	Config ExtraBgpConfig `json:"config" yaml:"config"`
+	// Network contains changes of network configuration, it's empty if the network configuration
+	// would not change
+	Network []BgpNetworkDiff `json:"network" yaml:"network"`
+}
+
+// Names of BGP route policy options.
+const (
+	BgpPolicyOptionImportAllow        = "import_allow"
+	BgpPolicyOptionImportDeny         = "import_deny"
+	BgpPolicyOptionExportAllow        = "export_allow"
+	BgpPolicyOptionExportDeny         = "export_deny"
+	BgpPolicyOptionAcceptDefaultRoute = "accept_default_route"
+	BgpPolicyOptionExportCommunities  = "export_communities"
+	BgpPolicyOptionExportLocalPref    = "export_local_pref"
+	BgpPolicyOptionExportPrepend      = "export_as_path_prepend"
+)
+
+// BgpPolicyConfig holds route policy applied to routes imported from and exported to BGP peers of
+// a cluster member. Prefix lists are comma-separated lists of CIDRs, each of them matches the prefix
+// itself and all of its more specific prefixes.
+type BgpPolicyConfig struct {
+	// ImportAllow lists prefixes that are accepted from BGP peers, other prefixes are rejected
+	ImportAllow string `json:"import_allow,omitempty" yaml:"import_allow,omitempty"`
+	// ImportDeny lists prefixes that are rejected from BGP peers
+	ImportDeny string `json:"import_deny,omitempty" yaml:"import_deny,omitempty"`
+	// ExportAllow lists prefixes that are announced to BGP peers, other prefixes are not announced
+	ExportAllow string `json:"export_allow,omitempty" yaml:"export_allow,omitempty"`
+	// ExportDeny lists prefixes that are not announced to BGP peers
+	ExportDeny string `json:"export_deny,omitempty" yaml:"export_deny,omitempty"`
+	// AcceptDefaultRoute controls whether default route is accepted from BGP peers (default "true")
+	AcceptDefaultRoute string `json:"accept_default_route,omitempty" yaml:"accept_default_route,omitempty"`
+	// ExportCommunities is a comma-separated list of standard ("asn:value") or large
+	// ("asn:value:value") BGP communities attached to announced prefixes
+	ExportCommunities string `json:"export_communities,omitempty" yaml:"export_communities,omitempty"`
+	// ExportLocalPref is the local preference attached to prefixes announced to internal peers
+	ExportLocalPref string `json:"export_local_pref,omitempty" yaml:"export_local_pref,omitempty"`
+	// ExportPrepend is the number of times the local ASN is prepended to the AS path of announced
+	// prefixes, making this member less preferred by the peers
+	ExportPrepend string `json:"export_as_path_prepend,omitempty" yaml:"export_as_path_prepend,omitempty"`
+}
+
+// fields returns pointers to the fields that hold route policy options, keyed by the option name.
+func (p *BgpPolicyConfig) fields() map[string]*string {
+	return map[string]*string{
+		BgpPolicyOptionImportAllow:        &p.ImportAllow,
+		BgpPolicyOptionImportDeny:         &p.ImportDeny,
+		BgpPolicyOptionExportAllow:        &p.ExportAllow,
+		BgpPolicyOptionExportDeny:         &p.ExportDeny,
+		BgpPolicyOptionAcceptDefaultRoute: &p.AcceptDefaultRoute,
+		BgpPolicyOptionExportCommunities:  &p.ExportCommunities,
+		BgpPolicyOptionExportLocalPref:    &p.ExportLocalPref,
+		BgpPolicyOptionExportPrepend:      &p.ExportPrepend,
+	}
+}
+
+// option returns pointer to the field that holds route policy option "name".
+func (p *BgpPolicyConfig) option(name string) (*string, error) {
+	return lookupBgpOption(p.fields(), "BGP policy", name)
+}
+
+// options returns route policy options that are set, keyed by the option name.
+func (p BgpPolicyConfig) options() map[string]string {
+	return setBgpOptions(p.fields())
+}
+
+// Merge returns route policy that results from applying options set in "update" on top of "p".
+func (p BgpPolicyConfig) Merge(update BgpPolicyConfig) BgpPolicyConfig {
+	return mergeBgpOptions(p, update)
+}
+
+// Unset clears route policy option "name".
+func (p *BgpPolicyConfig) Unset(name string) error {
+	return unsetBgpOption(p.fields(), "BGP policy", name)
+}
+
+// AcceptsDefaultRoute returns "true" if default route should be accepted from BGP peers.
+func (p BgpPolicyConfig) AcceptsDefaultRoute() bool {
+	accept, err := strconv.ParseBool(p.AcceptDefaultRoute)
+	return err != nil || accept
+}
+
+// ParsePrefixList parses a comma-separated list of CIDRs, e.g. value of the "import_allow" option.
+func ParsePrefixList(value string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	if value == "" {
+		return prefixes, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(item))
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid CIDR", item)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// BgpCommunity is a standard (RFC 1997) or large (RFC 8092) BGP community.
+type BgpCommunity []uint32
+
+// IsLarge returns "true" if the community is a large BGP community.
+func (c BgpCommunity) IsLarge() bool {
+	return len(c) == 3
+}
+
+// ParseCommunities parses a comma-separated list of standard ("asn:value") and large ("asn:value:value")
+// BGP communities.
+func ParseCommunities(value string) ([]BgpCommunity, error) {
+	var communities []BgpCommunity
+	if value == "" {
+		return communities, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) != 2 && len(parts) != 3 {
+			return nil, fmt.Errorf("'%s' is not a valid BGP community, expected 'asn:value' or 'asn:value:value'", item)
+		}
+
+		// Parts of standard communities are 16-bit numbers, parts of large communities are 32-bit
+		bitSize := 16
+		if len(parts) == 3 {
+			bitSize = 32
+		}
+
+		community := make(BgpCommunity, 0, len(parts))
+		for _, part := range parts {
+			number, err := strconv.ParseUint(part, 10, bitSize)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' is not a valid BGP community, parts must be %d-bit numbers", item, bitSize)
+			}
+			community = append(community, uint32(number))
+		}
+		communities = append(communities, community)
+	}
+
+	return communities, nil
+}
+
+// Validate ensures that route policy options have correct values.
+func (p BgpPolicyConfig) Validate() error {
+	for name, value := range map[string]string{
+		BgpPolicyOptionImportAllow: p.ImportAllow,
+		BgpPolicyOptionImportDeny:  p.ImportDeny,
+		BgpPolicyOptionExportAllow: p.ExportAllow,
+		BgpPolicyOptionExportDeny:  p.ExportDeny,
+	} {
+		_, err := ParsePrefixList(value)
+		if err != nil {
+			return fmt.Errorf("option '%s' is not valid: %w", name, err)
+		}
+	}
+
+	if p.AcceptDefaultRoute != "" {
+		_, err := strconv.ParseBool(p.AcceptDefaultRoute)
+		if err != nil {
+			return fmt.Errorf("option '%s' must be 'true' or 'false': %s", BgpPolicyOptionAcceptDefaultRoute, p.AcceptDefaultRoute)
+		}
+	}
+
+	_, err := ParseCommunities(p.ExportCommunities)
+	if err != nil {
+		return fmt.Errorf("option '%s' is not valid: %w", BgpPolicyOptionExportCommunities, err)
+	}
+
+	if p.ExportLocalPref != "" {
+		err = validateUint(BgpPolicyOptionExportLocalPref, p.ExportLocalPref, 0, 4294967295)
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.ExportPrepend != "" {
+		err = validateUint(BgpPolicyOptionExportPrepend, p.ExportPrepend, 0, 10)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Names of BGP graceful restart options.
+const (
+	BgpGracefulRestartOptionEnabled   = "graceful_restart"
+	BgpGracefulRestartOptionTime      = "graceful_restart_time"
+	BgpGracefulRestartOptionLongLived = "long_lived_graceful_restart"
+	BgpGracefulRestartOptionStaleTime = "long_lived_stale_time"
+)
+
+// Default graceful restart timers, in seconds, used when graceful restart is enabled without them.
+const (
+	BgpDefaultGracefulRestartTime = "120"
+	BgpDefaultLongLivedStaleTime  = "3600"
+)
+
+// BgpGracefulRestartConfig holds BGP graceful restart (RFC 4724) and long-lived graceful restart
+// (RFC 9494) options of a cluster member. With graceful restart, BGP peers keep forwarding traffic
+// using routes learned from the member while its routing daemon restarts.
+type BgpGracefulRestartConfig struct {
+	// Enabled controls whether graceful restart is negotiated with BGP peers (default "false")
+	Enabled string `json:"graceful_restart,omitempty" yaml:"graceful_restart,omitempty"`
+	// RestartTime is the time, in seconds, that peers keep routes of the member while it restarts
+	RestartTime string `json:"graceful_restart_time,omitempty" yaml:"graceful_restart_time,omitempty"`
+	// LongLived controls whether long-lived graceful restart is negotiated with BGP peers (default "false")
+	LongLived string `json:"long_lived_graceful_restart,omitempty" yaml:"long_lived_graceful_restart,omitempty"`
+	// StaleTime is the time, in seconds, that peers keep stale routes of the member after the restart
+	// time expires
+	StaleTime string `json:"long_lived_stale_time,omitempty" yaml:"long_lived_stale_time,omitempty"`
+}
+
+// fields returns pointers to the fields that hold graceful restart options, keyed by the option name.
+func (g *BgpGracefulRestartConfig) fields() map[string]*string {
+	return map[string]*string{
+		BgpGracefulRestartOptionEnabled:   &g.Enabled,
+		BgpGracefulRestartOptionTime:      &g.RestartTime,
+		BgpGracefulRestartOptionLongLived: &g.LongLived,
+		BgpGracefulRestartOptionStaleTime: &g.StaleTime,
+	}
+}
+
+// option returns pointer to the field that holds graceful restart option "name".
+func (g *BgpGracefulRestartConfig) option(name string) (*string, error) {
+	return lookupBgpOption(g.fields(), "BGP graceful restart", name)
+}
+
+// options returns graceful restart options that are set, keyed by the option name.
+func (g BgpGracefulRestartConfig) options() map[string]string {
+	return setBgpOptions(g.fields())
+}
+
+// Merge returns graceful restart options that result from applying options set in "update" on top of "g".
+func (g BgpGracefulRestartConfig) Merge(update BgpGracefulRestartConfig) BgpGracefulRestartConfig {
+	return mergeBgpOptions(g, update)
+}
+
+// Unset clears graceful restart option "name".
+func (g *BgpGracefulRestartConfig) Unset(name string) error {
+	return unsetBgpOption(g.fields(), "BGP graceful restart", name)
+}
+
+// IsEnabled returns "true" if graceful restart should be negotiated with BGP peers.
+func (g BgpGracefulRestartConfig) IsEnabled() bool {
+	enabled, _ := strconv.ParseBool(g.Enabled)
+	return enabled
+}
+
+// IsLongLived returns "true" if long-lived graceful restart should be negotiated with BGP peers.
+func (g BgpGracefulRestartConfig) IsLongLived() bool {
+	longLived, _ := strconv.ParseBool(g.LongLived)
+	return g.IsEnabled() && longLived
+}
+
+// Time returns graceful restart time in seconds, or its default value if it's not set.
+func (g BgpGracefulRestartConfig) Time() string {
+	if g.RestartTime == "" {
+		return BgpDefaultGracefulRestartTime
+	}
+	return g.RestartTime
+}
+
+// LongLivedStaleTime returns long-lived graceful restart stale time in seconds, or its default value
+// if it's not set.
+func (g BgpGracefulRestartConfig) LongLivedStaleTime() string {
+	if g.StaleTime == "" {
+		return BgpDefaultLongLivedStaleTime
+	}
+	return g.StaleTime
+}
+
+// Validate ensures that graceful restart options have correct values.
+func (g BgpGracefulRestartConfig) Validate() error {
+	for name, value := range map[string]string{
+		BgpGracefulRestartOptionEnabled:   g.Enabled,
+		BgpGracefulRestartOptionLongLived: g.LongLived,
+	} {
+		if value == "" {
+			continue
+		}
+		_, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("option '%s' must be 'true' or 'false': %s", name, value)
+		}
+	}
+
+	// Restart time is a 12-bit field of the graceful restart capability
+	if g.RestartTime != "" {
+		err := validateUint(BgpGracefulRestartOptionTime, g.RestartTime, 1, 4095)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Stale time is a 24-bit field of the long-lived graceful restart capability
+	if g.StaleTime != "" {
+		err := validateUint(BgpGracefulRestartOptionStaleTime, g.StaleTime, 1, 16777215)
+		if err != nil {
+			return err
+		}
+	}
+
+	longLived, _ := strconv.ParseBool(g.LongLived)
+	if longLived && !g.IsEnabled() {
+		return fmt.Errorf("option '%s' requires option '%s' to be 'true'", BgpGracefulRestartOptionLongLived, BgpGracefulRestartOptionEnabled)
+	}
+
+	return nil
+}
+
+// Names of BGP EVPN options.
+const (
+	BgpEvpnOptionEnabled  = "evpn"
+	BgpEvpnOptionVni      = "evpn_vni"
+	BgpEvpnOptionVtep     = "evpn_vtep"
+	BgpEvpnOptionImportRt = "evpn_import_rt"
+	BgpEvpnOptionExportRt = "evpn_export_rt"
+)
+
+// BgpEvpnConfig holds EVPN options of a cluster member. In EVPN mode, routes of the VRF are advertised
+// to BGP peers as EVPN type-5 (IP prefix) routes with the configured L3 VNI, instead of plain IPv4/IPv6
+// unicast routes, and traffic from the fabric terminates on a local VXLAN device in the VRF.
+type BgpEvpnConfig struct {
+	// Enabled controls whether routes are exchanged with BGP peers as EVPN type-5 routes (default "false")
+	Enabled string `json:"evpn,omitempty" yaml:"evpn,omitempty"`
+	// Vni is the L3 VNI of the VRF, used by the local VXLAN device and advertised with the routes
+	Vni string `json:"evpn_vni,omitempty" yaml:"evpn_vni,omitempty"`
+	// Vtep is the local IPv4 address of the VXLAN tunnel endpoint, it has to be reachable from the fabric
+	Vtep string `json:"evpn_vtep,omitempty" yaml:"evpn_vtep,omitempty"`
+	// ImportRt is a comma-separated list of route targets ("asn:value" or "ipv4:value") of routes that
+	// are imported into the VRF, it's derived from the ASN and VNI if not set
+	ImportRt string `json:"evpn_import_rt,omitempty" yaml:"evpn_import_rt,omitempty"`
+	// ExportRt is a comma-separated list of route targets attached to advertised routes, it's derived
+	// from the ASN and VNI if not set
+	ExportRt string `json:"evpn_export_rt,omitempty" yaml:"evpn_export_rt,omitempty"`
+}
+
+// fields returns pointers to the fields that hold EVPN options, keyed by the option name.
+func (e *BgpEvpnConfig) fields() map[string]*string {
+	return map[string]*string{
+		BgpEvpnOptionEnabled:  &e.Enabled,
+		BgpEvpnOptionVni:      &e.Vni,
+		BgpEvpnOptionVtep:     &e.Vtep,
+		BgpEvpnOptionImportRt: &e.ImportRt,
+		BgpEvpnOptionExportRt: &e.ExportRt,
+	}
+}
+
+// option returns pointer to the field that holds EVPN option "name".
+func (e *BgpEvpnConfig) option(name string) (*string, error) {
+	return lookupBgpOption(e.fields(), "BGP EVPN", name)
+}
+
+// options returns EVPN options that are set, keyed by the option name.
+func (e BgpEvpnConfig) options() map[string]string {
+	return setBgpOptions(e.fields())
+}
+
+// Merge returns EVPN options that result from applying options set in "update" on top of "e".
+func (e BgpEvpnConfig) Merge(update BgpEvpnConfig) BgpEvpnConfig {
+	return mergeBgpOptions(e, update)
+}
+
+// Unset clears EVPN option "name".
+func (e *BgpEvpnConfig) Unset(name string) error {
+	return unsetBgpOption(e.fields(), "BGP EVPN", name)
+}
+
+// IsEnabled returns "true" if routes should be exchanged with BGP peers as EVPN type-5 routes.
+func (e BgpEvpnConfig) IsEnabled() bool {
+	enabled, _ := strconv.ParseBool(e.Enabled)
+	return enabled
+}
+
+// ImportRouteTargets returns route targets of routes that are imported into the VRF, or nil if they
+// should be derived from the ASN and VNI.
+func (e BgpEvpnConfig) ImportRouteTargets() []string {
+	return splitRouteTargets(e.ImportRt)
+}
+
+// ExportRouteTargets returns route targets attached to advertised routes, or nil if they should be
+// derived from the ASN and VNI.
+func (e BgpEvpnConfig) ExportRouteTargets() []string {
+	return splitRouteTargets(e.ExportRt)
+}
+
+// splitRouteTargets splits comma-separated list of route targets.
+func splitRouteTargets(value string) []string {
+	var routeTargets []string
+	if value == "" {
+		return routeTargets
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		routeTargets = append(routeTargets, strings.TrimSpace(item))
+	}
+	return routeTargets
+}
+
+// validateRouteTarget validates that "value" is a route target in one of the "asn:value" (2-byte ASN
+// with 4-byte value or 4-byte ASN with 2-byte value) and "ipv4:value" (2-byte value) formats.
+func validateRouteTarget(value string) error {
+	admin, assigned, found := strings.Cut(value, ":")
+	if !found {
+		return fmt.Errorf("'%s' is not a valid route target, expected 'asn:value' or 'ipv4:value'", value)
+	}
+
+	number, err := strconv.ParseUint(assigned, 10, 32)
+	if err != nil {
+		return fmt.Errorf("'%s' is not a valid route target, value must be a 32-bit number", value)
+	}
+
+	addr, err := netip.ParseAddr(admin)
+	if err == nil {
+		if !addr.Is4() || number > 65535 {
+			return fmt.Errorf("'%s' is not a valid route target, expected IPv4 address and 16-bit value", value)
+		}
+		return nil
+	}
+
+	asn, err := strconv.ParseUint(admin, 10, 32)
+	if err != nil || (asn > 65535 && number > 65535) {
+		return fmt.Errorf("'%s' is not a valid route target, either ASN or value must be a 16-bit number", value)
+	}
+	return nil
+}
+
+// Validate ensures that EVPN options have correct values. VNI and VTEP address are required in EVPN mode.
+func (e BgpEvpnConfig) Validate() error {
+	if e.Enabled != "" {
+		_, err := strconv.ParseBool(e.Enabled)
+		if err != nil {
+			return fmt.Errorf("option '%s' must be 'true' or 'false': %s", BgpEvpnOptionEnabled, e.Enabled)
+		}
+	}
+
+	// VNI is a 24-bit field of the VXLAN header
+	if e.Vni != "" {
+		err := validateUint(BgpEvpnOptionVni, e.Vni, 1, 16777215)
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.Vtep != "" {
+		addr, err := netip.ParseAddr(e.Vtep)
+		if err != nil || !addr.Is4() {
+			return fmt.Errorf("option '%s' is not a valid IPv4 address: %s", BgpEvpnOptionVtep, e.Vtep)
+		}
+	}
+
+	for name, value := range map[string]string{
+		BgpEvpnOptionImportRt: e.ImportRt,
+		BgpEvpnOptionExportRt: e.ExportRt,
+	} {
+		for _, routeTarget := range splitRouteTargets(value) {
+			err := validateRouteTarget(routeTarget)
+			if err != nil {
+				return fmt.Errorf("option '%s' is not valid: %w", name, err)
+			}
+		}
+	}
+
+	if !e.IsEnabled() {
+		return nil
+	}
+
+	for name, value := range map[string]string{BgpEvpnOptionVni: e.Vni, BgpEvpnOptionVtep: e.Vtep} {
+		if value == "" {
+			return fmt.Errorf("option '%s' is required when option '%s' is 'true'", name, BgpEvpnOptionEnabled)
+		}
+	}
+
+	return nil
+}
+
+// Types of routes that logical routers can redistribute to BGP peers of a cluster member.
+const (
+	BgpRedistributeConnected = "connected"
+	BgpRedistributeStatic    = "static"
+	BgpRedistributeNat       = "nat"
+	BgpRedistributeLb        = "lb"
+)
+
+// BgpDefaultRedistribute is the set of route types redistributed by logical routers if none is
+// selected. It matches the set used by MicroOVN's own logical routers.
+var BgpDefaultRedistribute = []string{BgpRedistributeNat, BgpRedistributeLb}
+
+// BgpRouter describes a user logical router whose routes are advertised through BGP speaker of
+// a cluster member.
+type BgpRouter struct {
+	// Name is the name of the logical router in OVN Northbound database
+	Name string `json:"name" yaml:"name"`
+	// Member is the name of the cluster member that advertises routes of the router
+	Member string `json:"member" yaml:"member"`
+	// Redistribute lists types of routes that are advertised
+	Redistribute []string `json:"redistribute" yaml:"redistribute"`
+}
+
+// BgpRouters is a list of user logical routers whose routes are advertised through BGP.
+type BgpRouters []BgpRouter
+
+// BgpRouterRequest is a request to advertise routes of a user logical router through BGP.
+type BgpRouterRequest struct {
+	// Redistribute lists types of routes that should be advertised, BgpDefaultRedistribute is used
+	// if it's empty
+	Redistribute []string `json:"redistribute,omitempty" yaml:"redistribute,omitempty"`
+}
+
+// ValidateRedistribute returns error if "redistribute" contains unsupported or duplicate route types.
+func ValidateRedistribute(redistribute []string) error {
+	supported := []string{BgpRedistributeConnected, BgpRedistributeStatic, BgpRedistributeNat, BgpRedistributeLb}
+	for i, routeType := range redistribute {
+		if !slices.Contains(supported, routeType) {
+			return fmt.Errorf("unsupported route type '%s', supported types are: %s", routeType, strings.Join(supported, ", "))
+		}
+		if slices.Contains(redistribute[:i], routeType) {
+			return fmt.Errorf("route type '%s' specified more than once", routeType)
+		}
+	}
+	return nil
+}
+
+// Names of BGP preflight checks.
+const (
+	BgpCheckService        = "service"
+	BgpCheckConfig         = "config"
+	BgpCheckVrfModule      = "vrf_module"
+	BgpCheckInterface      = "interface"
+	BgpCheckNetplan        = "netplan"
+	BgpCheckNetworkBackend = "network_backend"
+	BgpCheckVrfTable       = "vrf_table"
+	BgpCheckDaemon         = "daemon"
+	BgpCheckBridgeMappings = "bridge_mappings"
+	BgpCheckVxlanModule    = "vxlan_module"
+	BgpCheckVtep           = "vtep"
+)
+
+// BgpCheckResult is the result of a single preflight check that is run before "bgp" service is enabled.
+type BgpCheckResult struct {
+	// Check is the name of the check, e.g. "interface"
+	Check string `json:"check" yaml:"check"`
+	// Subject is the resource that was checked, e.g. name of the external interface, if the check is
+	// run for multiple resources
+	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
+	// Passed is set if the check succeeded
+	Passed bool `json:"passed" yaml:"passed"`
+	// Message describes the result of the check
+	Message string `json:"message" yaml:"message"`
+}
+
+// BgpCheckReport contains results of preflight checks run on a cluster member before "bgp" service
+// is enabled with the provided configuration.
+type BgpCheckReport struct {
+	Member string           `json:"member" yaml:"member"`
+	Checks []BgpCheckResult `json:"checks" yaml:"checks"`
+}
+
+// Passed returns "true" if all checks in the report succeeded.
+func (r BgpCheckReport) Passed() bool {
+	for _, check := range r.Checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return true
+}
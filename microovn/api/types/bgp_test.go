@@ -0,0 +1,194 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// bgpRawConfig returns raw BGP configuration with external connection "eth1" and "options" in the
+// "key=value" format.
+func bgpRawConfig(options ...string) map[string]string {
+	rawConfig := map[string]string{"ext_connection": "eth1"}
+	for _, option := range options {
+		key, value, _ := strings.Cut(option, "=")
+		rawConfig[key] = value
+	}
+	return rawConfig
+}
+
+func TestExtraBgpConfigOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   map[string]string
+		check func(t *testing.T, bgpConf ExtraBgpConfig)
+	}{
+		{
+			name: "peer",
+			raw: map[string]string{
+				"ext_connection":      "eth1,eth2.100:192.0.2.1/24",
+				"eth1.peer_asn":       "internal",
+				"eth1.hold_time":      "9",
+				"eth2.100.neighbor":   "192.0.2.2",
+				"eth2.100.password":   "secret",
+				"eth2.100.auth":       "ao",
+				"eth2.100.bfd_min_rx": "300",
+			},
+			check: func(t *testing.T, bgpConf ExtraBgpConfig) {
+				expected := map[string]BgpPeerConfig{
+					"eth1":     {PeerAsn: "internal", HoldTime: "9"},
+					"eth2.100": {Neighbor: "192.0.2.2", Password: "secret", Auth: BgpAuthAo, BfdMinRx: "300"},
+				}
+				if !reflect.DeepEqual(bgpConf.Peers, expected) {
+					t.Errorf("expected peers %+v, got %+v", expected, bgpConf.Peers)
+				}
+
+				bgpConf.RedactSecrets()
+				if bgpConf.Peers["eth2.100"].Password != BgpRedactedSecret {
+					t.Errorf("expected password to be redacted, got '%s'", bgpConf.Peers["eth2.100"].Password)
+				}
+			},
+		},
+		{
+			name: "policy",
+			raw: bgpRawConfig(
+				"import_allow=192.0.2.0/24,2001:db8::/32",
+				"export_deny=198.51.100.0/24",
+				"accept_default_route=false",
+				"export_communities=65000:100,4200000000:1:2",
+				"export_local_pref=200",
+				"export_as_path_prepend=2",
+				"daemon=frr",
+				"network_backend=netlink",
+			),
+			check: func(t *testing.T, bgpConf ExtraBgpConfig) {
+				if bgpConf.Policy.AcceptsDefaultRoute() {
+					t.Errorf("expected default route to be rejected")
+				}
+
+				communities, err := ParseCommunities(bgpConf.Policy.ExportCommunities)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				expected := []BgpCommunity{{65000, 100}, {4200000000, 1, 2}}
+				if !reflect.DeepEqual(communities, expected) {
+					t.Errorf("expected communities %+v, got %+v", expected, communities)
+				}
+			},
+		},
+		{
+			name: "graceful restart",
+			raw:  bgpRawConfig("graceful_restart=true", "long_lived_graceful_restart=true", "long_lived_stale_time=7200"),
+			check: func(t *testing.T, bgpConf ExtraBgpConfig) {
+				if !bgpConf.GracefulRestart.IsLongLived() {
+					t.Errorf("expected long-lived graceful restart to be enabled")
+				}
+				if bgpConf.GracefulRestart.Time() != BgpDefaultGracefulRestartTime {
+					t.Errorf("expected default graceful restart time, got %s", bgpConf.GracefulRestart.Time())
+				}
+			},
+		},
+		{
+			name: "EVPN",
+			raw: bgpRawConfig(
+				"daemon=frr",
+				"evpn=true",
+				"evpn_vni=10100",
+				"evpn_vtep=192.0.2.10",
+				"evpn_import_rt=65000:10100, 192.0.2.1:100",
+				"evpn_export_rt=4200000000:100",
+			),
+			check: func(t *testing.T, bgpConf ExtraBgpConfig) {
+				if !bgpConf.Evpn.IsEnabled() {
+					t.Errorf("expected EVPN to be enabled")
+				}
+				expectedImport := []string{"65000:10100", "192.0.2.1:100"}
+				if !reflect.DeepEqual(bgpConf.Evpn.ImportRouteTargets(), expectedImport) {
+					t.Errorf("expected import route targets %v, got %v", expectedImport, bgpConf.Evpn.ImportRouteTargets())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bgpConf ExtraBgpConfig
+			err := bgpConf.FromMap(tt.raw)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(bgpConf.ToMap(), tt.raw) {
+				t.Errorf("expected %+v, got %+v", tt.raw, bgpConf.ToMap())
+			}
+			tt.check(t, bgpConf)
+		})
+	}
+
+	for _, options := range [][]string{
+		// Per-connection options
+		{"eth1.unknown=1"},
+		{"eth2.neighbor=192.0.2.2"},
+		{"eth1.neighbor=not-an-address"},
+		{"eth1.peer_asn=somewhere"},
+		{"eth1.auth=md5"},
+		{"eth1.password=\"quoted\""},
+		{"eth1.password=********"},
+		{"eth1.hold_time=1"},
+		{"eth1.keepalive_time=0"},
+		{"eth1.bfd_min_tx=0"},
+		{"eth1.bfd_multiplier=300"},
+		// Route policy options
+		{"import_allow=192.0.2.1"},
+		{"export_deny=192.0.2.0/24,"},
+		{"accept_default_route=maybe"},
+		{"export_communities=65000"},
+		{"export_communities=4200000000:100"},
+		{"export_local_pref=-1"},
+		{"export_as_path_prepend=11"},
+		{"daemon=quagga"},
+		{"network_backend=ifupdown"},
+		// Graceful restart options
+		{"graceful_restart=maybe"},
+		{"graceful_restart=true", "graceful_restart_time=0"},
+		{"graceful_restart=true", "graceful_restart_time=4096"},
+		{"graceful_restart=true", "long_lived_graceful_restart=true", "long_lived_stale_time=16777216"},
+		{"long_lived_graceful_restart=true"},
+		// EVPN options
+		{"evpn=maybe"},
+		{"evpn=true", "evpn_vtep=192.0.2.10"},
+		{"evpn=true", "evpn_vni=10100"},
+		{"evpn=true", "evpn_vni=16777216", "evpn_vtep=192.0.2.10"},
+		{"evpn=true", "evpn_vni=10100", "evpn_vtep=2001:db8::1"},
+		{"evpn_import_rt=65000"},
+		{"evpn_import_rt=4200000000:100000"},
+		{"evpn_export_rt=2001:db8::1:100"},
+		{"evpn_export_rt=192.0.2.1:100000"},
+		{"evpn=true", "evpn_vni=10100", "evpn_vtep=192.0.2.10", "import_deny=10.0.0.0/8"},
+		{"evpn=true", "evpn_vni=10100", "evpn_vtep=192.0.2.10", "accept_default_route=false"},
+	} {
+		t.Run(strings.Join(options, ","), func(t *testing.T) {
+			var bgpConf ExtraBgpConfig
+			err := bgpConf.FromMap(bgpRawConfig(options...))
+			if err == nil {
+				t.Errorf("expected error for '%s'", strings.Join(options, ","))
+			}
+		})
+	}
+}
+
+func TestValidateRedistribute(t *testing.T) {
+	for _, redistribute := range [][]string{nil, {"nat", "lb"}, {"connected", "static", "nat", "lb"}} {
+		err := ValidateRedistribute(redistribute)
+		if err != nil {
+			t.Errorf("unexpected error for %v: %s", redistribute, err)
+		}
+	}
+
+	for _, redistribute := range [][]string{{"bgp"}, {"nat", "nat"}, {""}} {
+		err := ValidateRedistribute(redistribute)
+		if err == nil {
+			t.Errorf("expected error for %v", redistribute)
+		}
+	}
+}
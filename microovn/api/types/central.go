@@ -0,0 +1,8 @@
+package types
+
+// CentralMigrateInRequest is a structure of a request to migrate OVN central services from an external
+// OVN central cluster into MicroOVN.
+type CentralMigrateInRequest struct {
+	NbRemote string `json:"nb_remote"` // Connection string of the external Northbound database (e.g. "ssl:10.0.0.1:6641")
+	SbRemote string `json:"sb_remote"` // Connection string of the external Southbound database (e.g. "ssl:10.0.0.1:6642")
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+)
+
+// availabilityZoneRegex - allowed format of availability zone names
+var availabilityZoneRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ChassisGateways - Slice with ChassisGateway records.
+type ChassisGateways []ChassisGateway
+
+// ChassisGateway - gateway role of the chassis of a cluster member. Gateway chassis are announced to the
+// CMS with "enable-chassis-as-gw" in "ovn-cms-options".
+type ChassisGateway struct {
+	// Member - name of the cluster member.
+	Member string `json:"member" yaml:"member"`
+	// Priority - optional gateway priority, announced as "gateway-priority" in "ovn-cms-options".
+	Priority string `json:"priority,omitempty" yaml:"priority,omitempty"`
+	// AvailabilityZones - optional availability zones, announced as "availability-zones" in "ovn-cms-options".
+	AvailabilityZones []string `json:"availability_zones,omitempty" yaml:"availability_zones,omitempty"`
+}
+
+// Validate ensures that gateway options have correct values.
+func (g ChassisGateway) Validate() error {
+	if g.Priority != "" {
+		// Same range as priorities of OVN Gateway_Chassis and HA_Chassis records
+		err := validateUint("priority", g.Priority, 0, 32767)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, zone := range g.AvailabilityZones {
+		if !availabilityZoneRegex.MatchString(zone) {
+			return fmt.Errorf("availability zone name must consist of letters, digits, '_', '.' or '-': '%s'", zone)
+		}
+	}
+	return nil
+}
+
+// Supported tunnel encapsulation types of the chassis
+const (
+	EncapTypeGeneve = "geneve"
+	EncapTypeVxlan  = "vxlan"
+)
+
+// ChassisEncap - tunnel encapsulation of the chassis of a cluster member, set as "ovn-encap-type" and
+// "ovn-encap-ip" in "external_ids" of the local Open vSwitch.
+type ChassisEncap struct {
+	// Member - name of the cluster member.
+	Member string `json:"member" yaml:"member"`
+	// Type - encapsulation type of tunnels to other chassis.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// IPs - local addresses of tunnel endpoints.
+	IPs []string `json:"ips,omitempty" yaml:"ips,omitempty"`
+}
+
+// Validate ensures that encapsulation options have correct values. Empty options are allowed, they
+// denote options that are left unchanged.
+func (e ChassisEncap) Validate() error {
+	if e.Type != "" && e.Type != EncapTypeGeneve && e.Type != EncapTypeVxlan {
+		return fmt.Errorf("unsupported encapsulation type '%s', expected '%s' or '%s'", e.Type, EncapTypeGeneve, EncapTypeVxlan)
+	}
+
+	for i, ip := range e.IPs {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil || addr.Zone() != "" {
+			return fmt.Errorf("encapsulation IP is not a valid IP address: '%s'", ip)
+		}
+		if slices.Contains(e.IPs[:i], ip) {
+			return fmt.Errorf("duplicate encapsulation IP: '%s'", ip)
+		}
+	}
+	return nil
+}
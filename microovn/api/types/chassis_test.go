@@ -0,0 +1,57 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestChassisGatewayValidate(t *testing.T) {
+	for _, gateway := range []ChassisGateway{
+		{},
+		{Priority: "0"},
+		{Priority: "32767", AvailabilityZones: []string{"az1", "zone-2.rack_3"}},
+	} {
+		err := gateway.Validate()
+		if err != nil {
+			t.Errorf("unexpected error for %+v: %s", gateway, err)
+		}
+	}
+
+	for _, gateway := range []ChassisGateway{
+		{Priority: "32768"},
+		{Priority: "high"},
+		{AvailabilityZones: []string{"az:1"}},
+		{AvailabilityZones: []string{""}},
+	} {
+		err := gateway.Validate()
+		if err == nil {
+			t.Errorf("expected error for %+v", gateway)
+		}
+	}
+}
+
+func TestChassisEncapValidate(t *testing.T) {
+	for _, encap := range []ChassisEncap{
+		{},
+		{Type: EncapTypeGeneve},
+		{Type: EncapTypeVxlan, IPs: []string{"10.0.0.1"}},
+		{IPs: []string{"10.0.0.1", "fd00::1"}},
+	} {
+		err := encap.Validate()
+		if err != nil {
+			t.Errorf("unexpected error for %+v: %s", encap, err)
+		}
+	}
+
+	for _, encap := range []ChassisEncap{
+		{Type: "stt"},
+		{IPs: []string{"10.0.0.256"}},
+		{IPs: []string{"node1.example.com"}},
+		{IPs: []string{"fe80::1%eth0"}},
+		{IPs: []string{"10.0.0.1", "10.0.0.1"}},
+	} {
+		err := encap.Validate()
+		if err == nil {
+			t.Errorf("expected error for %+v", encap)
+		}
+	}
+}
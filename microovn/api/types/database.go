@@ -0,0 +1,35 @@
+package types
+
+// RaftServer represents a single server in the RAFT cluster of an OVN central database
+// and the outcome of checking it against the MicroOVN cluster membership.
+type RaftServer struct {
+	ServerID string `json:"server_id"` // RAFT server ID as reported by "cluster/status"
+	Address  string `json:"address"`   // RAFT address of the server (e.g. "ssl:10.0.0.1:6643")
+	Member   string `json:"member"`    // Name of the MicroOVN member that owns the server, empty if there is none
+	Dead     bool   `json:"dead"`      // Whether the RAFT leader did not hear from the server for a long time
+	Stale    bool   `json:"stale"`     // Whether the server is dead or does not belong to any member with "central" service
+	Kicked   bool   `json:"kicked"`    // Whether the server was kicked from the RAFT cluster
+	Error    string `json:"error"`     // Error encountered while kicking the server
+}
+
+// RaftMembershipResult contains RAFT membership of a single OVN central database.
+type RaftMembershipResult struct {
+	Database string       `json:"database"`
+	Servers  []RaftServer `json:"servers"`
+	Error    string       `json:"error"`
+}
+
+// RaftMembershipReport is a collection of RaftMembershipResult structs, one for each
+// OVN central database.
+type RaftMembershipReport = []RaftMembershipResult
+
+// DatabaseRecoverRequest is a structure of a request to recover OVN central databases
+// after a permanent loss of quorum.
+type DatabaseRecoverRequest struct {
+	Force bool `json:"force"` // Proceed even if the database clusters still have a leader
+}
+
+// DatabaseRecoverResponse is a structure of a response to DatabaseRecoverRequest.
+type DatabaseRecoverResponse struct {
+	RemovedMembers []string `json:"removed_members"` // Members from which "central" service was removed
+}
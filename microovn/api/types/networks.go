@@ -0,0 +1,81 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// externalNetworkNameRegex - allowed format of external network (physnet) names
+var externalNetworkNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ExternalNetworks - Slice with ExternalNetwork records.
+type ExternalNetworks []ExternalNetwork
+
+// ExternalNetwork - a provider network that connects OVN to a physical network via the same interface
+// on each cluster member with "chassis" service.
+type ExternalNetwork struct {
+	// Name - physical network name used in "ovn-bridge-mappings" and "network_name" of the localnet port.
+	Name string `json:"name" yaml:"name"`
+	// Interface - name of the physical interface plugged to the external bridge.
+	Interface string `json:"interface" yaml:"interface"`
+	// Vlan - optional VLAN tag of the localnet port.
+	Vlan string `json:"vlan,omitempty" yaml:"vlan,omitempty"`
+	// Switch - name of the Logical Switch that represents the network in OVN.
+	Switch string `json:"switch" yaml:"switch"`
+}
+
+// Validate ensures that the external network definition is well-formed.
+func (n ExternalNetwork) Validate() error {
+	if !externalNetworkNameRegex.MatchString(n.Name) {
+		return fmt.Errorf("physical network name must consist of letters, digits, '_' or '-': '%s'", n.Name)
+	}
+
+	// Linux interface names are limited to 15 characters (IFNAMSIZ - 1). Bridge name "br-<iface>"
+	// is subject to the same limit, which leaves 12 characters for the interface. The bridge name is
+	// also part of comma-separated "ovn-bridge-mappings".
+	if n.Interface == "" || len(n.Interface) > 12 || strings.ContainsAny(n.Interface, "/:, \t\n") {
+		return fmt.Errorf("invalid interface name: '%s'", n.Interface)
+	}
+
+	if n.Vlan != "" {
+		err := validateUint("vlan", n.Vlan, 1, 4094)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExternalNetworkResponse - result of adding or removing external network. "Errors" lists cluster members
+// that failed to apply the change locally, the change is re-applied on them when MicroOVN restarts.
+type ExternalNetworkResponse struct {
+	Network ExternalNetwork `json:"network"`
+	Errors  []string        `json:"errors"`
+}
+
+// BridgeMappings - Slice with BridgeMapping records.
+type BridgeMappings []BridgeMapping
+
+// BridgeMapping - an "ovn-bridge-mappings" entry of a cluster member, that maps physical network to an
+// OVS bridge.
+type BridgeMapping struct {
+	// Member - name of the cluster member on which the mapping is applied.
+	Member string `json:"member" yaml:"member"`
+	// Physnet - physical network name used in "network_name" of localnet ports.
+	Physnet string `json:"physnet" yaml:"physnet"`
+	// Bridge - name of the OVS bridge connected to the physical network.
+	Bridge string `json:"bridge" yaml:"bridge"`
+}
+
+// Validate ensures that the bridge mapping is well-formed.
+func (m BridgeMapping) Validate() error {
+	if !externalNetworkNameRegex.MatchString(m.Physnet) {
+		return fmt.Errorf("physical network name must consist of letters, digits, '_' or '-': '%s'", m.Physnet)
+	}
+
+	if m.Bridge == "" || len(m.Bridge) > 15 || strings.ContainsAny(m.Bridge, "/:, \t\n") {
+		return fmt.Errorf("invalid bridge name: '%s'", m.Bridge)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestExternalNetworkValidate(t *testing.T) {
+	for _, network := range []ExternalNetwork{
+		{Name: "eth1", Interface: "eth1"},
+		{Name: "physnet_1", Interface: "enp5s0", Vlan: "100"},
+		{Name: "provider-net", Interface: "bond0.10", Vlan: "4094"},
+		{Name: "physnet", Interface: "enp5s0f1.100"},
+	} {
+		err := network.Validate()
+		if err != nil {
+			t.Errorf("unexpected error for %+v: %s", network, err)
+		}
+	}
+
+	for _, network := range []ExternalNetwork{
+		{Name: "", Interface: "eth1"},
+		{Name: "phys:net", Interface: "eth1"},
+		{Name: "physnet", Interface: ""},
+		{Name: "physnet", Interface: "interface-too-long"},
+		{Name: "physnet", Interface: "enp5s0f1.1000"},
+		{Name: "physnet", Interface: "eth/1"},
+		{Name: "physnet", Interface: "eth1,eth2"},
+		{Name: "physnet", Interface: "eth1", Vlan: "0"},
+		{Name: "physnet", Interface: "eth1", Vlan: "4095"},
+		{Name: "physnet", Interface: "eth1", Vlan: "ten"},
+	} {
+		err := network.Validate()
+		if err == nil {
+			t.Errorf("expected error for %+v", network)
+		}
+	}
+}
+
+func TestBridgeMappingValidate(t *testing.T) {
+	for _, mapping := range []BridgeMapping{
+		{Physnet: "physnet1", Bridge: "br-ex"},
+		{Physnet: "provider_net-2", Bridge: "br-provider"},
+	} {
+		err := mapping.Validate()
+		if err != nil {
+			t.Errorf("unexpected error for %+v: %s", mapping, err)
+		}
+	}
+
+	for _, mapping := range []BridgeMapping{
+		{Physnet: "", Bridge: "br-ex"},
+		{Physnet: "phys,net", Bridge: "br-ex"},
+		{Physnet: "physnet1", Bridge: ""},
+		{Physnet: "physnet1", Bridge: "br-ex:br-int"},
+		{Physnet: "physnet1", Bridge: "br-bridge-too-long"},
+	} {
+		err := mapping.Validate()
+		if err == nil {
+			t.Errorf("expected error for %+v", mapping)
+		}
+	}
+}
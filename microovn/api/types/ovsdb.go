@@ -25,3 +25,44 @@ type OvsdbSchemaVersionResult struct {
 	SchemaVersion string                `json:"schemaVersion"`
 	Error         OvsdbSchemaFetchError `json:"error"`
 }
+
+// OvsdbUpgradeStatus describes state of OVN central database schema upgrades in the cluster.
+type OvsdbUpgradeStatus struct {
+	Hold      bool                   `json:"hold"`      // Whether automatic schema upgrades are on hold
+	Databases []OvsdbUpgradeDbStatus `json:"databases"` // Upgrade status of each central database
+}
+
+// OvsdbUpgradeDbStatus describes schema upgrade status of a single OVN central database.
+type OvsdbUpgradeDbStatus struct {
+	Database        string            `json:"database"`
+	ActiveVersion   string            `json:"active_version"`   // Schema version currently used by the database
+	TargetVersion   string            `json:"target_version"`   // Schema version that the database will be upgraded to
+	UpgradeRequired bool              `json:"upgrade_required"` // Whether the database requires schema upgrade
+	Blockers        OvsdbSchemaReport `json:"blockers"`         // Members that are not ready for the upgrade
+	Error           string            `json:"error"`
+}
+
+// OvsdbUpgradeHoldRequest is a structure of a request to put automatic schema upgrades on hold,
+// or to release the hold.
+type OvsdbUpgradeHoldRequest struct {
+	Hold bool `json:"hold"`
+}
+
+// OvsdbUpgradeTriggerRequest is a structure of a request to explicitly trigger schema upgrade
+// of OVN central databases.
+type OvsdbUpgradeTriggerRequest struct {
+	Force bool `json:"force"` // Upgrade even if some members are not ready for the upgrade
+}
+
+// OvsdbUpgradeResult describes outcome of a schema upgrade of a single OVN central database.
+type OvsdbUpgradeResult struct {
+	Database        string `json:"database"`
+	PreviousVersion string `json:"previous_version"`
+	TargetVersion   string `json:"target_version"`
+	Upgraded        bool   `json:"upgraded"` // Whether the database schema was converted
+	Snapshot        string `json:"snapshot"` // Path to the snapshot taken before the upgrade, on the upgrading member
+	Error           string `json:"error"`
+}
+
+// OvsdbUpgradeReport is a collection of OvsdbUpgradeResult structs, one for each OVN central database.
+type OvsdbUpgradeReport = []OvsdbUpgradeResult
@@ -4,6 +4,8 @@ package types
 import (
 	"fmt"
 	"log"
+	"net/netip"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -108,10 +110,15 @@ const (
 	SrvSwitch SrvName = "switch"
 	// SrvBgp - string representation of BGP service
 	SrvBgp SrvName = "bgp"
+	// SrvRelay - string representation of OVSDB relay service.
+	SrvRelay SrvName = "relay"
+	// SrvGateway - string representation of gateway capability of chassis service. Capabilities are
+	// recorded along with services, but they don't run any snap service on their own.
+	SrvGateway SrvName = "gateway"
 )
 
 // ServiceNames - slice containing all known SrvName strings.
-var ServiceNames = []SrvName{SrvBgp, SrvChassis, SrvCentral, SrvSwitch}
+var ServiceNames = []SrvName{SrvBgp, SrvChassis, SrvCentral, SrvRelay, SrvSwitch}
 
 // ExtraServiceConfig - structure containing optional extra configuration for enabling service
 type ExtraServiceConfig struct {
@@ -120,10 +127,12 @@ type ExtraServiceConfig struct {
 
 // ExtraBgpConfig holds extra config options that can be used when enabling BGP config
 type ExtraBgpConfig struct {
-	// ExternalConnection is comma separated list of <iface_name>:<ip4_cidr> values. "iface_name"
+	// ExternalConnection is comma separated list of <iface_name>[:<cidr>][:<cidr>] values. "iface_name"
 	// is a name of the physical interface that provides connectivity to the external network and
-	// "ip4_cidr" is IPv4 address (e.g. 192.0.2.1/24) that should be assigned to a Logical Router
-	// Port connected to the external network
+	// optional "cidr" values are IPv4 (e.g. 192.0.2.1/24) and/or IPv6 (e.g. 2001:db8::1/64) addresses
+	// that should be assigned to a Logical Router Port connected to the external network. At most one
+	// address of each family can be specified. Without any address, BGP unnumbered over IPv6 link-local
+	// addresses is used.
 	ExternalConnection string `json:"ext_iface,omitempty" yaml:"ext_iface,omitempty"`
 	// Vrf is a VRF table ID into which the OVN will leak its routes
 	Vrf string `json:"vrf,omitempty" yaml:"vrf,omitempty"`
@@ -132,15 +141,117 @@ type ExtraBgpConfig struct {
 	// AsnRange is an optional range of RFC 6996 private ASNs [min, max] parsed from user input
 	// from which a unique ASN will be auto-selected (based on cluster member ID)
 	AsnRange [2]uint64 `json:"asn_range,omitempty" yaml:"asn_range,omitempty"`
-	// ManualBgpdConfig if set, skips automatic BIRD daemon configuration, allowing manual BGP daemon configuration.
+	// Daemon is the routing daemon that runs BGP sessions, "bird" (default) or "frr"
+	Daemon string `json:"daemon,omitempty" yaml:"daemon,omitempty"`
+	// NetworkBackend creates interfaces of the BGP redirect, "netplan" (default) or "netlink"
+	NetworkBackend string `json:"network_backend,omitempty" yaml:"network_backend,omitempty"`
+	// ManualBgpdConfig if set, skips automatic routing daemon configuration, allowing manual BGP daemon configuration.
 	// This was the former default behavior when no ASN was provided
 	ManualBgpdConfig bool `json:"manual_bgpd_config,omitempty" yaml:"manual_bgpd_config,omitempty"`
+	// Peers holds optional peering options of external connections, keyed by the interface name.
+	// They are set with "<iface_name>.<option>" keys.
+	Peers map[string]BgpPeerConfig `json:"peers,omitempty" yaml:"peers,omitempty"`
+	// Policy holds route policy applied to routes exchanged with BGP peers
+	Policy BgpPolicyConfig `json:"policy" yaml:"policy,omitempty"`
+	// GracefulRestart holds BGP graceful restart options
+	GracefulRestart BgpGracefulRestartConfig `json:"graceful_restart" yaml:"graceful_restart,omitempty"`
+	// Evpn holds options of the EVPN mode, in which routes are advertised as EVPN type-5 routes
+	Evpn BgpEvpnConfig `json:"evpn" yaml:"evpn,omitempty"`
 }
 
 // BgpExternalConnection represents a parsed structure from ExtraBgpConfig.ExternalConnection string.
 type BgpExternalConnection struct {
 	// Iface is a name of the physical interface that provides external connectivity
 	Iface string
+	// IPv4 is an optional IPv4 address, with prefix length, of the external connection
+	IPv4 netip.Prefix
+	// IPv6 is an optional IPv6 address, with prefix length, of the external connection
+	IPv6 netip.Prefix
+}
+
+// Networks returns list of addresses, in CIDR notation, configured on the external connection.
+func (c BgpExternalConnection) Networks() []string {
+	var networks []string
+	if c.IPv4.IsValid() {
+		networks = append(networks, c.IPv4.String())
+	}
+	if c.IPv6.IsValid() {
+		networks = append(networks, c.IPv6.String())
+	}
+	return networks
+}
+
+// IsUnnumbered returns "true" if the external connection does not have any address configured and
+// BGP unnumbered over IPv6 link-local addresses should be used.
+func (c BgpExternalConnection) IsUnnumbered() bool {
+	return !c.IPv4.IsValid() && !c.IPv6.IsValid()
+}
+
+// parseCidrs parses one or two CIDR addresses, of different families, separated by ":". As IPv6
+// addresses contain ":" as well, the separator is located by attempting to parse candidate parts.
+func parseCidrs(value string) ([]netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err == nil {
+		return []netip.Prefix{prefix}, nil
+	}
+
+	// IPv4 address can't contain ":", so in a valid pair it's either everything before the
+	// first ":" or everything after the last ":".
+	candidates := [][2]string{}
+	if first, rest, found := strings.Cut(value, ":"); found {
+		candidates = append(candidates, [2]string{first, rest})
+	}
+	if idx := strings.LastIndex(value, ":"); idx != -1 {
+		candidates = append(candidates, [2]string{value[:idx], value[idx+1:]})
+	}
+
+	for _, candidate := range candidates {
+		first, err := netip.ParsePrefix(candidate[0])
+		if err != nil {
+			continue
+		}
+		second, err := netip.ParsePrefix(candidate[1])
+		if err != nil {
+			continue
+		}
+		return []netip.Prefix{first, second}, nil
+	}
+
+	return nil, fmt.Errorf("invalid address '%s', expected CIDR notation (e.g. 192.0.2.1/24 or 2001:db8::1/64)", value)
+}
+
+// parseExternalConnection parses single "<iface_name>[:<cidr>][:<cidr>]" value.
+func parseExternalConnection(value string) (BgpExternalConnection, error) {
+	iface, addresses, _ := strings.Cut(strings.TrimSpace(value), ":")
+	connection := BgpExternalConnection{Iface: iface}
+	if iface == "" {
+		return connection, fmt.Errorf("missing interface name in external connection '%s'", value)
+	}
+
+	if addresses == "" {
+		return connection, nil
+	}
+
+	prefixes, err := parseCidrs(addresses)
+	if err != nil {
+		return connection, fmt.Errorf("external connection '%s': %w", value, err)
+	}
+
+	for _, prefix := range prefixes {
+		if prefix.Addr().Is4() {
+			if connection.IPv4.IsValid() {
+				return connection, fmt.Errorf("external connection '%s' has more than one IPv4 address", value)
+			}
+			connection.IPv4 = prefix
+		} else {
+			if connection.IPv6.IsValid() {
+				return connection, fmt.Errorf("external connection '%s' has more than one IPv6 address", value)
+			}
+			connection.IPv6 = prefix
+		}
+	}
+
+	return connection, nil
 }
 
 // parseAsnRange parses an ASN range string in format "min-max".
@@ -183,6 +294,17 @@ func validateAsnRange(asnRange [2]uint64) error {
 // FromMap initializes ExtraBgpConfig structure from the provided map of string keys and string values.
 // This functions also validates the resulting structure and returns error if the validation fails.
 func (bgpConf *ExtraBgpConfig) FromMap(rawConfig map[string]string) error {
+	err := bgpConf.SetFromMap(rawConfig)
+	if err != nil {
+		return err
+	}
+
+	return bgpConf.Validate()
+}
+
+// SetFromMap sets options of ExtraBgpConfig structure that are present in the provided map of string
+// keys and string values. Other options are left untouched and the resulting structure is not validated.
+func (bgpConf *ExtraBgpConfig) SetFromMap(rawConfig map[string]string) error {
 	for key, value := range rawConfig {
 		if key == "ext_connection" {
 			bgpConf.ExternalConnection = value
@@ -196,6 +318,14 @@ func (bgpConf *ExtraBgpConfig) FromMap(rawConfig map[string]string) error {
 			bgpConf.Asn = value
 			continue
 		}
+		if key == "daemon" {
+			bgpConf.Daemon = value
+			continue
+		}
+		if key == "network_backend" {
+			bgpConf.NetworkBackend = value
+			continue
+		}
 		if key == "asn_range" {
 			asnRange, err := parseAsnRange(value)
 			if err != nil {
@@ -204,10 +334,98 @@ func (bgpConf *ExtraBgpConfig) FromMap(rawConfig map[string]string) error {
 			bgpConf.AsnRange = asnRange
 			continue
 		}
+		if option, err := bgpConf.Policy.option(key); err == nil {
+			*option = value
+			continue
+		}
+		if option, err := bgpConf.GracefulRestart.option(key); err == nil {
+			*option = value
+			continue
+		}
+		if option, err := bgpConf.Evpn.option(key); err == nil {
+			*option = value
+			continue
+		}
+		// Per-connection options have "<iface_name>.<option>" format. Interface name may
+		// contain "." as well (e.g. VLAN interfaces), while option names do not.
+		if idx := strings.LastIndex(key, "."); idx > 0 {
+			err := bgpConf.setPeerOption(key[:idx], key[idx+1:], value)
+			if err != nil {
+				return err
+			}
+			continue
+		}
 		return fmt.Errorf("unknown BGP config option: %s", key)
 	}
 
-	return bgpConf.Validate()
+	return nil
+}
+
+// setPeerOption sets per-connection option "name" of the external connection "iface".
+func (bgpConf *ExtraBgpConfig) setPeerOption(iface string, name string, value string) error {
+	if bgpConf.Peers == nil {
+		bgpConf.Peers = make(map[string]BgpPeerConfig)
+	}
+
+	peer := bgpConf.Peers[iface]
+	option, err := peer.option(name)
+	if err != nil {
+		return err
+	}
+	*option = value
+	bgpConf.Peers[iface] = peer
+
+	return nil
+}
+
+// RedactSecrets replaces secrets, like BGP session passwords, with BgpRedactedSecret.
+func (bgpConf *ExtraBgpConfig) RedactSecrets() {
+	for iface, peer := range bgpConf.Peers {
+		if peer.Password != "" {
+			peer.Password = BgpRedactedSecret
+			bgpConf.Peers[iface] = peer
+		}
+	}
+}
+
+// ToMap returns options of ExtraBgpConfig structure that are set, as a map of string keys and string values.
+// Keys of the map are the same as those accepted by FromMap.
+func (bgpConf *ExtraBgpConfig) ToMap() map[string]string {
+	rawConfig := make(map[string]string)
+	if bgpConf.ExternalConnection != "" {
+		rawConfig["ext_connection"] = bgpConf.ExternalConnection
+	}
+	if bgpConf.Vrf != "" {
+		rawConfig["vrf"] = bgpConf.Vrf
+	}
+	if bgpConf.Asn != "" {
+		rawConfig["asn"] = bgpConf.Asn
+	}
+	if bgpConf.Daemon != "" {
+		rawConfig["daemon"] = bgpConf.Daemon
+	}
+	if bgpConf.NetworkBackend != "" {
+		rawConfig["network_backend"] = bgpConf.NetworkBackend
+	}
+	if bgpConf.AsnRange != [2]uint64{} {
+		rawConfig["asn_range"] = fmt.Sprintf("%d-%d", bgpConf.AsnRange[0], bgpConf.AsnRange[1])
+	}
+	for name, value := range bgpConf.Policy.options() {
+		rawConfig[name] = value
+	}
+	for name, value := range bgpConf.GracefulRestart.options() {
+		rawConfig[name] = value
+	}
+	for name, value := range bgpConf.Evpn.options() {
+		rawConfig[name] = value
+	}
+	for iface, peer := range bgpConf.Peers {
+		for name, value := range peer.options() {
+			rawConfig[iface+"."+name] = value
+		}
+	}
+
+	return rawConfig
 }
 
 // Validate ensures that all required fields of ExtraBgpConfig are present and that they have
@@ -229,6 +447,14 @@ func (bgpConf *ExtraBgpConfig) Validate() error {
 		}
 	}
 
+	if bgpConf.Daemon != "" && bgpConf.Daemon != BgpDaemonBird && bgpConf.Daemon != BgpDaemonFrr {
+		return fmt.Errorf("option 'daemon' must be one of '%s', '%s': %s", BgpDaemonBird, BgpDaemonFrr, bgpConf.Daemon)
+	}
+
+	if bgpConf.NetworkBackend != "" && bgpConf.NetworkBackend != NetworkBackendNetplan && bgpConf.NetworkBackend != NetworkBackendNetlink {
+		return fmt.Errorf("option 'network_backend' must be one of '%s', '%s': %s", NetworkBackendNetplan, NetworkBackendNetlink, bgpConf.NetworkBackend)
+	}
+
 	// Validate ASN range if provided
 	if bgpConf.AsnRange[0] != 0 || bgpConf.AsnRange[1] != 0 {
 		err := validateAsnRange(bgpConf.AsnRange)
@@ -246,6 +472,46 @@ func (bgpConf *ExtraBgpConfig) Validate() error {
 		return fmt.Errorf("external connections have to be set")
 	}
 
+	err = bgpConf.Policy.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = bgpConf.GracefulRestart.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = bgpConf.Evpn.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Routes received from an EVPN fabric are imported into the VRF by their route targets, prefix
+	// based import policy is applied only to plain IPv4/IPv6 unicast routes
+	if bgpConf.Evpn.IsEnabled() {
+		for name, value := range map[string]string{
+			BgpPolicyOptionImportAllow:        bgpConf.Policy.ImportAllow,
+			BgpPolicyOptionImportDeny:         bgpConf.Policy.ImportDeny,
+			BgpPolicyOptionAcceptDefaultRoute: bgpConf.Policy.AcceptDefaultRoute,
+		} {
+			if value != "" {
+				return fmt.Errorf("option '%s' is not supported when option '%s' is 'true'", name, BgpEvpnOptionEnabled)
+			}
+		}
+	}
+
+	for iface, peer := range bgpConf.Peers {
+		if !slices.ContainsFunc(extConnections, func(c BgpExternalConnection) bool { return c.Iface == iface }) {
+			return fmt.Errorf("per-connection options are set for '%s', which is not an external connection", iface)
+		}
+
+		err = peer.Validate(iface)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -254,9 +520,11 @@ func (bgpConf *ExtraBgpConfig) Validate() error {
 func (bgpConf *ExtraBgpConfig) ParseExternalConnection() ([]BgpExternalConnection, error) {
 	parsedConnections := make([]BgpExternalConnection, 0)
 	for _, extConn := range strings.Split(bgpConf.ExternalConnection, ",") {
-		parsedConnections = append(parsedConnections, BgpExternalConnection{
-			Iface: extConn,
-		})
+		parsedConnection, err := parseExternalConnection(extConn)
+		if err != nil {
+			return nil, err
+		}
+		parsedConnections = append(parsedConnections, parsedConnection)
 	}
 
 	return parsedConnections, nil
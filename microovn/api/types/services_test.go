@@ -0,0 +1,87 @@
+package types
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestParseExternalConnection(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected BgpExternalConnection
+	}{
+		{
+			value:    "eth1",
+			expected: BgpExternalConnection{Iface: "eth1"},
+		},
+		{
+			value:    "eth1:192.0.2.1/24",
+			expected: BgpExternalConnection{Iface: "eth1", IPv4: netip.MustParsePrefix("192.0.2.1/24")},
+		},
+		{
+			value:    "eth1:2001:db8::1/64",
+			expected: BgpExternalConnection{Iface: "eth1", IPv6: netip.MustParsePrefix("2001:db8::1/64")},
+		},
+		{
+			value: "eth1:192.0.2.1/24:2001:db8::1/64",
+			expected: BgpExternalConnection{
+				Iface: "eth1",
+				IPv4:  netip.MustParsePrefix("192.0.2.1/24"),
+				IPv6:  netip.MustParsePrefix("2001:db8::1/64"),
+			},
+		},
+		{
+			value: "eth1:2001:db8::1/64:192.0.2.1/24",
+			expected: BgpExternalConnection{
+				Iface: "eth1",
+				IPv4:  netip.MustParsePrefix("192.0.2.1/24"),
+				IPv6:  netip.MustParsePrefix("2001:db8::1/64"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			connection, err := parseExternalConnection(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if connection != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, connection)
+			}
+		})
+	}
+}
+
+func TestParseExternalConnectionInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		":192.0.2.1/24",
+		"eth1:192.0.2.1",
+		"eth1:192.0.2.1/24:198.51.100.1/24",
+		"eth1:2001:db8::1/64:2001:db8:1::1/64",
+	} {
+		t.Run(value, func(t *testing.T) {
+			_, err := parseExternalConnection(value)
+			if err == nil {
+				t.Errorf("expected error for '%s'", value)
+			}
+		})
+	}
+}
+
+func TestBgpExternalConnectionNetworks(t *testing.T) {
+	connection := BgpExternalConnection{
+		Iface: "eth1",
+		IPv4:  netip.MustParsePrefix("192.0.2.1/24"),
+		IPv6:  netip.MustParsePrefix("2001:db8::1/64"),
+	}
+
+	networks := connection.Networks()
+	if len(networks) != 2 || networks[0] != "192.0.2.1/24" || networks[1] != "2001:db8::1/64" {
+		t.Errorf("unexpected networks %v", networks)
+	}
+	if connection.IsUnnumbered() {
+		t.Error("connection with addresses reported as unnumbered")
+	}
+}
@@ -0,0 +1,323 @@
+package bgp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"strconv"
+
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// Kinds of values that are allocated to cluster members through the "bgp_allocations" table. Each value
+// of a kind can be allocated only once in the cluster.
+const (
+	allocationRouterID = "router_id"
+	allocationLrpMac   = "lrp_mac"
+	allocationVrf      = "vrf"
+	allocationAsn      = "asn"
+)
+
+// allocationDescriptions are human-readable names of allocation kinds, used in error messages.
+var allocationDescriptions = map[string]string{
+	allocationRouterID: "BGP router ID",
+	allocationLrpMac:   "MAC address",
+	allocationVrf:      "VRF table ID",
+	allocationAsn:      "ASN",
+}
+
+// maxHashAttempts limits the number of alternative values tried when a value derived from a hash collides
+// with a value allocated to a different resource.
+const maxHashAttempts = 100
+
+// defaultAsnRange is the range of auto-selected ASNs, used when the user does not provide one. The values
+// 4200000000-4209999999 are reserved for lower tier network infrastructure to enable structured ASN
+// allocation schemes.
+var defaultAsnRange = [2]uint64{4210000000, 4294967294}
+
+// selectAllocation returns value of "kind" for resource "name" of cluster "member", given values that are
+// already allocated in the cluster. If "requested" is not empty, it's returned unless it's allocated to
+// a different resource. Otherwise, the value already allocated to the resource is kept, or the first
+// unallocated value from "candidates" is selected.
+func selectAllocation(allocations []database.BgpAllocation, kind string, member string, name string, requested string, candidates iter.Seq[string]) (string, error) {
+	var owned string
+	allocated := make(map[string]database.BgpAllocation, len(allocations))
+	for _, allocation := range allocations {
+		allocated[allocation.Value] = allocation
+		if allocation.Member == member && allocation.Name == name {
+			owned = allocation.Value
+		}
+	}
+
+	if requested != "" {
+		allocation, ok := allocated[requested]
+		if ok && (allocation.Member != member || allocation.Name != name) {
+			return "", fmt.Errorf("%s %s is already used by cluster member '%s'", allocationDescriptions[kind], requested, allocation.Member)
+		}
+		return requested, nil
+	}
+
+	if owned != "" {
+		return owned, nil
+	}
+
+	for candidate := range candidates {
+		if _, ok := allocated[candidate]; !ok {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to find an available %s", allocationDescriptions[kind])
+}
+
+// allocate records value of "kind" for resource "name" of the local member in the cluster database and
+// returns it. The value is selected by selectAllocation, a value previously allocated to the same resource
+// is released if a different value is requested.
+func allocate(ctx context.Context, s state.State, kind string, name string, requested string, candidates iter.Seq[string]) (string, error) {
+	var value string
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		allocations, err := database.GetBgpAllocations(ctx, tx, database.BgpAllocationFilter{Kind: &kind})
+		if err != nil {
+			return err
+		}
+
+		value, err = selectAllocation(allocations, kind, s.Name(), name, requested, candidates)
+		if err != nil {
+			return err
+		}
+
+		for _, allocation := range allocations {
+			if allocation.Member != s.Name() || allocation.Name != name {
+				continue
+			}
+			if allocation.Value == value {
+				return nil
+			}
+
+			err = database.DeleteBgpAllocation(ctx, tx, kind, allocation.Value)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = database.CreateBgpAllocation(ctx, tx, database.BgpAllocation{Member: s.Name(), Kind: kind, Name: name, Value: value})
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate %s: %w", allocationDescriptions[kind], err)
+	}
+
+	return value, nil
+}
+
+// previewAllocation returns value of "kind" that allocate would select for resource "name" of the local
+// member, without recording it in the cluster database.
+func previewAllocation(ctx context.Context, s state.State, kind string, name string, requested string, candidates iter.Seq[string]) (string, error) {
+	var value string
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		allocations, err := database.GetBgpAllocations(ctx, tx, database.BgpAllocationFilter{Kind: &kind})
+		if err != nil {
+			return err
+		}
+
+		value, err = selectAllocation(allocations, kind, s.Name(), name, requested, candidates)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// release removes allocation of "kind" for resource "name" of the local member from the cluster database.
+func release(ctx context.Context, s state.State, kind string, name string) error {
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		allocations, err := database.GetBgpAllocations(ctx, tx, database.BgpAllocationFilter{Member: ptr(s.Name())})
+		if err != nil {
+			return err
+		}
+
+		for _, allocation := range allocations {
+			if allocation.Kind == kind && allocation.Name == name {
+				err = database.DeleteBgpAllocation(ctx, tx, kind, allocation.Value)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release %s: %w", allocationDescriptions[kind], err)
+	}
+
+	return nil
+}
+
+// releaseAll removes all allocations of the local member from the cluster database.
+func releaseAll(ctx context.Context, s state.State) error {
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteBgpAllocations(ctx, tx, s.Name())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release BGP allocations: %w", err)
+	}
+
+	return nil
+}
+
+// ptr returns pointer to "value".
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// hashCandidates yields values derived from "name" by the hash function "generate". The first candidate
+// is always derived from the name alone, so that the same value is selected on each member rebuild unless
+// it collides. Further candidates are derived from the name with a numeric suffix.
+func hashCandidates(name string, generate func(string) string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if !yield(generate(name)) {
+			return
+		}
+		for i := 1; i < maxHashAttempts; i++ {
+			if !yield(generate(fmt.Sprintf("%s-%d", name, i))) {
+				return
+			}
+		}
+	}
+}
+
+// allocateLrpMac returns MAC address of Logical Router Port "lrpName", allocated in the cluster database.
+func allocateLrpMac(ctx context.Context, s state.State, lrpName string) (string, error) {
+	return allocate(ctx, s, allocationLrpMac, lrpName, "", hashCandidates(lrpName, generateLrpMac))
+}
+
+// previewLrpMac returns MAC address that allocateLrpMac would allocate to Logical Router Port "lrpName",
+// without recording it in the cluster database.
+func previewLrpMac(ctx context.Context, s state.State, lrpName string) (string, error) {
+	return previewAllocation(ctx, s, allocationLrpMac, lrpName, "", hashCandidates(lrpName, generateLrpMac))
+}
+
+// releaseLrpMacs releases MAC addresses of Logical Router Ports of external connections "extConnections".
+func releaseLrpMacs(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) error {
+	for _, extConnection := range extConnections {
+		err := release(ctx, s, allocationLrpMac, getLrpName(s, extConnection.Iface))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allocateRouterID returns BGP router ID of the local member, allocated in the cluster database. If
+// "requested" is empty, the ID is derived from Logical Router Port "lrpName".
+func allocateRouterID(ctx context.Context, s state.State, requested string, lrpName string) (string, error) {
+	return allocate(ctx, s, allocationRouterID, "", requested, hashCandidates(lrpName, generateBGPRouterID))
+}
+
+// allocateVrfTableID returns VRF table ID of the local member, allocated in the cluster database. If
+// "requested" is empty, the lowest table ID that is not used on the local system, nor allocated to any
+// other member, is selected.
+func allocateVrfTableID(ctx context.Context, s state.State, requested string) (string, error) {
+	if requested != "" {
+		return allocate(ctx, s, allocationVrf, "", requested, nil)
+	}
+
+	usedTableIDs, err := getUsedVrfTableIDs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return allocate(ctx, s, allocationVrf, "", "", vrfTableCandidates(usedTableIDs))
+}
+
+// vrfTableCandidates yields VRF table IDs, starting from 10, that are not present in "usedTableIDs".
+func vrfTableCandidates(usedTableIDs map[int]bool) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		const minID = 10
+		for i := minID; i <= math.MaxUint32; i++ {
+			if usedTableIDs[i] {
+				continue
+			}
+			if !yield(strconv.Itoa(i)) {
+				return
+			}
+		}
+	}
+}
+
+// allocateAsn returns ASN of the local member, allocated in the cluster database. If "requested" is empty,
+// ASN is auto-selected from "asnRange" (or the default range), starting with the value derived from the
+// cluster member ID.
+func allocateAsn(ctx context.Context, s state.State, requested string, asnRange [2]uint64) (string, error) {
+	if requested != "" {
+		return allocate(ctx, s, allocationAsn, "", requested, nil)
+	}
+
+	if asnRange == [2]uint64{} {
+		asnRange = defaultAsnRange
+	}
+
+	first, err := generateAsnFromClusterMemberID(ctx, s, asnRange)
+	if err != nil {
+		return "", err
+	}
+
+	firstAsn, err := strconv.ParseUint(first, 10, 32)
+	if err != nil {
+		return "", err
+	}
+
+	return allocate(ctx, s, allocationAsn, "", "", asnCandidates(firstAsn, asnRange))
+}
+
+// asnCandidates yields each ASN from "asnRange" exactly once, starting with "first" and wrapping around
+// at the end of the range.
+func asnCandidates(first uint64, asnRange [2]uint64) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		rangeSize := asnRange[1] - asnRange[0] + 1
+		if first < asnRange[0] || first > asnRange[1] {
+			first = asnRange[0]
+		}
+		offset := first - asnRange[0]
+		for i := uint64(0); i < rangeSize; i++ {
+			asn := asnRange[0] + (offset+i)%rangeSize
+			if !yield(strconv.FormatUint(asn, 10)) {
+				return
+			}
+		}
+	}
+}
+
+// recordAllocations records values used by applied BGP "config" of the local member, and MAC addresses of
+// Logical Router Ports of its "extConnections", in the cluster database. Values that are already recorded
+// are kept, conflicting values are reported as an error.
+func recordAllocations(ctx context.Context, s state.State, config appliedConfig, extConnections []types.BgpExternalConnection) error {
+	var allErrors error
+	_, err := allocateVrfTableID(ctx, s, config.Vrf)
+	allErrors = errors.Join(allErrors, err)
+
+	if config.Asn != "" {
+		_, err = allocateAsn(ctx, s, config.Asn, config.AsnRange)
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	if config.RouterID != "" {
+		_, err = allocateRouterID(ctx, s, config.RouterID, "")
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	for _, extConnection := range extConnections {
+		_, err = allocateLrpMac(ctx, s, getLrpName(s, extConnection.Iface))
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	return allErrors
+}
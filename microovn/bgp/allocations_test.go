@@ -0,0 +1,115 @@
+package bgp
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/database"
+)
+
+func TestSelectAllocation(t *testing.T) {
+	allocations := []database.BgpAllocation{
+		{Member: "node1", Kind: allocationVrf, Value: "10"},
+		{Member: "node2", Kind: allocationVrf, Value: "11"},
+	}
+	candidates := slices.Values([]string{"10", "11", "12"})
+
+	tests := []struct {
+		name      string
+		member    string
+		requested string
+		expected  string
+	}{
+		{name: "keep allocated value", member: "node1", expected: "10"},
+		{name: "first free candidate", member: "node3", expected: "12"},
+		{name: "requested free value", member: "node1", requested: "20", expected: "20"},
+		{name: "requested own value", member: "node2", requested: "11", expected: "11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := selectAllocation(allocations, allocationVrf, tt.member, "", tt.requested, candidates)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, value)
+			}
+		})
+	}
+}
+
+func TestSelectAllocationConflict(t *testing.T) {
+	allocations := []database.BgpAllocation{
+		{Member: "node1", Kind: allocationAsn, Value: "65001"},
+		{Member: "node2", Kind: allocationAsn, Value: "65002"},
+	}
+
+	_, err := selectAllocation(allocations, allocationAsn, "node1", "", "65002", nil)
+	if err == nil || err.Error() != "ASN 65002 is already used by cluster member 'node2'" {
+		t.Errorf("expected conflict with node2, got: %v", err)
+	}
+
+	_, err = selectAllocation(allocations, allocationAsn, "node3", "", "", slices.Values([]string{"65001", "65002"}))
+	if err == nil {
+		t.Errorf("expected error when all candidates are allocated")
+	}
+}
+
+func TestSelectAllocationPerResource(t *testing.T) {
+	allocations := []database.BgpAllocation{
+		{Member: "node1", Kind: allocationLrpMac, Name: "lrp-node1-eth1", Value: generateLrpMac("lrp-node1-eth1")},
+	}
+
+	// A different resource with colliding hash gets the next candidate
+	candidates := hashCandidates("lrp-node1-eth1", generateLrpMac)
+	value, err := selectAllocation(allocations, allocationLrpMac, "node1", "lrp-node1-eth2", "", candidates)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != generateLrpMac("lrp-node1-eth1-1") {
+		t.Errorf("expected MAC derived from the next candidate, got '%s'", value)
+	}
+}
+
+func TestAsnCandidates(t *testing.T) {
+	tests := []struct {
+		first    uint64
+		expected []string
+	}{
+		{first: 65002, expected: []string{"65002", "65003", "65000", "65001"}},
+		{first: 65000, expected: []string{"65000", "65001", "65002", "65003"}},
+		// Values outside of the range start from its beginning
+		{first: 64999, expected: []string{"65000", "65001", "65002", "65003"}},
+	}
+
+	for _, tt := range tests {
+		candidates := slices.Collect(asnCandidates(tt.first, [2]uint64{65000, 65003}))
+		if !slices.Equal(candidates, tt.expected) {
+			t.Errorf("expected candidates %v for %d, got %v", tt.expected, tt.first, candidates)
+		}
+	}
+}
+
+func TestVrfTableCandidates(t *testing.T) {
+	next, stop := iter.Pull(vrfTableCandidates(map[int]bool{10: true, 12: true}))
+	defer stop()
+
+	for _, expected := range []string{"11", "13", "14"} {
+		candidate, ok := next()
+		if !ok || candidate != expected {
+			t.Errorf("expected candidate '%s', got '%s'", expected, candidate)
+		}
+	}
+}
+
+func TestHashCandidates(t *testing.T) {
+	candidates := slices.Collect(hashCandidates("lrp-node1-eth1", generateBGPRouterID))
+	if len(candidates) != maxHashAttempts {
+		t.Fatalf("expected %d candidates, got %d", maxHashAttempts, len(candidates))
+	}
+	if candidates[0] != generateBGPRouterID("lrp-node1-eth1") {
+		t.Errorf("expected first candidate to be derived from the name only, got '%s'", candidates[0])
+	}
+}
@@ -0,0 +1,288 @@
+package bgp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/canonical/lxd/shared"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/snap"
+)
+
+// BirdService - Name of the Bird routing daemon service managed by MicroOVN
+const BirdService = "bird"
+
+// birdTemplateInput - input data for the birdConfTemplate
+type birdTemplateInput struct {
+	VrfTableID string
+	VrfName    string
+	RouterID   string
+	Sessions   []bgpSession
+	Filters    []routeFilter
+	ASN        string
+
+	GracefulRestart types.BgpGracefulRestartConfig
+}
+
+// birdPrefixSet formats "prefixes" as items of a BIRD prefix set. Each item matches the prefix itself
+// and all of its more specific prefixes.
+func birdPrefixSet(prefixes []netip.Prefix) string {
+	items := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		items = append(items, prefix.String()+"+")
+	}
+	return strings.Join(items, ", ")
+}
+
+// birdPeerAs formats AS number of a BGP peer for the "neighbor" option of BIRD's BGP protocol.
+func birdPeerAs(peerAsn string) string {
+	if peerAsn == "external" || peerAsn == "internal" {
+		return peerAsn
+	}
+	return "as " + peerAsn
+}
+
+// birdConfTemplate - a template of a Bird configuration file that enables BGP daemon in dynamic
+// mode on specified interfaces, or with explicitly configured neighbors. See bgpSessions for details.
+var birdConfTemplate = template.Must(
+	template.New("bird.conf").
+		Funcs(template.FuncMap{"birdPrefixSet": birdPrefixSet, "birdPeerAs": birdPeerAs}).
+		Parse(`
+log syslog all;
+protocol device {};
+protocol direct {
+	disabled;	# Disable learning directly connected routes
+	ipv4;
+	ipv6;
+}
+
+protocol kernel kernel4 {
+	ipv4 {
+		export all;
+	};
+	learn;
+	kernel table {{ .VrfTableID }};
+	merge paths yes;
+{{- if .GracefulRestart.IsEnabled }}
+	# Keep routes in the kernel table until BGP sessions converge after a graceful restart
+	graceful restart on;{{ end }}
+}
+
+protocol kernel kernel6 {
+	ipv6 {
+		export all;
+	};
+	learn;
+	kernel table {{ .VrfTableID }};
+	merge paths yes;
+{{- if .GracefulRestart.IsEnabled }}
+	# Keep routes in the kernel table until BGP sessions converge after a graceful restart
+	graceful restart on;{{ end }}
+}
+
+protocol static {
+	ipv4;
+}
+
+protocol bfd {
+	# The BIRD BFD code is currently not fully VRF aware, as long as we
+	# only have interfaces in VRFs, using strict bind allows it to work.
+	#
+	# In the event we some time in the future want to speak on both VRF
+	# and non-VRF interfaces, we can instantiate multiple BFD instances.
+	#
+	# Ref: https://bird.network.cz/?get_doc&v=30&f=bird-6.html#ss6.3
+	strict bind yes;
+}
+
+{{ range .Filters }}
+filter {{ .Name }} {
+{{- if .RejectAll }}
+	reject;
+{{- else }}
+{{- if .RejectDefault }}
+	if net = {{ .DefaultRoute }} then reject;{{ end }}
+{{- with .Deny }}
+	if net ~ [ {{ birdPrefixSet . }} ] then reject;{{ end }}
+{{- with .Allow }}
+	if net !~ [ {{ birdPrefixSet . }} ] then reject;{{ end }}
+{{- range .Communities }}{{ if .IsLarge }}
+	bgp_large_community.add(({{ index . 0 }}, {{ index . 1 }}, {{ index . 2 }}));{{ else }}
+	bgp_community.add(({{ index . 0 }}, {{ index . 1 }}));{{ end }}{{ end }}
+{{- with .LocalPref }}
+	bgp_local_pref = {{ . }};{{ end }}
+{{- range .Prepend }}
+	bgp_path.prepend({{ . }});{{ end }}
+	accept;
+{{- end }}
+}
+{{ end }}{{ range .Sessions }}
+protocol bgp {{ .Name }} {
+	router id {{ $.RouterID }};
+	interface "{{ .Interface }}";
+	vrf "{{ $.VrfName }}";
+	local {{ if .LocalAddress.IsValid }}{{ .LocalAddress }} {{ end }}as {{ $.ASN }};
+{{- if .Neighbor.IsValid }}
+	neighbor {{ .Neighbor }} {{ birdPeerAs .PeerAsn }};
+{{- else }}
+	neighbor range {{ .NeighborRange }} {{ birdPeerAs .PeerAsn }};
+	dynamic name "dyn_{{ .Name }}_";{{ end }}
+{{- with .Peer.HoldTime }}
+	hold time {{ . }};{{ end }}
+{{- with .Peer.KeepaliveTime }}
+	keepalive time {{ . }};{{ end }}
+{{- if $.GracefulRestart.IsEnabled }}
+	graceful restart on;
+	graceful restart time {{ $.GracefulRestart.Time }};{{ end }}
+{{- if $.GracefulRestart.IsLongLived }}
+	long lived graceful restart on;
+	long lived stale time {{ $.GracefulRestart.LongLivedStaleTime }};{{ end }}
+{{- if .Peer.Password }}{{ if eq .Peer.Auth "ao" }}
+	authentication ao;
+	keys {
+		key {
+			id 0;
+			send id 0;
+			recv id 0;
+			secret "{{ .Peer.Password }}";
+			algorithm hmac sha256;
+		};
+	};{{ else }}
+	password "{{ .Peer.Password }}";{{ end }}{{ end }}
+{{- if .IPv4 }}
+	ipv4 {
+		next hop self ebgp;
+{{- if .ExtendedNextHop }}
+		extended next hop on;
+		require extended next hop on;{{ end }}
+		import filter microovn_import_v4;
+		export filter microovn_export_v4;
+	};{{ end }}
+{{- if .IPv6 }}
+	ipv6 {
+		import filter microovn_import_v6;
+		export filter microovn_export_v6;
+	};{{ end }}
+	bfd {
+		# We only want to use BFD for liveness and failure detection if
+		# our peer has it configured.
+		passive yes;
+{{- with .Peer.BfdMinRx }}
+		min rx interval {{ . }} ms;{{ end }}
+{{- with .Peer.BfdMinTx }}
+		min tx interval {{ . }} ms;{{ end }}
+{{- with .Peer.BfdMultiplier }}
+		multiplier {{ . }};{{ end }}
+	};
+}
+{{ end }}`))
+
+// birdDaemon - BIRD Internet Routing Daemon, the default routing daemon bundled with MicroOVN
+type birdDaemon struct{}
+
+// Name returns name of the routing daemon
+func (birdDaemon) Name() string {
+	return types.BgpDaemonBird
+}
+
+// Executable returns path to the BIRD executable
+func (birdDaemon) Executable() string {
+	return paths.BirdBinary()
+}
+
+// Start starts and enables BIRD service
+func (birdDaemon) Start(ctx context.Context) error {
+	return snap.Start(ctx, BirdService, true)
+}
+
+// Stop stops and disables BIRD service
+func (birdDaemon) Stop(ctx context.Context) error {
+	return snap.Stop(ctx, BirdService, true)
+}
+
+// Validate returns error if BIRD does not support options in "config"
+func (birdDaemon) Validate(config types.ExtraBgpConfig) error {
+	if config.Evpn.IsEnabled() {
+		return fmt.Errorf("BIRD does not support EVPN, option '%s' requires option 'daemon' to be '%s'",
+			types.BgpEvpnOptionEnabled, types.BgpDaemonFrr)
+	}
+
+	return nil
+}
+
+// Configure configures BIRD to start BGP processes listening on each interface in extConnections.
+// Each BGP daemon is connected to the VRF table specified in "config". It will announce routes from the VRF
+// to its peers, and it will insert routes announced by its peers into the same VRF, as allowed by the route
+// policy.
+// All BGP daemons will be configured with the ASN and router ID from "config", per-connection peering
+// options are taken from it as well. Unchanged BGP protocols are kept running when BIRD reloads its
+// configuration.
+func (birdDaemon) Configure(ctx context.Context, extConnections []types.BgpExternalConnection, config appliedConfig) error {
+	filters, err := routeFilters(config.Policy, config.Asn)
+	if err != nil {
+		return fmt.Errorf("failed to render BGP route policy: %w", err)
+	}
+
+	err = writeDaemonConfig(paths.BirdConfigFile(), func(file *os.File) error {
+		return birdConfTemplate.Execute(file, birdTemplateInput{
+			VrfTableID: config.Vrf,
+			VrfName:    getVrfName(config.Vrf),
+			RouterID:   config.RouterID,
+			Sessions:   bgpSessions(extConnections, config.Peers),
+			Filters:    filters,
+			ASN:        config.Asn,
+
+			GracefulRestart: config.GracefulRestart,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render Bird configuration template: %w", err)
+	}
+
+	out, err := shared.RunCommandContext(ctx, filepath.Join(paths.Wrappers(), "birdc"), "configure")
+	if err != nil {
+		return fmt.Errorf("failed to apply Bird configuration: %w: %s", err, out)
+	}
+	return nil
+}
+
+// ResetConfig backs up BIRD configuration and replaces it with the default one
+func (birdDaemon) ResetConfig(ctx context.Context) error {
+	backupConfig := fmt.Sprintf("%s_%d", paths.BirdConfigFile(), time.Now().Unix())
+	_, err := shared.RunCommandContext(ctx, "cp", paths.BirdConfigFile(), backupConfig)
+	if err != nil {
+		return fmt.Errorf("failed to backup Bird config. Will not proceed with its removal: %v", err)
+	}
+
+	_, err = shared.RunCommandContext(ctx, "cp", paths.BirdDefaultConfig(), paths.BirdConfigDir())
+	if err != nil {
+		return fmt.Errorf("failed to reset Bird config: %v", err)
+	}
+
+	return nil
+}
+
+// Sessions returns state of BGP protocols running in BIRD. BIRD runs only BGP protocols in the VRF
+// used for BGP redirect, "vrfName" is therefore not used to filter them.
+func (birdDaemon) Sessions(ctx context.Context, _ string) ([]types.BgpProtocolStatus, error) {
+	output, err := shared.RunCommandContext(ctx, filepath.Join(paths.Wrappers(), "birdc"), "show", "protocols", "all")
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to query BIRD"), err)
+	}
+
+	return parseBirdProtocols(output), nil
+}
+
+// RouteProtocol returns protocol of routes that BIRD installs into the kernel
+func (birdDaemon) RouteProtocol() string {
+	return "bird"
+}
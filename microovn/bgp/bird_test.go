@@ -0,0 +1,60 @@
+package bgp
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestBirdConfTemplate(t *testing.T) {
+	extConnections := []types.BgpExternalConnection{
+		{Iface: "eth1"},
+		{Iface: "eth2", IPv4: netip.MustParsePrefix("192.0.2.1/24")},
+		{Iface: "eth3", IPv4: netip.MustParsePrefix("198.51.100.1/24")},
+	}
+	peers := map[string]types.BgpPeerConfig{
+		"eth1": {PeerAsn: "internal", BfdMultiplier: "5"},
+		"eth3": {Neighbor: "198.51.100.2", PeerAsn: "65003", Password: "secret", Auth: types.BgpAuthAo},
+	}
+	filters, err := routeFilters(types.BgpPolicyConfig{ImportDeny: "10.0.0.0/8", ExportCommunities: "4200000000:1:2"}, "65000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var config strings.Builder
+	err = birdConfTemplate.Execute(&config, birdTemplateInput{
+		VrfTableID: "10",
+		VrfName:    "ovnvrf10",
+		RouterID:   "192.0.2.10",
+		Sessions:   bgpSessions(extConnections, peers),
+		Filters:    filters,
+		ASN:        "65000",
+
+		GracefulRestart: types.BgpGracefulRestartConfig{Enabled: "true", LongLived: "true", StaleTime: "7200"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"\tkernel table 10;\n",
+		"\tif net ~ [ 10.0.0.0/8+ ] then reject;\n",
+		"\tbgp_large_community.add((4200000000, 1, 2));\n",
+		"protocol bgp microovn_eth1 {\n",
+		"\tneighbor range fe80::/10 internal;\n\tdynamic name \"dyn_microovn_eth1_\";\n",
+		"\t\tmultiplier 5;\n",
+		"\tlocal 192.0.2.1 as 65000;\n\tneighbor range 192.0.2.0/24 external;\n",
+		"\tneighbor 198.51.100.2 as 65003;\n",
+		"\tauthentication ao;\n",
+		"\t\t\tsecret \"secret\";\n",
+		"\tmerge paths yes;\n\t# Keep routes in the kernel table until BGP sessions converge after a graceful restart\n\tgraceful restart on;\n",
+		"\tgraceful restart on;\n\tgraceful restart time 120;\n",
+		"\tlong lived graceful restart on;\n\tlong lived stale time 7200;\n",
+	} {
+		if !strings.Contains(config.String(), expected) {
+			t.Errorf("expected BIRD configuration to contain %q, got:\n%s", expected, config.String())
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package bgp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// Keys of the "bgp_config" table that are not part of the user-facing BGP config options.
+const (
+	configKeyManualBgpdConfig = "manual_bgpd_config"
+	configKeyRouterID         = "router_id"
+)
+
+// appliedConfig is the BGP configuration applied on a cluster member. Unlike the configuration
+// supplied by the user, values that were auto-selected (VRF table ID, ASN) are always set.
+type appliedConfig struct {
+	types.ExtraBgpConfig
+	// RouterID is the BGP router ID used by the BGP daemon. It is kept stable across configuration
+	// changes, so that removal of an external connection does not reset all BGP sessions.
+	RouterID string
+}
+
+// toMap returns the applied configuration as a map of keys and values stored in the "bgp_config" table.
+// Secrets are encrypted with "key".
+func (c appliedConfig) toMap(key []byte) (map[string]string, error) {
+	rawConfig := c.ToMap()
+	for name, value := range rawConfig {
+		if !isSecretKey(name) {
+			continue
+		}
+
+		encrypted, err := encryptSecret(key, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt option '%s': %w", name, err)
+		}
+		rawConfig[name] = encrypted
+	}
+
+	if c.ManualBgpdConfig {
+		rawConfig[configKeyManualBgpdConfig] = "true"
+	}
+	if c.RouterID != "" {
+		rawConfig[configKeyRouterID] = c.RouterID
+	}
+
+	return rawConfig, nil
+}
+
+// appliedConfigFromItems returns the applied configuration from records of the "bgp_config" table.
+// Secrets are decrypted with "key".
+func appliedConfigFromItems(items []database.BgpConfigItem, key []byte) (appliedConfig, error) {
+	var config appliedConfig
+	rawConfig := make(map[string]string)
+	for _, item := range items {
+		switch item.Key {
+		case configKeyManualBgpdConfig:
+			manual, err := strconv.ParseBool(item.Value)
+			if err != nil {
+				return config, fmt.Errorf("invalid value of '%s': %s", item.Key, item.Value)
+			}
+			config.ManualBgpdConfig = manual
+		case configKeyRouterID:
+			config.RouterID = item.Value
+		default:
+			value := item.Value
+			if isSecretKey(item.Key) {
+				var err error
+				value, err = decryptSecret(key, value)
+				if err != nil {
+					return config, fmt.Errorf("invalid value of '%s': %w", item.Key, err)
+				}
+			}
+			rawConfig[item.Key] = value
+		}
+	}
+
+	err := config.SetFromMap(rawConfig)
+	return config, err
+}
+
+// loadAppliedConfig returns BGP configuration applied on the local member, or nil if the configuration
+// is not known.
+func loadAppliedConfig(ctx context.Context, s state.State) (*appliedConfig, error) {
+	var items []database.BgpConfigItem
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		name := s.Name()
+		items, err = database.GetBgpConfigItems(ctx, tx, database.BgpConfigItemFilter{Member: &name})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied BGP configuration: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	key, err := secretKey(s)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := appliedConfigFromItems(items, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse applied BGP configuration: %w", err)
+	}
+
+	return &config, nil
+}
+
+// saveAppliedConfig stores BGP configuration applied on the local member, replacing any previously
+// stored configuration. Secrets are stored encrypted with the cluster key.
+func saveAppliedConfig(ctx context.Context, s state.State, config appliedConfig) error {
+	secret, err := secretKey(s)
+	if err != nil {
+		return err
+	}
+
+	rawConfig, err := config.toMap(secret)
+	if err != nil {
+		return err
+	}
+
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		err := database.DeleteBgpConfigItems(ctx, tx, s.Name())
+		if err != nil {
+			return err
+		}
+
+		for key, value := range rawConfig {
+			_, err = database.CreateBgpConfigItem(ctx, tx, database.BgpConfigItem{Member: s.Name(), Key: key, Value: value})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store applied BGP configuration: %w", err)
+	}
+
+	return nil
+}
+
+// removeAppliedConfig removes stored BGP configuration of the local member.
+func removeAppliedConfig(ctx context.Context, s state.State) error {
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteBgpConfigItems(ctx, tx, s.Name())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove applied BGP configuration: %w", err)
+	}
+
+	return nil
+}
+
+// MemberConfigs returns BGP configuration applied on each cluster member, keyed by the member name.
+// Members without known BGP configuration are not included.
+func MemberConfigs(ctx context.Context, s state.State) (map[string]types.BgpMemberConfig, error) {
+	itemsByMember := make(map[string][]database.BgpConfigItem)
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		items, err := database.GetBgpConfigItems(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			itemsByMember[item.Member] = append(itemsByMember[item.Member], item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read BGP configuration: %w", err)
+	}
+
+	key, err := secretKey(s)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make(map[string]types.BgpMemberConfig, len(itemsByMember))
+	for member, items := range itemsByMember {
+		config, err := appliedConfigFromItems(items, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse BGP configuration of member '%s': %w", member, err)
+		}
+
+		configs[member] = types.BgpMemberConfig{Member: member, Config: &config.ExtraBgpConfig, RouterID: config.RouterID}
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,122 @@
+package bgp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+func TestAppliedConfigRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		config appliedConfig
+	}{
+		{
+			name: "automatic configuration",
+			config: appliedConfig{
+				ExtraBgpConfig: types.ExtraBgpConfig{
+					ExternalConnection: "eth1,eth2:192.0.2.1/24",
+					Vrf:                "10",
+					Asn:                "4210000001",
+					AsnRange:           [2]uint64{4210000000, 4210000100},
+				},
+				RouterID: "10.20.30.40",
+			},
+		},
+		{
+			name: "peer with password",
+			config: appliedConfig{
+				ExtraBgpConfig: types.ExtraBgpConfig{
+					ExternalConnection: "eth1",
+					Vrf:                "10",
+					Asn:                "4210000001",
+					Peers: map[string]types.BgpPeerConfig{
+						"eth1": {Password: "s3cr3t-pass"},
+					},
+				},
+			},
+		},
+		{
+			name: "manual BGP daemon configuration",
+			config: appliedConfig{
+				ExtraBgpConfig: types.ExtraBgpConfig{
+					ExternalConnection: "eth1",
+					Vrf:                "10",
+					ManualBgpdConfig:   true,
+				},
+			},
+		},
+	}
+
+	key := make([]byte, 32)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawConfig, err := tt.config.toMap(key)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var items []database.BgpConfigItem
+			for key, value := range rawConfig {
+				items = append(items, database.BgpConfigItem{Member: "node1", Key: key, Value: value})
+			}
+
+			config, err := appliedConfigFromItems(items, key)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(config, tt.config) {
+				t.Errorf("expected %+v, got %+v", tt.config, config)
+			}
+		})
+	}
+}
+
+func TestAppliedConfigEncryptsPassword(t *testing.T) {
+	config := appliedConfig{
+		ExtraBgpConfig: types.ExtraBgpConfig{
+			ExternalConnection: "eth1",
+			Peers: map[string]types.BgpPeerConfig{
+				"eth1": {Password: "s3cr3t-pass"},
+			},
+		},
+	}
+
+	key := make([]byte, 32)
+	rawConfig, err := config.toMap(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for name, value := range rawConfig {
+		if strings.Contains(value, "s3cr3t-pass") {
+			t.Errorf("stored option '%s' contains the password in clear text: %s", name, value)
+		}
+	}
+	stored := rawConfig["eth1."+types.BgpPeerOptionPassword]
+	if !strings.HasPrefix(stored, encryptedPrefix) {
+		t.Errorf("expected encrypted password, got: %s", stored)
+	}
+
+	// Password can't be decrypted with a different key
+	otherKey := make([]byte, 32)
+	otherKey[0] = 1
+	items := []database.BgpConfigItem{{Member: "node1", Key: "eth1." + types.BgpPeerOptionPassword, Value: stored}}
+	_, err = appliedConfigFromItems(items, otherKey)
+	if err == nil {
+		t.Error("expected error when decrypting with a different key")
+	}
+
+	// Passwords stored in plain text by earlier versions are still accepted
+	items[0].Value = "s3cr3t-pass"
+	loaded, err := appliedConfigFromItems(items, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if loaded.Peers["eth1"].Password != "s3cr3t-pass" {
+		t.Errorf("expected password from plain text value, got: %+v", loaded.Peers["eth1"])
+	}
+}
@@ -0,0 +1,165 @@
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// RoutingDaemon is a routing daemon that runs BGP sessions with peers on external networks. The daemon
+// exchanges routes between its peers and the VRF used for BGP redirect.
+type RoutingDaemon interface {
+	// Name returns name of the routing daemon, as used in the "daemon" BGP config option
+	Name() string
+	// Executable returns path to the main executable of the routing daemon
+	Executable() string
+	// Start starts and enables snap services of the routing daemon
+	Start(ctx context.Context) error
+	// Stop stops and disables snap services of the routing daemon
+	Stop(ctx context.Context) error
+	// Validate returns error if the routing daemon does not support options in BGP configuration "config"
+	Validate(config types.ExtraBgpConfig) error
+	// Configure renders configuration of the routing daemon for external connections "extConnections" and
+	// applies it. BGP sessions that did not change are not interrupted.
+	Configure(ctx context.Context, extConnections []types.BgpExternalConnection, config appliedConfig) error
+	// ResetConfig backs up the current configuration of the routing daemon and replaces it with the default one
+	ResetConfig(ctx context.Context) error
+	// Sessions returns state of BGP sessions in VRF "vrfName"
+	Sessions(ctx context.Context, vrfName string) ([]types.BgpProtocolStatus, error)
+	// RouteProtocol returns protocol of routes that the routing daemon installs into the kernel
+	RouteProtocol() string
+}
+
+// routingDaemons lists all supported routing daemons, keyed by their name.
+var routingDaemons = map[string]RoutingDaemon{
+	types.BgpDaemonBird: birdDaemon{},
+	types.BgpDaemonFrr:  frrDaemon{},
+}
+
+// getRoutingDaemon returns routing daemon "name". BIRD is used if the name is empty.
+func getRoutingDaemon(name string) (RoutingDaemon, error) {
+	if name == "" {
+		name = types.BgpDaemonBird
+	}
+
+	daemon, ok := routingDaemons[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown routing daemon: %s", name)
+	}
+
+	return daemon, nil
+}
+
+// writeDaemonConfig writes configuration of a routing daemon into "path". The configuration may contain
+// BGP session passwords, it must not be readable by others.
+func writeDaemonConfig(path string, render func(file *os.File) error) error {
+	configFile, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open configuration file '%s' for writing: %w", path, err)
+	}
+	defer configFile.Close()
+
+	err = configFile.Chmod(0600)
+	if err != nil {
+		return fmt.Errorf("failed to restrict permissions of configuration file '%s': %w", path, err)
+	}
+
+	return render(configFile)
+}
+
+// bgpSession - a single BGP session (or a group of dynamic BGP sessions) that the routing daemon runs
+// on an external connection
+type bgpSession struct {
+	// Name is a unique name of the session, e.g. "microovn_eth1" or "microovn_eth1_ipv4"
+	Name string
+	// Interface is the BGP redirect interface on which the session runs
+	Interface string
+	// LocalAddress is the local address of the session, if known
+	LocalAddress netip.Addr
+	// Neighbor is the address of an explicitly configured neighbor
+	Neighbor netip.Addr
+	// NeighborRange is a range of addresses from which dynamic peers are accepted. For BGP
+	// unnumbered sessions, this is the range of IPv6 link-local addresses.
+	NeighborRange netip.Prefix
+	// Unnumbered is set for BGP unnumbered sessions over IPv6 link-local addresses
+	Unnumbered bool
+	// PeerAsn is the AS number of the peer, "external" or "internal"
+	PeerAsn string
+	// IPv4 and IPv6 are set for address families exchanged over the session. ExtendedNextHop is set
+	// if IPv4 routes are exchanged with IPv6 next hops.
+	IPv4            bool
+	IPv6            bool
+	ExtendedNextHop bool
+	// Peer holds remaining peering options of the external connection
+	Peer types.BgpPeerConfig
+}
+
+// bgpSessions returns BGP sessions for external connections "extConnections". Connections without
+// an explicit neighbor in "peers" accept dynamic peers, either over IPv6 link-local addresses (BGP
+// unnumbered) or from subnets of their static addresses. Connections with an explicit neighbor use
+// a single BGP session with it.
+func bgpSessions(extConnections []types.BgpExternalConnection, peers map[string]types.BgpPeerConfig) []bgpSession {
+	var sessions []bgpSession
+	for _, extConnection := range extConnections {
+		peer := peers[extConnection.Iface]
+		peerAsn := peer.PeerAsn
+		if peerAsn == "" {
+			peerAsn = "external"
+		}
+
+		session := bgpSession{
+			Name:      "microovn_" + extConnection.Iface,
+			Interface: getBgpRedirectIfaceName(extConnection.Iface),
+			PeerAsn:   peerAsn,
+			Peer:      peer,
+		}
+
+		if peer.Neighbor != "" {
+			session.Neighbor, _ = netip.ParseAddr(peer.Neighbor)
+			if session.Neighbor.Is4() {
+				session.IPv4 = true
+				if extConnection.IPv4.IsValid() {
+					session.LocalAddress = extConnection.IPv4.Addr()
+				}
+			} else {
+				session.IPv4, session.IPv6, session.ExtendedNextHop = true, true, true
+				if extConnection.IPv6.IsValid() {
+					session.LocalAddress = extConnection.IPv6.Addr()
+				}
+			}
+			sessions = append(sessions, session)
+			continue
+		}
+
+		if extConnection.IsUnnumbered() {
+			unnumbered := session
+			unnumbered.Unnumbered = true
+			unnumbered.NeighborRange = netip.MustParsePrefix("fe80::/10")
+			unnumbered.IPv4, unnumbered.IPv6, unnumbered.ExtendedNextHop = true, true, true
+			sessions = append(sessions, unnumbered)
+		}
+
+		if extConnection.IPv4.IsValid() {
+			ipv4 := session
+			ipv4.Name += "_ipv4"
+			ipv4.LocalAddress = extConnection.IPv4.Addr()
+			ipv4.NeighborRange = extConnection.IPv4.Masked()
+			ipv4.IPv4 = true
+			sessions = append(sessions, ipv4)
+		}
+
+		if extConnection.IPv6.IsValid() {
+			ipv6 := session
+			ipv6.Name += "_ipv6"
+			ipv6.LocalAddress = extConnection.IPv6.Addr()
+			ipv6.NeighborRange = extConnection.IPv6.Masked()
+			ipv6.IPv6 = true
+			sessions = append(sessions, ipv6)
+		}
+	}
+
+	return sessions
+}
@@ -0,0 +1,74 @@
+package bgp
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestBgpSessions(t *testing.T) {
+	extConnections := []types.BgpExternalConnection{
+		{Iface: "eth1"},
+		{Iface: "eth2", IPv4: netip.MustParsePrefix("192.0.2.1/24")},
+		{Iface: "eth3", IPv4: netip.MustParsePrefix("198.51.100.1/24")},
+	}
+	peers := map[string]types.BgpPeerConfig{
+		"eth1": {PeerAsn: "internal"},
+		"eth3": {Neighbor: "198.51.100.2", PeerAsn: "65003", Password: "secret", Auth: types.BgpAuthAo},
+	}
+
+	sessions := bgpSessions(extConnections, peers)
+	expected := []bgpSession{
+		{
+			Name:            "microovn_eth1",
+			Interface:       getBgpRedirectIfaceName("eth1"),
+			NeighborRange:   netip.MustParsePrefix("fe80::/10"),
+			Unnumbered:      true,
+			PeerAsn:         "internal",
+			IPv4:            true,
+			IPv6:            true,
+			ExtendedNextHop: true,
+			Peer:            peers["eth1"],
+		},
+		{
+			Name:          "microovn_eth2_ipv4",
+			Interface:     getBgpRedirectIfaceName("eth2"),
+			LocalAddress:  netip.MustParseAddr("192.0.2.1"),
+			NeighborRange: netip.MustParsePrefix("192.0.2.0/24"),
+			PeerAsn:       "external",
+			IPv4:          true,
+		},
+		{
+			Name:         "microovn_eth3",
+			Interface:    getBgpRedirectIfaceName("eth3"),
+			LocalAddress: netip.MustParseAddr("198.51.100.1"),
+			Neighbor:     netip.MustParseAddr("198.51.100.2"),
+			PeerAsn:      "65003",
+			IPv4:         true,
+			Peer:         peers["eth3"],
+		},
+	}
+
+	if !reflect.DeepEqual(sessions, expected) {
+		t.Errorf("expected sessions %+v, got %+v", expected, sessions)
+	}
+}
+
+func TestGetRoutingDaemon(t *testing.T) {
+	for name, expected := range map[string]string{"": types.BgpDaemonBird, "bird": types.BgpDaemonBird, "frr": types.BgpDaemonFrr} {
+		daemon, err := getRoutingDaemon(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if daemon.Name() != expected {
+			t.Errorf("expected routing daemon '%s' for '%s', got '%s'", expected, name, daemon.Name())
+		}
+	}
+
+	_, err := getRoutingDaemon("quagga")
+	if err == nil {
+		t.Errorf("expected unknown routing daemon to fail")
+	}
+}
@@ -0,0 +1,127 @@
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/netplan"
+	"github.com/canonical/microovn/microovn/network"
+)
+
+// evpnNetplanFile - netplan configuration of the VXLAN device and bridge used in EVPN mode
+const evpnNetplanFile = "91-microovn-bgp-evpn.yaml"
+
+// vxlanPort - IANA assigned UDP port of VXLAN
+const vxlanPort = 4789
+
+// getEvpnVxlanName returns name of the VXLAN device that terminates traffic of L3 VNI "vni".
+func getEvpnVxlanName(vni string) string {
+	return fmt.Sprintf("ovnvx%s", vni)
+}
+
+// getEvpnBridgeName returns name of the bridge that connects VXLAN device of L3 VNI "vni" to the VRF.
+func getEvpnBridgeName(vni string) string {
+	return fmt.Sprintf("ovnvxbr%s", vni)
+}
+
+// evpnConfig returns network configuration of the VXLAN device with the L3 VNI and local tunnel endpoint
+// from "evpn", and of the bridge that connects it to the VRF.
+func evpnConfig(evpn types.BgpEvpnConfig) (*netplan.Config, error) {
+	vni, err := strconv.Atoi(evpn.Vni)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EVPN VNI '%s': %v", evpn.Vni, err)
+	}
+
+	vxlanName := getEvpnVxlanName(evpn.Vni)
+	np := netplan.NewConfig()
+	np.AddVxlan(vxlanName, vni, evpn.Vtep, vxlanPort)
+	np.AddLinuxBridge(getEvpnBridgeName(evpn.Vni), []string{vxlanName})
+	return np, nil
+}
+
+// setupEvpn creates VXLAN device with the L3 VNI and local tunnel endpoint from "evpn", and connects it,
+// through a bridge, to the VRF specified by "tableID". The routing daemon binds the VRF to the L3 VNI
+// by the VXLAN device in it. Interfaces are created with the network "backend". Nothing is created if
+// EVPN mode is not enabled.
+func setupEvpn(ctx context.Context, s state.State, backend network.Backend, evpn types.BgpEvpnConfig, tableID string) error {
+	if !evpn.IsEnabled() {
+		return nil
+	}
+
+	err := checkKernelModule("vxlan")
+	if err != nil {
+		return fmt.Errorf("failed to create VXLAN device for EVPN: %v", err)
+	}
+
+	np, err := evpnConfig(evpn)
+	if err != nil {
+		return err
+	}
+
+	vxlanName := getEvpnVxlanName(evpn.Vni)
+	bridgeName := getEvpnBridgeName(evpn.Vni)
+	vrfName := getVrfName(tableID)
+
+	err = backend.Apply(ctx, s, evpnNetplanFile, *np)
+	if err != nil {
+		return err
+	}
+
+	// VRF is defined by the network configuration of the BGP redirect, the bridge is bound to it
+	// the same way as the BGP redirect interfaces are.
+	_, err = shared.RunCommandContext(ctx, "ip", "link", "set", "dev", bridgeName, "master", vrfName)
+	if err != nil {
+		return fmt.Errorf("failed to bind interface '%s' to VRF '%s': %v", bridgeName, vrfName, err)
+	}
+
+	for _, iface := range []string{vxlanName, bridgeName} {
+		_, err = shared.RunCommandContext(ctx, "ip", "link", "set", "dev", iface, "up")
+		if err != nil {
+			return fmt.Errorf("failed to bring up interface '%s': %v", iface, err)
+		}
+	}
+
+	return nil
+}
+
+// teardownEvpn removes VXLAN device and bridge that were created by setupEvpn.
+func teardownEvpn(ctx context.Context) error {
+	err := network.Cleanup(ctx, evpnNetplanFile)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup EVPN network configuration: %v", err)
+	}
+	return nil
+}
+
+// checkEvpn returns "true" if interfaces in the local system match EVPN options "evpn", i.e. VXLAN
+// device and bridge exist in EVPN mode, and network configuration of them does not exist otherwise.
+func checkEvpn(evpn types.BgpEvpnConfig) bool {
+	if !evpn.IsEnabled() {
+		return !network.ConfigExists(evpnNetplanFile)
+	}
+
+	for _, iface := range []string{getEvpnVxlanName(evpn.Vni), getEvpnBridgeName(evpn.Vni)} {
+		if !shared.PathExists(fmt.Sprintf("/sys/class/net/%s", iface)) {
+			return false
+		}
+	}
+	return true
+}
+
+// evpnDevicesChanged returns "true" if VXLAN device of EVPN mode has to be created again to apply
+// "desired" EVPN options in VRF "desiredTableID". Route targets are applied by the routing daemon only.
+func evpnDevicesChanged(current types.BgpEvpnConfig, desired types.BgpEvpnConfig, currentTableID string, desiredTableID string) bool {
+	if current.IsEnabled() != desired.IsEnabled() {
+		return true
+	}
+	if !desired.IsEnabled() {
+		return false
+	}
+
+	return current.Vni != desired.Vni || current.Vtep != desired.Vtep || currentTableID != desiredTableID
+}
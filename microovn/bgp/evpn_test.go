@@ -0,0 +1,39 @@
+package bgp
+
+import (
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestEvpnDevicesChanged(t *testing.T) {
+	disabled := types.BgpEvpnConfig{}
+	enabled := types.BgpEvpnConfig{Enabled: "true", Vni: "10100", Vtep: "192.0.2.10", ImportRt: "65000:1"}
+
+	tests := []struct {
+		name     string
+		current  types.BgpEvpnConfig
+		desired  types.BgpEvpnConfig
+		vrf      string
+		expected bool
+	}{
+		{name: "disabled", current: disabled, desired: disabled, vrf: "10", expected: false},
+		{name: "disabled with VRF change", current: disabled, desired: disabled, vrf: "20", expected: false},
+		{name: "enable", current: disabled, desired: enabled, vrf: "10", expected: true},
+		{name: "disable", current: enabled, desired: disabled, vrf: "10", expected: true},
+		{name: "unchanged", current: enabled, desired: enabled, vrf: "10", expected: false},
+		{name: "route targets", current: enabled, desired: enabled.Merge(types.BgpEvpnConfig{ImportRt: "65000:2"}), vrf: "10", expected: false},
+		{name: "VNI", current: enabled, desired: enabled.Merge(types.BgpEvpnConfig{Vni: "10200"}), vrf: "10", expected: true},
+		{name: "VTEP", current: enabled, desired: enabled.Merge(types.BgpEvpnConfig{Vtep: "192.0.2.20"}), vrf: "10", expected: true},
+		{name: "VRF", current: enabled, desired: enabled, vrf: "20", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := evpnDevicesChanged(tt.current, tt.desired, "10", tt.vrf)
+			if changed != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package bgp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/canonical/lxd/shared"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/snap"
+)
+
+// FrrService - Name of the FRR routing daemon service managed by MicroOVN
+const FrrService = "frr"
+
+// Default BGP timers of FRR, used when only one of the hold and keepalive times is configured.
+const (
+	frrDefaultKeepaliveTime = 60
+	frrDefaultHoldTime      = 180
+)
+
+// frrTemplateInput - input data for the frrConfTemplate
+type frrTemplateInput struct {
+	VrfName  string
+	RouterID string
+	Sessions []bgpSession
+	Filters  []routeFilter
+	ASN      string
+
+	GracefulRestart types.BgpGracefulRestartConfig
+	Evpn            types.BgpEvpnConfig
+}
+
+// frrFamily returns keyword that FRR uses for prefix lists of IPv4 or IPv6 routes.
+func frrFamily(ipv6 bool) string {
+	if ipv6 {
+		return "ipv6"
+	}
+	return "ip"
+}
+
+// frrPrefixList returns lines of FRR prefix list "name" that matches "prefixes" and all of their more
+// specific prefixes.
+func frrPrefixList(name string, ipv6 bool, prefixes []netip.Prefix) []string {
+	lines := make([]string, 0, len(prefixes))
+	for i, prefix := range prefixes {
+		line := fmt.Sprintf("%s prefix-list %s seq %d permit %s", frrFamily(ipv6), name, (i+1)*5, prefix)
+		if prefix.Bits() < prefix.Addr().BitLen() {
+			line += fmt.Sprintf(" le %d", prefix.Addr().BitLen())
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// frrCommunities formats standard (large == false) or large (large == true) BGP communities from
+// "communities" for the FRR "set community" and "set large-community" commands.
+func frrCommunities(communities []types.BgpCommunity, large bool) string {
+	var items []string
+	for _, community := range communities {
+		if community.IsLarge() != large {
+			continue
+		}
+
+		parts := make([]string, 0, len(community))
+		for _, part := range community {
+			parts = append(parts, strconv.FormatUint(uint64(part), 10))
+		}
+		items = append(items, strings.Join(parts, ":"))
+	}
+	return strings.Join(items, " ")
+}
+
+// frrTimers returns keepalive and hold time of the BGP session in format of the FRR "neighbor timers"
+// command, or an empty string if they are not configured. FRR requires both of them to be set, the
+// missing one is derived from the other.
+func frrTimers(peer types.BgpPeerConfig) string {
+	if peer.HoldTime == "" && peer.KeepaliveTime == "" {
+		return ""
+	}
+
+	keepalive, _ := strconv.Atoi(peer.KeepaliveTime)
+	hold, _ := strconv.Atoi(peer.HoldTime)
+	if peer.HoldTime == "" {
+		hold = max(keepalive*3, frrDefaultHoldTime)
+	}
+	if peer.KeepaliveTime == "" {
+		keepalive = min(hold/3, frrDefaultKeepaliveTime)
+	}
+
+	return fmt.Sprintf("%d %d", keepalive, hold)
+}
+
+// frrConfTemplate - a template of FRR's integrated configuration file. It configures bgpd in the VRF
+// used for BGP redirect with one peer group for each BGP session (see bgpSessions for details), and
+// bfdd with one passive BFD profile for each of them. In EVPN mode, the VRF is bound to the L3 VNI and
+// the sessions exchange EVPN type-5 routes instead of plain IPv4/IPv6 unicast routes.
+var frrConfTemplate = template.Must(
+	template.New("frr.conf").
+		Funcs(template.FuncMap{
+			"frrFamily":      frrFamily,
+			"frrPrefixList":  frrPrefixList,
+			"frrCommunities": frrCommunities,
+			"frrTimers":      frrTimers,
+			"join":           strings.Join,
+		}).
+		Parse(`frr defaults traditional
+log syslog informational
+!
+{{- range .Filters }}{{ $family := frrFamily .IPv6 }}
+{{- if .RejectDefault }}
+{{ $family }} prefix-list {{ .Name }}_default seq 5 permit {{ .DefaultRoute }}{{ end }}
+{{- range frrPrefixList (print .Name "_deny") .IPv6 .Deny }}
+{{ . }}{{ end }}
+{{- range frrPrefixList (print .Name "_allow") .IPv6 .Allow }}
+{{ . }}{{ end }}
+{{- if .RejectAll }}
+route-map {{ .Name }} deny 10
+exit
+{{- else }}
+{{- if .RejectDefault }}
+route-map {{ .Name }} deny 10
+ match {{ $family }} address prefix-list {{ .Name }}_default
+exit{{ end }}
+{{- if .Deny }}
+route-map {{ .Name }} deny 20
+ match {{ $family }} address prefix-list {{ .Name }}_deny
+exit{{ end }}
+route-map {{ .Name }} permit 30
+{{- if .Allow }}
+ match {{ $family }} address prefix-list {{ .Name }}_allow{{ end }}
+{{- with frrCommunities .Communities false }}
+ set community {{ . }} additive{{ end }}
+{{- with frrCommunities .Communities true }}
+ set large-community {{ . }} additive{{ end }}
+{{- with .LocalPref }}
+ set local-preference {{ . }}{{ end }}
+{{- with .Prepend }}
+ set as-path prepend {{ join . " " }}{{ end }}
+exit
+{{- end }}
+!
+{{- end }}
+bfd
+{{- range .Sessions }}
+ profile {{ .Name }}
+  ! We only want to use BFD for liveness and failure detection if
+  ! our peer has it configured.
+  passive-mode
+{{- with .Peer.BfdMinRx }}
+  receive-interval {{ . }}{{ end }}
+{{- with .Peer.BfdMinTx }}
+  transmit-interval {{ . }}{{ end }}
+{{- with .Peer.BfdMultiplier }}
+  detect-multiplier {{ . }}{{ end }}
+ exit
+{{- end }}
+exit
+!
+{{- if .Evpn.IsEnabled }}
+vrf {{ .VrfName }}
+ vni {{ .Evpn.Vni }}
+exit-vrf
+!
+{{- end }}
+router bgp {{ .ASN }} vrf {{ .VrfName }}
+ bgp router-id {{ .RouterID }}
+ no bgp ebgp-requires-policy
+ no bgp default ipv4-unicast
+{{- if .GracefulRestart.IsEnabled }}
+ bgp graceful-restart
+ bgp graceful-restart restart-time {{ .GracefulRestart.Time }}
+ bgp graceful-restart preserve-fw-state{{ end }}
+{{- if .GracefulRestart.IsLongLived }}
+ bgp long-lived-graceful-restart stale-time {{ .GracefulRestart.LongLivedStaleTime }}{{ end }}
+{{- range $session := .Sessions }}
+ neighbor {{ .Name }} peer-group
+ neighbor {{ .Name }} remote-as {{ .PeerAsn }}
+ neighbor {{ .Name }} bfd profile {{ .Name }}
+{{- with .Peer.Password }}
+ neighbor {{ $session.Name }} password {{ . }}{{ end }}
+{{- with frrTimers .Peer }}
+ neighbor {{ $session.Name }} timers {{ . }}{{ end }}
+{{- if .Unnumbered }}
+ neighbor {{ .Interface }} interface peer-group {{ .Name }}
+{{- else if .Neighbor.IsValid }}
+{{- if .ExtendedNextHop }}
+ neighbor {{ .Name }} capability extended-nexthop{{ end }}
+{{- if .LocalAddress.IsValid }}
+ neighbor {{ .Name }} update-source {{ .LocalAddress }}{{ end }}
+ neighbor {{ .Neighbor }} peer-group {{ .Name }}
+{{- else }}
+ bgp listen range {{ .NeighborRange }} peer-group {{ .Name }}
+{{- end }}
+{{- end }}
+ !
+ address-family ipv4 unicast
+  redistribute kernel
+{{- if not .Evpn.IsEnabled }}{{ range .Sessions }}{{ if .IPv4 }}
+  neighbor {{ .Name }} activate
+  neighbor {{ .Name }} route-map microovn_import_v4 in
+  neighbor {{ .Name }} route-map microovn_export_v4 out{{ end }}{{ end }}{{ end }}
+ exit-address-family
+ !
+ address-family ipv6 unicast
+  redistribute kernel
+{{- if not .Evpn.IsEnabled }}{{ range .Sessions }}{{ if .IPv6 }}
+  neighbor {{ .Name }} activate
+  neighbor {{ .Name }} route-map microovn_import_v6 in
+  neighbor {{ .Name }} route-map microovn_export_v6 out{{ end }}{{ end }}{{ end }}
+ exit-address-family
+{{- if .Evpn.IsEnabled }}
+ !
+ address-family l2vpn evpn
+{{- range .Sessions }}
+  neighbor {{ .Name }} activate{{ end }}
+  advertise-all-vni
+  advertise ipv4 unicast route-map microovn_export_v4
+  advertise ipv6 unicast route-map microovn_export_v6
+{{- with .Evpn.ImportRouteTargets }}
+  route-target import {{ join . " " }}{{ end }}
+{{- with .Evpn.ExportRouteTargets }}
+  route-target export {{ join . " " }}{{ end }}
+ exit-address-family
+{{- end }}
+exit
+!
+`))
+
+// frrNeighbor represents a single BGP neighbor in the JSON output of FRR's "show bgp neighbors" command.
+type frrNeighbor struct {
+	RemoteAs          uint32 `json:"remoteAs"`
+	BgpState          string `json:"bgpState"`
+	PeerGroup         string `json:"peerGroup"`
+	BgpTimerUpString  string `json:"bgpTimerUpString"`
+	LastResetDueTo    string `json:"lastResetDueTo"`
+	AddressFamilyInfo map[string]struct {
+		AcceptedPrefixCounter int `json:"acceptedPrefixCounter"`
+		SentPrefixCounter     int `json:"sentPrefixCounter"`
+	} `json:"addressFamilyInfo"`
+}
+
+// parseFrrNeighbors parses JSON output of the "show bgp vrf <vrf> neighbors json" command and returns
+// status of every BGP neighbor found in it, sorted by the neighbor address (or interface).
+func parseFrrNeighbors(output string) ([]types.BgpProtocolStatus, error) {
+	var rawNeighbors map[string]json.RawMessage
+	err := json.Unmarshal([]byte(output), &rawNeighbors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse BGP neighbors: %w", err)
+	}
+
+	var sessions []types.BgpProtocolStatus
+	for address, rawNeighbor := range rawNeighbors {
+		// Output may contain other keys than neighbors, e.g. "vrfName"
+		var neighbor frrNeighbor
+		if json.Unmarshal(rawNeighbor, &neighbor) != nil || neighbor.BgpState == "" {
+			continue
+		}
+
+		session := types.BgpProtocolStatus{
+			Name:            neighbor.PeerGroup,
+			State:           neighbor.BgpState,
+			Since:           neighbor.BgpTimerUpString,
+			NeighborAddress: address,
+		}
+		if session.Name == "" {
+			session.Name = address
+		}
+		if neighbor.RemoteAs != 0 {
+			session.NeighborAs = strconv.FormatUint(uint64(neighbor.RemoteAs), 10)
+		}
+		if neighbor.BgpState != "Established" {
+			session.Info = neighbor.LastResetDueTo
+		}
+		for _, family := range neighbor.AddressFamilyInfo {
+			session.ImportedRoutes += family.AcceptedPrefixCounter
+			session.ExportedRoutes += family.SentPrefixCounter
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].NeighborAddress < sessions[j].NeighborAddress })
+	return sessions, nil
+}
+
+// frrDaemon - FRRouting protocol suite, using its zebra, bgpd and bfdd daemons
+type frrDaemon struct{}
+
+// Name returns name of the routing daemon
+func (frrDaemon) Name() string {
+	return types.BgpDaemonFrr
+}
+
+// Executable returns path to the executable of bgpd, the BGP daemon of FRR
+func (frrDaemon) Executable() string {
+	return paths.FrrBgpdBinary()
+}
+
+// Start starts and enables FRR service
+func (frrDaemon) Start(ctx context.Context) error {
+	return snap.Start(ctx, FrrService, true)
+}
+
+// Stop stops and disables FRR service
+func (frrDaemon) Stop(ctx context.Context) error {
+	return snap.Stop(ctx, FrrService, true)
+}
+
+// Validate returns error if FRR does not support options in "config"
+func (frrDaemon) Validate(config types.ExtraBgpConfig) error {
+	for iface, peer := range config.Peers {
+		if peer.Auth == types.BgpAuthAo {
+			return fmt.Errorf("FRR does not support TCP-AO authentication, option '%s.%s' must be '%s'",
+				iface, types.BgpPeerOptionAuth, types.BgpAuthMd5)
+		}
+
+		if strings.ContainsAny(peer.Password, " \t") {
+			return fmt.Errorf("FRR does not support whitespace in option '%s.%s'", iface, types.BgpPeerOptionPassword)
+		}
+	}
+
+	return nil
+}
+
+// Configure configures bgpd and bfdd daemons of FRR to run BGP sessions on each interface in
+// extConnections, in the VRF specified in "config". Routes from the VRF are announced to the BGP peers
+// and routes announced by the peers are installed into the same VRF by zebra, as allowed by the route
+// policy. In EVPN mode, the routes are exchanged as EVPN type-5 routes of the configured L3 VNI.
+// Configuration is applied with "frr-reload", unchanged BGP sessions are not interrupted.
+func (frrDaemon) Configure(ctx context.Context, extConnections []types.BgpExternalConnection, config appliedConfig) error {
+	filters, err := routeFilters(config.Policy, config.Asn)
+	if err != nil {
+		return fmt.Errorf("failed to render BGP route policy: %w", err)
+	}
+
+	err = writeDaemonConfig(paths.FrrConfigFile(), func(file *os.File) error {
+		return frrConfTemplate.Execute(file, frrTemplateInput{
+			VrfName:  getVrfName(config.Vrf),
+			RouterID: config.RouterID,
+			Sessions: bgpSessions(extConnections, config.Peers),
+			Filters:  filters,
+			ASN:      config.Asn,
+
+			GracefulRestart: config.GracefulRestart,
+			Evpn:            config.Evpn,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render FRR configuration template: %w", err)
+	}
+
+	out, err := shared.RunCommandContext(ctx, filepath.Join(paths.Wrappers(), "frr-reload"))
+	if err != nil {
+		return fmt.Errorf("failed to apply FRR configuration: %w: %s", err, out)
+	}
+	return nil
+}
+
+// ResetConfig backs up FRR configuration and replaces it with the default one
+func (frrDaemon) ResetConfig(ctx context.Context) error {
+	backupConfig := fmt.Sprintf("%s_%d", paths.FrrConfigFile(), time.Now().Unix())
+	_, err := shared.RunCommandContext(ctx, "cp", paths.FrrConfigFile(), backupConfig)
+	if err != nil {
+		return fmt.Errorf("failed to backup FRR config. Will not proceed with its removal: %v", err)
+	}
+
+	_, err = shared.RunCommandContext(ctx, "cp", paths.FrrDefaultConfig(), paths.FrrConfigDir())
+	if err != nil {
+		return fmt.Errorf("failed to reset FRR config: %v", err)
+	}
+
+	return nil
+}
+
+// Sessions returns state of BGP neighbors of bgpd in VRF "vrfName"
+func (frrDaemon) Sessions(ctx context.Context, vrfName string) ([]types.BgpProtocolStatus, error) {
+	if vrfName == "" {
+		return nil, nil
+	}
+
+	output, err := shared.RunCommandContext(ctx, filepath.Join(paths.Wrappers(), "vtysh"),
+		"-c", fmt.Sprintf("show bgp vrf %s neighbors json", vrfName),
+	)
+	if err != nil {
+		return nil, errors.Join(errors.New("failed to query FRR"), err)
+	}
+
+	return parseFrrNeighbors(output)
+}
+
+// RouteProtocol returns protocol of routes that zebra installs into the kernel on behalf of bgpd
+func (frrDaemon) RouteProtocol() string {
+	return "bgp"
+}
@@ -0,0 +1,191 @@
+package bgp
+
+import (
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestFrrTimers(t *testing.T) {
+	tests := []struct {
+		peer     types.BgpPeerConfig
+		expected string
+	}{
+		{peer: types.BgpPeerConfig{}, expected: ""},
+		{peer: types.BgpPeerConfig{HoldTime: "9", KeepaliveTime: "2"}, expected: "2 9"},
+		{peer: types.BgpPeerConfig{HoldTime: "9"}, expected: "3 9"},
+		{peer: types.BgpPeerConfig{HoldTime: "600"}, expected: "60 600"},
+		{peer: types.BgpPeerConfig{KeepaliveTime: "10"}, expected: "10 180"},
+		{peer: types.BgpPeerConfig{KeepaliveTime: "100"}, expected: "100 300"},
+	}
+
+	for _, tt := range tests {
+		timers := frrTimers(tt.peer)
+		if timers != tt.expected {
+			t.Errorf("expected timers '%s' for %+v, got '%s'", tt.expected, tt.peer, timers)
+		}
+	}
+}
+
+func TestFrrConfTemplate(t *testing.T) {
+	extConnections := []types.BgpExternalConnection{
+		{Iface: "eth1"},
+		{Iface: "eth2", IPv4: netip.MustParsePrefix("192.0.2.1/24")},
+		{Iface: "eth3", IPv6: netip.MustParsePrefix("2001:db8::1/64")},
+	}
+	peers := map[string]types.BgpPeerConfig{
+		"eth1": {HoldTime: "9", BfdMinRx: "300"},
+		"eth3": {Neighbor: "2001:db8::2", PeerAsn: "65003", Password: "secret"},
+	}
+	filters, err := routeFilters(types.BgpPolicyConfig{ImportDeny: "10.0.0.0/8", ExportCommunities: "65000:100"}, "65000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var config strings.Builder
+	err = frrConfTemplate.Execute(&config, frrTemplateInput{
+		VrfName:  "ovnvrf10",
+		RouterID: "192.0.2.10",
+		Sessions: bgpSessions(extConnections, peers),
+		Filters:  filters,
+		ASN:      "65000",
+
+		GracefulRestart: types.BgpGracefulRestartConfig{Enabled: "true", RestartTime: "300"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"ip prefix-list microovn_import_v4_deny seq 5 permit 10.0.0.0/8 le 32\n",
+		"route-map microovn_export_v6 deny 10\n match ipv6 address prefix-list microovn_export_v6_default\n",
+		" set community 65000:100 additive\n",
+		" profile microovn_eth1\n",
+		"  receive-interval 300\n",
+		"router bgp 65000 vrf ovnvrf10\n bgp router-id 192.0.2.10\n",
+		" neighbor microovn_eth1 timers 3 9\n",
+		" neighbor veth1-bgp interface peer-group microovn_eth1\n",
+		" bgp listen range 192.0.2.0/24 peer-group microovn_eth2_ipv4\n",
+		" neighbor microovn_eth3 remote-as 65003\n",
+		" neighbor microovn_eth3 password secret\n",
+		" neighbor microovn_eth3 capability extended-nexthop\n",
+		" neighbor microovn_eth3 update-source 2001:db8::1\n",
+		" neighbor 2001:db8::2 peer-group microovn_eth3\n",
+		"  neighbor microovn_eth3 route-map microovn_import_v6 in\n",
+		" bgp graceful-restart\n bgp graceful-restart restart-time 300\n bgp graceful-restart preserve-fw-state\n",
+	} {
+		if !strings.Contains(config.String(), expected) {
+			t.Errorf("expected FRR configuration to contain %q, got:\n%s", expected, config.String())
+		}
+	}
+
+	// Long-lived graceful restart is not enabled
+	if strings.Contains(config.String(), "long-lived-graceful-restart") {
+		t.Errorf("unexpected long-lived graceful restart:\n%s", config.String())
+	}
+
+	// IPv4-only sessions are not activated for IPv6 routes
+	if strings.Contains(config.String(), "neighbor microovn_eth2_ipv4 route-map microovn_import_v6") {
+		t.Errorf("unexpected IPv6 routes on IPv4-only session:\n%s", config.String())
+	}
+}
+
+func TestFrrConfTemplateEvpn(t *testing.T) {
+	extConnections := []types.BgpExternalConnection{
+		{Iface: "eth1"},
+		{Iface: "eth2", IPv4: netip.MustParsePrefix("192.0.2.1/24")},
+	}
+	filters, err := routeFilters(types.BgpPolicyConfig{}, "65000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var config strings.Builder
+	err = frrConfTemplate.Execute(&config, frrTemplateInput{
+		VrfName:  "ovnvrf10",
+		RouterID: "192.0.2.10",
+		Sessions: bgpSessions(extConnections, nil),
+		Filters:  filters,
+		ASN:      "65000",
+
+		Evpn: types.BgpEvpnConfig{Enabled: "true", Vni: "10100", Vtep: "192.0.2.10", ImportRt: "65000:1,65000:2"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"vrf ovnvrf10\n vni 10100\nexit-vrf\n",
+		" address-family l2vpn evpn\n  neighbor microovn_eth1 activate\n  neighbor microovn_eth2_ipv4 activate\n",
+		"  advertise ipv4 unicast route-map microovn_export_v4\n",
+		"  route-target import 65000:1 65000:2\n",
+	} {
+		if !strings.Contains(config.String(), expected) {
+			t.Errorf("expected FRR configuration to contain %q, got:\n%s", expected, config.String())
+		}
+	}
+
+	// Sessions exchange only EVPN routes, export route targets are derived by FRR
+	for _, unexpected := range []string{"route-map microovn_import_v4 in", "route-target export"} {
+		if strings.Contains(config.String(), unexpected) {
+			t.Errorf("unexpected %q in FRR configuration:\n%s", unexpected, config.String())
+		}
+	}
+}
+
+func TestParseFrrNeighbors(t *testing.T) {
+	output := `{
+  "veth1-bgp":{
+    "remoteAs":65001,
+    "bgpState":"Established",
+    "peerGroup":"microovn_eth1",
+    "bgpTimerUpString":"00:05:12",
+    "addressFamilyInfo":{
+      "ipv4Unicast":{"acceptedPrefixCounter":3,"sentPrefixCounter":2},
+      "ipv6Unicast":{"acceptedPrefixCounter":1,"sentPrefixCounter":2}
+    }
+  },
+  "192.0.2.2":{
+    "remoteAs":65002,
+    "bgpState":"Active",
+    "peerGroup":"microovn_eth2",
+    "lastResetDueTo":"Waiting for peer OPEN"
+  },
+  "vrfName":"ovnvrf10"
+}`
+
+	sessions, err := parseFrrNeighbors(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []types.BgpProtocolStatus{
+		{
+			Name:            "microovn_eth2",
+			State:           "Active",
+			Info:            "Waiting for peer OPEN",
+			NeighborAddress: "192.0.2.2",
+			NeighborAs:      "65002",
+		},
+		{
+			Name:            "microovn_eth1",
+			State:           "Established",
+			Since:           "00:05:12",
+			NeighborAddress: "veth1-bgp",
+			NeighborAs:      "65001",
+			ImportedRoutes:  4,
+			ExportedRoutes:  4,
+		},
+	}
+	if !reflect.DeepEqual(sessions, expected) {
+		t.Errorf("expected sessions %+v, got %+v", expected, sessions)
+	}
+
+	_, err = parseFrrNeighbors("% Unknown command")
+	if err == nil {
+		t.Errorf("expected invalid output to fail")
+	}
+}
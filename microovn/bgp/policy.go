@@ -0,0 +1,120 @@
+package bgp
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// Names of filters that implement route policy of the local member. Routing daemons use them to name
+// their filters or route maps.
+const (
+	importFilterV4 = "microovn_import_v4"
+	importFilterV6 = "microovn_import_v6"
+	exportFilterV4 = "microovn_export_v4"
+	exportFilterV6 = "microovn_export_v6"
+)
+
+// routeFilter - a single filter of routes of one address family, rendered by the routing daemon into
+// its configuration. Routes are checked in the following order:
+//   - all routes are rejected if "RejectAll" is set
+//   - default route is rejected if "RejectDefault" is set
+//   - routes matching the "Deny" list are rejected
+//   - routes that do not match the "Allow" list are rejected, unless the list is empty
+//
+// Remaining routes are accepted, with BGP attributes set by the filter.
+type routeFilter struct {
+	Name          string
+	IPv6          bool
+	DefaultRoute  netip.Prefix
+	RejectDefault bool
+	RejectAll     bool
+	Deny          []netip.Prefix
+	Allow         []netip.Prefix
+	Communities   []types.BgpCommunity
+	LocalPref     string
+	Prepend       []string
+}
+
+// familyPrefixes returns prefixes from "prefixes" that belong to the address family of "defaultRoute".
+func familyPrefixes(prefixes []netip.Prefix, defaultRoute netip.Prefix) []netip.Prefix {
+	var familyPrefixes []netip.Prefix
+	for _, prefix := range prefixes {
+		if prefix.Addr().Is4() == defaultRoute.Addr().Is4() {
+			familyPrefixes = append(familyPrefixes, prefix)
+		}
+	}
+	return familyPrefixes
+}
+
+// newRouteFilter returns filter "name" that applies "allow" and "deny" prefix lists to routes of the
+// address family of "defaultRoute". Each prefix matches itself and all of its more specific prefixes.
+// If the allow list is not empty, but it does not contain any prefix of this address family, all routes
+// are rejected.
+func newRouteFilter(name string, defaultRoute netip.Prefix, allow []netip.Prefix, deny []netip.Prefix) routeFilter {
+	filter := routeFilter{
+		Name:         name,
+		IPv6:         defaultRoute.Addr().Is6(),
+		DefaultRoute: defaultRoute,
+		Deny:         familyPrefixes(deny, defaultRoute),
+		Allow:        familyPrefixes(allow, defaultRoute),
+	}
+	filter.RejectAll = len(allow) != 0 && len(filter.Allow) == 0
+
+	return filter
+}
+
+// routeFilters returns import and export filters for IPv4 and IPv6 routes that implement route
+// "policy". Default route is never announced to BGP peers, prefixes announced to them are tagged with
+// configured communities, local preference and AS path prepending of the local "asn".
+func routeFilters(policy types.BgpPolicyConfig, asn string) ([]routeFilter, error) {
+	var prefixLists [4][]netip.Prefix
+	for i, value := range []string{policy.ImportAllow, policy.ImportDeny, policy.ExportAllow, policy.ExportDeny} {
+		prefixes, err := types.ParsePrefixList(value)
+		if err != nil {
+			return nil, err
+		}
+		prefixLists[i] = prefixes
+	}
+	importAllow, importDeny, exportAllow, exportDeny := prefixLists[0], prefixLists[1], prefixLists[2], prefixLists[3]
+
+	communities, err := types.ParseCommunities(policy.ExportCommunities)
+	if err != nil {
+		return nil, err
+	}
+
+	var prepend []string
+	if policy.ExportPrepend != "" {
+		count, err := strconv.Atoi(policy.ExportPrepend)
+		if err != nil {
+			return nil, fmt.Errorf("invalid AS path prepend count: %s", policy.ExportPrepend)
+		}
+		for range count {
+			prepend = append(prepend, asn)
+		}
+	}
+
+	defaultV4, defaultV6 := netip.MustParsePrefix("0.0.0.0/0"), netip.MustParsePrefix("::/0")
+	filters := []routeFilter{
+		newRouteFilter(importFilterV4, defaultV4, importAllow, importDeny),
+		newRouteFilter(importFilterV6, defaultV6, importAllow, importDeny),
+		newRouteFilter(exportFilterV4, defaultV4, exportAllow, exportDeny),
+		newRouteFilter(exportFilterV6, defaultV6, exportAllow, exportDeny),
+	}
+
+	for i := range filters {
+		if filters[i].Name == importFilterV4 || filters[i].Name == importFilterV6 {
+			filters[i].RejectDefault = !policy.AcceptsDefaultRoute()
+			continue
+		}
+
+		filters[i].RejectDefault = true
+		filters[i].Communities = communities
+		filters[i].LocalPref = policy.ExportLocalPref
+		filters[i].Prepend = prepend
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,82 @@
+package bgp
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestRouteFiltersDefault(t *testing.T) {
+	filters, err := routeFilters(types.BgpPolicyConfig{}, "65000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defaultV4, defaultV6 := netip.MustParsePrefix("0.0.0.0/0"), netip.MustParsePrefix("::/0")
+	expected := []routeFilter{
+		{Name: importFilterV4, DefaultRoute: defaultV4},
+		{Name: importFilterV6, IPv6: true, DefaultRoute: defaultV6},
+		{Name: exportFilterV4, DefaultRoute: defaultV4, RejectDefault: true},
+		{Name: exportFilterV6, IPv6: true, DefaultRoute: defaultV6, RejectDefault: true},
+	}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Errorf("expected filters %+v, got %+v", expected, filters)
+	}
+}
+
+func TestRouteFilters(t *testing.T) {
+	policy := types.BgpPolicyConfig{
+		ImportAllow:        "192.0.2.0/24",
+		ImportDeny:         "192.0.2.128/25,2001:db8::/32",
+		ExportAllow:        "198.51.100.0/24,2001:db8:1::/48",
+		AcceptDefaultRoute: "false",
+		ExportCommunities:  "65000:100,4200000000:1:2",
+		ExportLocalPref:    "200",
+		ExportPrepend:      "2",
+	}
+
+	filters, err := routeFilters(policy, "65000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	export := routeFilter{
+		DefaultRoute:  netip.MustParsePrefix("0.0.0.0/0"),
+		RejectDefault: true,
+		Allow:         []netip.Prefix{netip.MustParsePrefix("198.51.100.0/24")},
+		Communities:   []types.BgpCommunity{{65000, 100}, {4200000000, 1, 2}},
+		LocalPref:     "200",
+		Prepend:       []string{"65000", "65000"},
+	}
+	exportV4, exportV6 := export, export
+	exportV4.Name = exportFilterV4
+	exportV6.Name, exportV6.IPv6 = exportFilterV6, true
+	exportV6.DefaultRoute = netip.MustParsePrefix("::/0")
+	exportV6.Allow = []netip.Prefix{netip.MustParsePrefix("2001:db8:1::/48")}
+
+	expected := []routeFilter{
+		{
+			Name:          importFilterV4,
+			DefaultRoute:  netip.MustParsePrefix("0.0.0.0/0"),
+			RejectDefault: true,
+			Deny:          []netip.Prefix{netip.MustParsePrefix("192.0.2.128/25")},
+			Allow:         []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")},
+		},
+		{
+			// Allow list contains only IPv4 prefixes, no IPv6 route is accepted
+			Name:          importFilterV6,
+			IPv6:          true,
+			DefaultRoute:  netip.MustParsePrefix("::/0"),
+			RejectDefault: true,
+			RejectAll:     true,
+			Deny:          []netip.Prefix{netip.MustParsePrefix("2001:db8::/32")},
+		},
+		exportV4,
+		exportV6,
+	}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Errorf("expected filters %+v, got %+v", expected, filters)
+	}
+}
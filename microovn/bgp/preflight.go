@@ -0,0 +1,299 @@
+package bgp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/external"
+	"github.com/canonical/microovn/microovn/network"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+// Preflight checks whether "bgp" service can be enabled on the local member with configuration "config",
+// without creating any resources. It returns result of each check, checks of the local system are skipped
+// if the configuration itself is not valid.
+func Preflight(ctx context.Context, s state.State, config types.ExtraBgpConfig) []types.BgpCheckResult {
+	configResult := checkConfig(config)
+	if !configResult.Passed {
+		return []types.BgpCheckResult{configResult}
+	}
+
+	// All of them are known to be valid at this point
+	daemon, _ := getRoutingDaemon(config.Daemon)
+	backend, _ := network.GetBackend(config.NetworkBackend)
+	extConnections, _ := config.ParseExternalConnection()
+
+	results := []types.BgpCheckResult{configResult, checkVrfModule()}
+	for _, extConnection := range extConnections {
+		results = append(results, checkInterface(extConnection.Iface))
+	}
+	results = append(results, checkNetworkBackend(backend), checkNetplan(), checkVrfTable(ctx, s, config.Vrf), checkDaemon(daemon))
+	results = append(results, checkBridgeMappings(ctx, s, extConnections)...)
+	if config.Evpn.IsEnabled() {
+		results = append(results, checkVxlanModule(), checkVtep(config.Evpn.Vtep))
+	}
+
+	return results
+}
+
+// checkError returns error that describes all failed checks in "results", or nil if all of them passed.
+func checkError(results []types.BgpCheckResult) error {
+	var failures []string
+	for _, result := range results {
+		if result.Passed {
+			continue
+		}
+
+		name := result.Check
+		if result.Subject != "" {
+			name += " " + result.Subject
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", name, result.Message))
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(failures, "; "))
+}
+
+// checkConfig checks that BGP configuration "config" is valid and supported by the selected routing daemon.
+func checkConfig(config types.ExtraBgpConfig) types.BgpCheckResult {
+	result := types.BgpCheckResult{Check: types.BgpCheckConfig}
+	err := config.Validate()
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	daemon, err := getRoutingDaemon(config.Daemon)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	err = daemon.Validate(config)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Passed = true
+	result.Message = "configuration is valid"
+	return result
+}
+
+// checkVrfModule checks that the VRF kernel module is loaded.
+func checkVrfModule() types.BgpCheckResult {
+	result := types.BgpCheckResult{Check: types.BgpCheckVrfModule}
+	err := checkKernelModule("vrf")
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Passed = true
+	result.Message = "vrf kernel module is loaded"
+	return result
+}
+
+// checkVxlanModule checks that the VXLAN kernel module, required in EVPN mode, is loaded.
+func checkVxlanModule() types.BgpCheckResult {
+	result := types.BgpCheckResult{Check: types.BgpCheckVxlanModule}
+	err := checkKernelModule("vxlan")
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Passed = true
+	result.Message = "vxlan kernel module is loaded"
+	return result
+}
+
+// checkVtep checks that VXLAN tunnel endpoint address "vtep" is assigned to an interface of the local
+// system, so that VXLAN traffic from the fabric can be terminated on it.
+func checkVtep(vtep string) types.BgpCheckResult {
+	result := types.BgpCheckResult{Check: types.BgpCheckVtep, Subject: vtep}
+	addresses, err := net.InterfaceAddrs()
+	if err != nil {
+		result.Message = fmt.Sprintf("failed to list addresses of local interfaces: %v", err)
+		return result
+	}
+
+	for _, address := range addresses {
+		prefix, err := netip.ParsePrefix(address.String())
+		if err == nil && prefix.Addr().String() == vtep {
+			result.Passed = true
+			result.Message = "address is assigned to a local interface"
+			return result
+		}
+	}
+
+	result.Message = "address is not assigned to any local interface"
+	return result
+}
+
+// checkInterface checks that physical interface "iface" exists and that it's not enslaved to another
+// device, like a bridge or a bond.
+func checkInterface(iface string) types.BgpCheckResult {
+	result := types.BgpCheckResult{Check: types.BgpCheckInterface, Subject: iface}
+	ifacePath := filepath.Join("/sys/class/net", iface)
+	if !shared.PathExists(ifacePath) {
+		result.Message = "interface does not exist"
+		return result
+	}
+
+	master, err := os.Readlink(filepath.Join(ifacePath, "master"))
+	if err == nil {
+		result.Message = fmt.Sprintf("interface is already enslaved to '%s'", filepath.Base(master))
+		return result
+	}
+
+	result.Passed = true
+	result.Message = "interface is available"
+	return result
+}
+
+// checkNetworkBackend checks that network "backend" can create interfaces on the local system.
+func checkNetworkBackend(backend network.Backend) types.BgpCheckResult {
+	result := types.BgpCheckResult{Check: types.BgpCheckNetworkBackend, Subject: backend.Name()}
+	err := backend.Available()
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Passed = true
+	result.Message = "network backend is available"
+	return result
+}
+
+// checkNetplan checks that network configuration of BGP redirect and EVPN interfaces, applied by any
+// of the network backends, does not exist yet.
+func checkNetplan() types.BgpCheckResult {
+	result := types.BgpCheckResult{Check: types.BgpCheckNetplan}
+	for _, filename := range []string{vethNetplanFile, evpnNetplanFile} {
+		if network.ConfigExists(filename) {
+			result.Message = fmt.Sprintf("network configuration '%s' already exists, it may be left over from previous BGP setup", filename)
+			return result
+		}
+	}
+
+	result.Passed = true
+	result.Message = "no conflicting network configuration"
+	return result
+}
+
+// checkVrfTable checks that VRF table ID "requested" is not used on the local system, nor allocated to
+// another cluster member. If no table ID is requested, it checks that one can be selected.
+func checkVrfTable(ctx context.Context, s state.State, requested string) types.BgpCheckResult {
+	result := types.BgpCheckResult{Check: types.BgpCheckVrfTable}
+	usedTableIDs, err := getUsedVrfTableIDs(ctx)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	if requested != "" {
+		tableID, _ := strconv.Atoi(requested)
+		if usedTableIDs[tableID] {
+			result.Message = fmt.Sprintf("VRF table ID %s is already used on this system", requested)
+			return result
+		}
+	}
+
+	tableID, err := previewAllocation(ctx, s, allocationVrf, "", requested, vrfTableCandidates(usedTableIDs))
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Passed = true
+	result.Message = fmt.Sprintf("VRF table ID %s is available", tableID)
+	return result
+}
+
+// checkDaemon checks that the executable of routing daemon "daemon" is available.
+func checkDaemon(daemon RoutingDaemon) types.BgpCheckResult {
+	result := types.BgpCheckResult{Check: types.BgpCheckDaemon, Subject: daemon.Name()}
+	_, err := os.Stat(daemon.Executable())
+	if err != nil {
+		result.Message = fmt.Sprintf("routing daemon is not available: %v", err)
+		return result
+	}
+
+	result.Passed = true
+	result.Message = "routing daemon is available"
+	return result
+}
+
+// bridgeMappingConflict returns description of a conflict between OVN bridge mappings "mappings" and
+// the mapping of physical network "physnet" to bridge "bridge", or an empty string if they don't conflict.
+func bridgeMappingConflict(mappings string, physnet string, bridge string) string {
+	for _, mapping := range strings.Split(mappings, ",") {
+		mappedPhysnet, mappedBridge, _ := strings.Cut(mapping, ":")
+		if mappedPhysnet == physnet {
+			return fmt.Sprintf("physical network '%s' is already mapped to bridge '%s'", physnet, mappedBridge)
+		}
+		if mappedBridge == bridge {
+			return fmt.Sprintf("bridge '%s' is already mapped to physical network '%s'", bridge, mappedPhysnet)
+		}
+	}
+	return ""
+}
+
+// checkBridgeMappings checks that external bridges of "extConnections" do not exist in OVS yet and that
+// their physical networks do not conflict with existing "ovn-bridge-mappings".
+func checkBridgeMappings(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) []types.BgpCheckResult {
+	failed := func(err error) []types.BgpCheckResult {
+		return []types.BgpCheckResult{{Check: types.BgpCheckBridgeMappings, Message: err.Error()}}
+	}
+
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return failed(err)
+	}
+	defer ovs.Close()
+
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return failed(fmt.Errorf("failed to lookup ovn-bridge-mappings: %w", err))
+	}
+
+	bridges, err := ovsdbclient.Find[ovsdbclient.Bridge](ctx, ovs, ovsdbclient.TableBridge)
+	if err != nil {
+		return failed(fmt.Errorf("failed to lookup OVS bridges: %w", err))
+	}
+
+	var results []types.BgpCheckResult
+	for _, extConnection := range extConnections {
+		result := types.BgpCheckResult{Check: types.BgpCheckBridgeMappings, Subject: extConnection.Iface}
+		bridgeName := external.BridgeName(extConnection.Iface)
+		conflict := bridgeMappingConflict(openvSwitch.ExternalIDs["ovn-bridge-mappings"], getPhysnetName(s, extConnection.Iface), bridgeName)
+		switch {
+		case conflict != "":
+			result.Message = conflict
+		case slices.ContainsFunc(bridges, func(b ovsdbclient.Bridge) bool { return b.Name == bridgeName }):
+			result.Message = fmt.Sprintf("bridge '%s' already exists", bridgeName)
+		default:
+			result.Passed = true
+			result.Message = "no conflicting bridge mappings"
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
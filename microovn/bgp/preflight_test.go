@@ -0,0 +1,41 @@
+package bgp
+
+import (
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestBridgeMappingConflict(t *testing.T) {
+	for _, tc := range []struct {
+		mappings string
+		conflict bool
+	}{
+		{mappings: "", conflict: false},
+		{mappings: "physnet1:br-ex", conflict: false},
+		{mappings: "physnet1:br-ex,physnet_node1_eth1:br-other", conflict: true},
+		{mappings: "physnet1:br-eth1", conflict: true},
+	} {
+		conflict := bridgeMappingConflict(tc.mappings, "physnet_node1_eth1", "br-eth1")
+		if (conflict != "") != tc.conflict {
+			t.Errorf("mappings '%s': expected conflict %t, got '%s'", tc.mappings, tc.conflict, conflict)
+		}
+	}
+}
+
+func TestCheckError(t *testing.T) {
+	passed := types.BgpCheckResult{Check: types.BgpCheckVrfModule, Passed: true, Message: "vrf kernel module is loaded"}
+	if err := checkError([]types.BgpCheckResult{passed}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := checkError([]types.BgpCheckResult{
+		passed,
+		{Check: types.BgpCheckInterface, Subject: "eth1", Message: "interface does not exist"},
+		{Check: types.BgpCheckNetplan, Message: "netplan configuration already exists"},
+	})
+	expected := "interface eth1: interface does not exist; netplan: netplan configuration already exists"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error '%s', got '%v'", expected, err)
+	}
+}
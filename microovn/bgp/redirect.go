@@ -1,13 +1,11 @@
 package bgp
 
 import (
-	"bufio"
 	"context"
 	"crypto/md5"
 	"database/sql"
 	"errors"
 	"fmt"
-	"math"
 	"os"
 	"slices"
 	"strings"
@@ -15,11 +13,13 @@ import (
 
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/lxd/shared/revert"
 	"github.com/canonical/microcluster/v3/state"
 	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/external"
 	"github.com/canonical/microovn/microovn/netplan"
-	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
-	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/network"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
 )
 
 // BgpManagedTag - a key used in "external_ids" table of various OVN/OVS
@@ -36,15 +36,29 @@ const BgpVrfTable = "microovn-bgp-vrf"
 // keep track of "ovn-bridge-mappings" managed by MicroOVN.
 const BgpBridgeMapping = "microovn-bgp-bridge-mapping"
 
+// vethNetplanFile - name of the netplan file with veth pairs and VRF used for BGP redirecting
+const vethNetplanFile = "90-microovn-bgp-veth.yaml"
+
 // getOvnIntegrationBridge returns current value of "external-ids:ovn-bridge" from
 // the Open_vSwitch table in the OVS database. It returns default value 'br-int' if the
 // key does not exist in external-ids.
 func getOvnIntegrationBridge(ctx context.Context, s state.State) (string, error) {
-	brName, err := vsctlGetIfExists(ctx, s, "Open_vSwitch", ".", "external-ids", "ovn-bridge")
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return "br-int", err
+	}
+	defer ovs.Close()
+
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return "br-int", err
+	}
+
+	brName := openvSwitch.ExternalIDs["ovn-bridge"]
 	if brName == "" {
 		brName = "br-int"
 	}
-	return brName, err
+	return brName, nil
 }
 
 // getPhysnetName returns physical network name that can be used for setting "ovn-bridge-mappings"
@@ -85,7 +99,7 @@ func getLrpName(s state.State, iface string) string {
 // generateLrpMac returns a local unicast MAC address based on an interface name. The returned
 // address will always be same for given interface name.
 // Warning: There is no guarantee that the address won't conflict with other MAC addresses
-// present in the network.
+// present in the network. Use allocateLrpMac to get an address that is unique in the cluster.
 func generateLrpMac(ifaceName string) string {
 	macAddr := "02:"
 	nameHash := md5.Sum([]byte(ifaceName))
@@ -98,7 +112,8 @@ func generateLrpMac(ifaceName string) string {
 // generateBGPRouterID returns a router-id address based on a string. The returned
 // router-id will always be same for given interface name.
 // Warning: There is no guarantee that the address won't conflict with other
-// router-ids present in the AS.
+// router-ids present in the AS. Use allocateRouterID to get a router-id that is unique
+// in the cluster.
 func generateBGPRouterID(s string) string {
 	routerID := ""
 	hash := md5.Sum([]byte(s))
@@ -151,26 +166,6 @@ func generateAsnFromClusterMemberID(ctx context.Context, s state.State, asnRange
 	return fmt.Sprintf("%d", asn), nil
 }
 
-// vsctlGetIfExists runs 'ovs-vsctl' get to retrieve record [column [key]] from the
-// specified table. Returned string has whitespace and quotations trimmed.
-// If the 'ovs-vsctl' command failed due to the "key" not being found in "column",
-// this function returns empty string without error.
-func vsctlGetIfExists(ctx context.Context, s state.State, table string, record string, column string, key string) (string, error) {
-	args := []string{"get", table, record}
-	if column != "" {
-		if key != "" {
-			column = fmt.Sprintf("%s:%s", column, key)
-		}
-		args = append(args, column)
-	}
-	result, err := ovnCmd.VSCtl(ctx, s, args...)
-	// Don't return error if command failed due to
-	if err != nil && !strings.Contains(fmt.Sprintf("%v", err), "ovs-vsctl: no key") {
-		return "", err
-	}
-	return strings.Trim(strings.TrimSpace(result), "\""), nil
-}
-
 // getVrfName Based on the supplied VRF table ID, return name
 // of the VRF that would be created by OVN.
 //
@@ -197,20 +192,10 @@ func getBgpRedirectIfacePeerName(externalIface string) string {
 	return fmt.Sprintf("%s-brg", getBgpVethName(externalIface))
 }
 
-// parseOvnFind parses STDOUT string of OVN/OVS "find" commands with "--bare"
-// formatting. Returned value is a list of strings with each element containing
-// single, non-empty, line of the "find" result.
-func parseOvnFind(stdout string) []string {
-	var foundValues []string
-	scanner := bufio.NewScanner(strings.NewReader(stdout))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-		foundValues = append(foundValues, line)
-	}
-	return foundValues
+// getBgpRedirectLspName returns name of the Logical Switch Port to which BGP+BFD traffic from external
+// interface "externalIface" is redirected.
+func getBgpRedirectLspName(s state.State, externalIface string) string {
+	return fmt.Sprintf("lsp-%s-%s", s.Name(), getBgpRedirectIfaceName(externalIface))
 }
 
 // checkKernelModule checks the presence of the specified module in the running kernel.
@@ -262,128 +247,164 @@ func getUsedVrfTableIDs(ctx context.Context) (map[int]bool, error) {
 	return usedTableIDs, nil
 }
 
-// findAvailableVrfTableID returns the first available VRF Table ID.
-// It finds the currently used Table IDs from system routing tables, and returns the lowest available ID,
-// with a minimum value of 10 if no ID is in use.
-// Returns error if unable to query the database or system or the possible table IDs are all in use.
-func findAvailableVrfTableID(ctx context.Context, s state.State) (string, error) {
-	const minID = 10
+// managedExternalIDs returns "external_ids" map that marks resources as managed by MicroOVN.
+func managedExternalIDs() map[string]string {
+	return map[string]string{BgpManagedTag: "true"}
+}
 
-	usedTableIDs, err := getUsedVrfTableIDs(ctx)
+// createExternalBridges sets up OVS bridge for each external connection defined in "extConnections" argument.
+// Physical interface defined in the external connection will be plugged to this bridge and the bridge will
+// be named "br-<iface>". Additionally, a physical network name will be constructed with getPhysnetName() and
+// will be added to "ovn-bridge-mappings" in the OVS database. All changes are applied in a single transaction.
+func createExternalBridges(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) error {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
 	if err != nil {
-		return "", err
+		return err
 	}
+	defer ovs.Close()
 
-	// Find first available table ID
-	for i := minID; i <= math.MaxUint32; i++ {
-		if !usedTableIDs[i] {
-			return fmt.Sprintf("%d", i), nil
-		}
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return fmt.Errorf("failed to lookup ovn-bridge-mappings: %v", err)
 	}
 
-	return "", fmt.Errorf("failed to find an available VRF table ID")
+	var operations []ovsdbclient.Operation
+	var bridgeMaps []string
+	for _, extConnection := range extConnections {
+		operations = append(operations, external.CreateBridgeOperations(openvSwitch, extConnection.Iface, managedExternalIDs())...)
+		bridgeMaps = append(bridgeMaps, fmt.Sprintf("%s:%s", getPhysnetName(s, extConnection.Iface), external.BridgeName(extConnection.Iface)))
+	}
+	operations = append(operations, external.AddBridgeMapsOperation(openvSwitch, BgpBridgeMapping, bridgeMaps...))
+
+	_, err = ovs.Transact(ctx, operations...)
+	if err != nil {
+		logger.Errorf("failed to create external bridges: %v", err)
+		return err
+	}
+	return nil
 }
 
-// createExternalBridges sets up OVS bridge for each external connection defined in "extConnections" argument.
-// Physical interface defined in the external connection will be plugged to this bridge and the bridge will
-// be named "<iface>-br". Additionally, a physical network name will be constructed with getPhysnetName() and
-// will be added to "ovn-bridge-mappings" in the OVS database.
-func createExternalBridges(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) error {
+// externalNetworkOperations returns OVN Northbound operations that create Logical Switch and Logical Router
+// Port for each external network defined in "extConnections" argument. Along with the operations, it returns
+// named UUIDs of the Logical Router Ports that need to be added to the Logical Router. MAC addresses of the
+// Logical Router Ports are allocated in the cluster database.
+func externalNetworkOperations(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) ([]ovsdbclient.Operation, []any, error) {
+	var operations []ovsdbclient.Operation
+	var lrPorts []any
 	for _, extConnection := range extConnections {
-		bridgeName := fmt.Sprintf("br-%s", extConnection.Iface)
-		physnet := getPhysnetName(s, extConnection.Iface)
-		bridgeMap, err := vsctlGetIfExists(ctx, s, "Open_vSwitch", ".", "external-ids", "ovn-bridge-mappings")
+		lsName := getLsName(s, extConnection.Iface)
+		lspName := fmt.Sprintf("lsp-%s-%s", s.Name(), extConnection.Iface)
+
+		patchName := fmt.Sprintf("patch-%s-%s", s.Name(), extConnection.Iface)
+		physnetName := getPhysnetName(s, extConnection.Iface)
+
+		lrpName := getLrpName(s, extConnection.Iface)
+		lrpMac, err := allocateLrpMac(ctx, s, lrpName)
 		if err != nil {
-			return fmt.Errorf("failed to lookup ovn-bridge-mappings: %v", err)
+			return nil, nil, err
 		}
-		if bridgeMap == "" {
-			bridgeMap = fmt.Sprintf("%s:%s", physnet, bridgeName)
-		} else {
-			bridgeMap = fmt.Sprintf("%s,%s:%s", bridgeMap, physnet, bridgeName)
 
-		}
+		lrpRef := "lrp_" + extConnection.Iface
+		lspRef := "lsp_" + extConnection.Iface
+		patchRef := "patch_" + extConnection.Iface
 
-		_, err = ovnCmd.VSCtl(ctx, s,
-			"--",
-			"add-br", bridgeName,
-			"--",
-			"set", "bridge", bridgeName, fmt.Sprintf("external-ids:%s=true", BgpManagedTag),
-			"--",
-			"set", "Open_vSwitch", ".", fmt.Sprintf("external-ids:ovn-bridge-mappings=\"%s\"", bridgeMap),
-			fmt.Sprintf("external-ids:%s=\"%s\"", BgpBridgeMapping, bridgeMap),
-			"--",
-			"add-port", bridgeName, extConnection.Iface,
+		operations = append(operations,
+			ovsdbclient.AssertAbsent(ovsdbclient.TableLogicalSwitch, ovsdbclient.Equal("name", lsName)),
+			// Create Logical Router Port
+			ovsdbclient.Insert(ovsdbclient.TableLogicalRouterPort, map[string]any{
+				"name":     lrpName,
+				"mac":      lrpMac,
+				"networks": ovsdbclient.StringSet(extConnection.Networks()...),
+			}, lrpRef),
+			// Create Logical Switch Port connected to the Logical Router Port
+			ovsdbclient.Insert(ovsdbclient.TableLogicalSwitchPort, map[string]any{
+				"name":      lspName,
+				"type":      "router",
+				"options":   ovsdbclient.Map(map[string]string{"router-port": lrpName}),
+				"addresses": ovsdbclient.StringSet("router"),
+			}, lspRef),
+			// Connect Logical Switch with the external network
+			ovsdbclient.Insert(ovsdbclient.TableLogicalSwitchPort, map[string]any{
+				"name":      patchName,
+				"type":      "localnet",
+				"options":   ovsdbclient.Map(map[string]string{"network_name": physnetName}),
+				"addresses": ovsdbclient.StringSet("unknown"),
+			}, patchRef),
+			ovsdbclient.Insert(ovsdbclient.TableLogicalSwitch, map[string]any{
+				"name":         lsName,
+				"ports":        ovsdbclient.Set(ovsdbclient.NamedUUID(lspRef), ovsdbclient.NamedUUID(patchRef)),
+				"external_ids": ovsdbclient.Map(managedExternalIDs()),
+			}, ""),
 		)
-		if err != nil {
-			logger.Errorf("failed to create external bridge for interface '%s': %v", extConnection.Iface, err)
-			return err
-		}
+		lrPorts = append(lrPorts, ovsdbclient.NamedUUID(lrpRef))
 	}
-	return nil
+
+	return operations, lrPorts, nil
 }
 
 // createExternalNetworks creates a single Logical Router and connects it to each external network defined
 // in "extConnections" argument. The connection is facilitated via Logical switches, each external network
-// is represented by its own switch.
+// is represented by its own switch. All resources are created in a single transaction.
 func createExternalNetworks(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) error {
-	// Create Logical Router
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer nb.Close()
+
 	lrName := getLrName(s)
-	_, err := ovnCmd.NBCtlCluster(ctx,
-		s,
-		"--",
-		"lr-add", lrName,
-		"--",
-		"set", "Logical_Router", lrName, fmt.Sprintf("options:chassis=%s", s.Name()),
-		"--",
-		"set", "Logical_Router", lrName, fmt.Sprintf("external-ids:%s=true", BgpManagedTag),
-	)
+	operations := []ovsdbclient.Operation{
+		ovsdbclient.AssertAbsent(ovsdbclient.TableLogicalRouter, ovsdbclient.Equal("name", lrName)),
+	}
+
+	networkOperations, lrPorts, err := externalNetworkOperations(ctx, s, extConnections)
 	if err != nil {
-		logger.Errorf("Failed to create OVN Logical Router for external connectivity: %v", err)
 		return err
 	}
-	for _, extConnection := range extConnections {
-		lsName := getLsName(s, extConnection.Iface)
-		lspName := fmt.Sprintf("lsp-%s-%s", s.Name(), extConnection.Iface)
+	operations = append(operations, networkOperations...)
 
-		patchName := fmt.Sprintf("patch-%s-%s", s.Name(), extConnection.Iface)
-		physnetName := getPhysnetName(s, extConnection.Iface)
+	// Create Logical Router
+	operations = append(operations, ovsdbclient.Insert(ovsdbclient.TableLogicalRouter, map[string]any{
+		"name":         lrName,
+		"ports":        ovsdbclient.Set(lrPorts...),
+		"options":      ovsdbclient.Map(map[string]string{"chassis": s.Name()}),
+		"external_ids": ovsdbclient.Map(managedExternalIDs()),
+	}, ""))
+
+	_, err = nb.Transact(ctx, operations...)
+	if err != nil {
+		logger.Errorf("Failed to create OVN external networks: %v", err)
+		return err
+	}
+	return nil
+}
 
-		lrpName := getLrpName(s, extConnection.Iface)
-		lrpMac := generateLrpMac(lrpName)
+// addExternalNetworks connects existing Logical Router, created by createExternalNetworks, to each external
+// network defined in "extConnections" argument. All resources are created in a single transaction.
+func addExternalNetworks(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) error {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer nb.Close()
 
-		_, err = ovnCmd.NBCtlCluster(ctx,
-			s,
-			"--",
-			// Create Logical Router Port
-			"lrp-add", lrName, lrpName, lrpMac,
-			"--",
-			// Create Logical Switch and connect it to the Logical Router Port
-			"ls-add", lsName,
-			"--",
-			"set", "Logical_Switch", lsName, fmt.Sprintf("external-ids:%s=true", BgpManagedTag),
-			"--",
-			"lsp-add", lsName, lspName,
-			"--",
-			"lsp-set-type", lspName, "router",
-			"--",
-			"lsp-set-options", lspName, fmt.Sprintf("router-port=%s", lrpName),
-			"--",
-			"lsp-set-addresses", lspName, "router",
-			"--",
-			// Connect Logical Switch with the external network
-			"lsp-add", lsName, patchName,
-			"--",
-			"lsp-set-addresses", patchName, "unknown",
-			"--",
-			"lsp-set-type", patchName, "localnet",
-			"--",
-			"lsp-set-options", patchName, fmt.Sprintf("network_name=%s", physnetName),
-		)
+	lrRow := []ovsdbclient.Condition{ovsdbclient.Equal("name", getLrName(s))}
+	operations := []ovsdbclient.Operation{
+		ovsdbclient.AssertPresent(ovsdbclient.TableLogicalRouter, lrRow...),
+	}
 
-		if err != nil {
-			logger.Errorf("failed to create external networks for interface '%s': %v", extConnection.Iface, err)
-			return err
-		}
+	networkOperations, lrPorts, err := externalNetworkOperations(ctx, s, extConnections)
+	if err != nil {
+		return err
+	}
+	operations = append(operations, networkOperations...)
+	operations = append(operations, ovsdbclient.Mutate(ovsdbclient.TableLogicalRouter, lrRow,
+		ovsdbclient.SetInsert("ports", lrPorts...),
+	))
+
+	_, err = nb.Transact(ctx, operations...)
+	if err != nil {
+		return fmt.Errorf("failed to add OVN external networks: %v", err)
 	}
 	return nil
 }
@@ -399,40 +420,52 @@ func createVrf(ctx context.Context, s state.State, extConnections []types.BgpExt
 		return fmt.Errorf("failed to create vrf for LR '%s': %v", lrName, err)
 	}
 
-	_, err := ovnCmd.NBCtlCluster(ctx,
-		s,
-		"set", "Logical_Router", lrName,
-		"options:dynamic-routing=true",
-		fmt.Sprintf("options:dynamic-routing-vrf-id=%s", tableID),
-	)
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
 	if err != nil {
-		return fmt.Errorf("failed to create vrf for LR '%s': %v", lrName, err)
+		return err
+	}
+	defer nb.Close()
+
+	lrRow := []ovsdbclient.Condition{ovsdbclient.Equal("name", lrName)}
+	operations := []ovsdbclient.Operation{
+		ovsdbclient.AssertPresent(ovsdbclient.TableLogicalRouter, lrRow...),
+		ovsdbclient.SetMapKeys(ovsdbclient.TableLogicalRouter, lrRow,
+			"options", map[string]string{
+				"dynamic-routing":        "true",
+				"dynamic-routing-vrf-id": tableID,
+			}),
 	}
 
 	for _, extConnection := range extConnections {
-		lrpName := getLrpName(s, extConnection.Iface)
-		_, err = ovnCmd.NBCtlCluster(ctx,
-			s,
-			"lrp-set-options", lrpName,
-			"dynamic-routing-maintain-vrf=true",
-			"dynamic-routing-redistribute=nat,lb",
-			fmt.Sprintf("dynamic-routing-port-name=%s",
-				getBgpRedirectIfaceName(extConnection.Iface)),
+		lrpRow := []ovsdbclient.Condition{ovsdbclient.Equal("name", getLrpName(s, extConnection.Iface))}
+		operations = append(operations,
+			ovsdbclient.AssertPresent(ovsdbclient.TableLogicalRouterPort, lrpRow...),
+			ovsdbclient.SetMapKeys(ovsdbclient.TableLogicalRouterPort, lrpRow,
+				"options", map[string]string{
+					"dynamic-routing-maintain-vrf": "true",
+					"dynamic-routing-redistribute": "nat,lb",
+					"dynamic-routing-port-name":    getBgpRedirectIfaceName(extConnection.Iface),
+				}),
 		)
-		if err != nil {
-			return fmt.Errorf("failed to enable vrf for LRP '%s': %v", lrpName, err)
-		}
+	}
+
+	_, err = nb.Transact(ctx, operations...)
+	if err != nil {
+		return fmt.Errorf("failed to create vrf for LR '%s': %v", lrName, err)
 	}
 	return nil
 }
 
-func generateVeth(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, tableID string) error {
-
+// vethConfig returns network configuration of veth pair for BGP redirect of each external connection
+// defined in "extConnections" argument. One end of each pair is placed into the VRF specified by "tableID",
+// the other one is plugged into the OVN integration bridge. If "preview" is set, MAC addresses of the veths
+// are not allocated in the cluster database, only the values that would be allocated are used.
+func vethConfig(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, tableID string, preview bool) (*netplan.Config, error) {
 	vrfName := getVrfName(tableID)
 
 	brInt, err := getOvnIntegrationBridge(ctx, s)
 	if err != nil {
-		return fmt.Errorf("failed to lookup integration bridge: %v", err)
+		return nil, fmt.Errorf("failed to lookup integration bridge: %v", err)
 	}
 
 	np := netplan.NewConfig()
@@ -443,7 +476,14 @@ func generateVeth(ctx context.Context, s state.State, extConnections []types.Bgp
 	for _, extConnection := range extConnections {
 		bgpInterface := getBgpRedirectIfaceName(extConnection.Iface)
 		brgInterface := getBgpRedirectIfacePeerName(extConnection.Iface)
-		mac := generateLrpMac(getLrpName(s, extConnection.Iface))
+		allocateMac := allocateLrpMac
+		if preview {
+			allocateMac = previewLrpMac
+		}
+		mac, err := allocateMac(ctx, s, getLrpName(s, extConnection.Iface))
+		if err != nil {
+			return nil, err
+		}
 
 		// Add to virtual ethernet
 		// The BGP-side veth needs link-local: [ipv6] so that networkd
@@ -451,6 +491,9 @@ func generateVeth(ctx context.Context, s state.State, extConnections []types.Bgp
 		// required for unnumbered BGP peering.
 		np.AddVeth(bgpInterface, brgInterface, mac, false, []string{"ipv6"})
 		np.AddVeth(brgInterface, bgpInterface, "", false, nil)
+		// With static addresses, the BGP-side veth shares them with the
+		// LRP, so that BGP sessions can be established from these addresses.
+		np.SetAddresses(bgpInterface, extConnection.Networks())
 		brIntInterfaces = append(brIntInterfaces, brgInterface)
 		vrfInterfaces = append(vrfInterfaces, bgpInterface)
 
@@ -472,13 +515,19 @@ func generateVeth(ctx context.Context, s state.State, extConnections []types.Bgp
 	np.AddBridge(brInt, brIntInterfaces)
 	np.Network.OpenvSwitch.ExternalIDs["dynamic-routing-port-mapping"] = drPortMapping.String()
 
-	filename := "90-microovn-bgp-veth.yaml"
-	err = netplan.WriteToNetplan(ctx, filename, *np)
+	return np, nil
+}
+
+// generateVeth creates, with the network "backend", veth pair for BGP redirect of each external connection
+// defined in "extConnections" argument, as described by vethConfig.
+func generateVeth(ctx context.Context, s state.State, backend network.Backend, extConnections []types.BgpExternalConnection, tableID string) error {
+	vrfName := getVrfName(tableID)
+	np, err := vethConfig(ctx, s, extConnections, tableID, false)
 	if err != nil {
 		return err
 	}
 
-	err = netplan.Apply(ctx)
+	err = backend.Apply(ctx, s, vethNetplanFile, *np)
 	if err != nil {
 		return err
 	}
@@ -487,7 +536,7 @@ func generateVeth(ctx context.Context, s state.State, extConnections []types.Bgp
 	// distributions networkd may not fully configure the interfaces:
 	// - The BGP-side veths may remain DOWN
 	// - VRF binding may not be applied
-	for _, iface := range vrfInterfaces {
+	for _, iface := range np.Network.Vrfs[vrfName].Interfaces {
 		// Ensure interface is bound to the VRF
 		_, err = shared.RunCommandContext(ctx, "ip", "link", "set", "dev", iface, "master", vrfName)
 		if err != nil {
@@ -502,195 +551,395 @@ func generateVeth(ctx context.Context, s state.State, extConnections []types.Bgp
 	return nil
 }
 
-// redirectBgp creates a port in OVS, moves it to the VRF specified by "tableID" and configures OVN to redirect
-// BGP+BFD traffic from the associated Logical Router Ports to the newly created ports.
+// redirectBgp associates OVS ports, created by generateVeth in the VRF specified by "tableID", with OVN and
+// configures OVN to redirect BGP+BFD traffic from the associated Logical Router Ports to these ports.
 func redirectBgp(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, tableID string) error {
-	vrfName := getVrfName(tableID)
-
-	err := generateVeth(ctx, s, extConnections, tableID)
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
 	if err != nil {
 		return err
 	}
+	defer nb.Close()
 
+	var nbOperations []ovsdbclient.Operation
 	for _, extConnection := range extConnections {
-		lsName := getLsName(s, extConnection.Iface)
-		lrpName := getLrpName(s, extConnection.Iface)
-		brgIface := getBgpRedirectIfacePeerName(extConnection.Iface)
-		bgpIface := getBgpRedirectIfaceName(extConnection.Iface)
-		bgpLsp := fmt.Sprintf("lsp-%s-%s", s.Name(), bgpIface)
+		lsRow := []ovsdbclient.Condition{ovsdbclient.Equal("name", getLsName(s, extConnection.Iface))}
+		lrpRow := []ovsdbclient.Condition{ovsdbclient.Equal("name", getLrpName(s, extConnection.Iface))}
+		bgpLsp := getBgpRedirectLspName(s, extConnection.Iface)
+		bgpLspRef := "lsp_" + getBgpRedirectIfaceName(extConnection.Iface)
 
 		// Create Logical Switch Port to which the BGP+BFD traffic will be redirected
-		_, err := ovnCmd.NBCtlCluster(ctx,
-			s,
-			"--",
-			"lsp-add", lsName, bgpLsp,
-			"--",
-			"lsp-set-addresses", bgpLsp, "unknown",
-			"--",
-			"add", "Logical_Router_Port", lrpName, "options", fmt.Sprintf("routing-protocol-redirect=%s", bgpLsp),
-			"--",
-			"add", "Logical_Router_Port", lrpName, "options", "routing-protocols=\"BGP,BFD\"",
-			"--",
-			"set", "Logical_Router_Port", lrpName, "ipv6_ra_configs:send_periodic=true",
-			"--",
-			"set", "Logical_Router_Port", lrpName, "ipv6_ra_configs:address_mode=slaac",
-			"--",
-			"set", "Logical_Router_Port", lrpName, "ipv6_ra_configs:max_interval=4",
-			"--",
-			"set", "Logical_Router_Port", lrpName, "ipv6_ra_configs:min_interval=3",
+		nbOperations = append(nbOperations,
+			ovsdbclient.AssertPresent(ovsdbclient.TableLogicalSwitch, lsRow...),
+			ovsdbclient.AssertPresent(ovsdbclient.TableLogicalRouterPort, lrpRow...),
+			ovsdbclient.Insert(ovsdbclient.TableLogicalSwitchPort, map[string]any{
+				"name":      bgpLsp,
+				"addresses": ovsdbclient.StringSet("unknown"),
+			}, bgpLspRef),
+			ovsdbclient.Mutate(ovsdbclient.TableLogicalSwitch, lsRow,
+				ovsdbclient.SetInsert("ports", ovsdbclient.NamedUUID(bgpLspRef)),
+			),
+			ovsdbclient.Mutate(ovsdbclient.TableLogicalRouterPort, lrpRow,
+				ovsdbclient.MapInsert("options", map[string]string{
+					"routing-protocol-redirect": bgpLsp,
+					"routing-protocols":         "BGP,BFD",
+				}),
+			),
+			ovsdbclient.SetMapKeys(ovsdbclient.TableLogicalRouterPort, lrpRow, "ipv6_ra_configs", map[string]string{
+				"send_periodic": "true",
+				"address_mode":  "slaac",
+				"max_interval":  "4",
+				"min_interval":  "3",
+			}),
 		)
-		if err != nil {
-			return fmt.Errorf("failed to create LSP for BGP redirect '%s': %v", bgpLsp, err)
-		}
+	}
+
+	_, err = nb.Transact(ctx, nbOperations...)
+	if err != nil {
+		return fmt.Errorf("failed to create LSPs for BGP redirect: %v", err)
+	}
+
+	return attachRedirectPorts(ctx, s, extConnections, tableID)
+}
+
+// attachRedirectPorts binds OVS ports, created by generateVeth in the VRF specified by "tableID", to Logical
+// Switch Ports to which BGP+BFD traffic of "extConnections" is redirected.
+func attachRedirectPorts(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, tableID string) error {
+	vrfName := getVrfName(tableID)
 
-		// Create OVS port and associate it with the LSP
-		_, err = ovnCmd.VSCtl(ctx, s,
-			"--",
-			"set", "Port", brgIface, fmt.Sprintf("external-ids:%s=true", BgpManagedTag),
-			fmt.Sprintf("external-ids:%s=%s", BgpVrfTable, vrfName),
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
 
-			"--",
-			"set", "Interface", brgIface, "type=system", fmt.Sprintf("external_ids:iface-id=%s", bgpLsp),
-			fmt.Sprintf("external-ids:%s=true", BgpManagedTag),
+	var ovsOperations []ovsdbclient.Operation
+	for _, extConnection := range extConnections {
+		// Associate OVS port, created by netplan, with the LSP
+		portRow := []ovsdbclient.Condition{ovsdbclient.Equal("name", getBgpRedirectIfacePeerName(extConnection.Iface))}
+		ovsOperations = append(ovsOperations,
+			ovsdbclient.AssertPresent(ovsdbclient.TablePort, portRow...),
+			ovsdbclient.AssertPresent(ovsdbclient.TableInterface, portRow...),
+			ovsdbclient.SetMapKeys(ovsdbclient.TablePort, portRow, "external_ids", map[string]string{
+				BgpManagedTag: "true",
+				BgpVrfTable:   vrfName,
+			}),
+			ovsdbclient.Update(ovsdbclient.TableInterface, map[string]any{"type": "system"}, portRow...),
+			ovsdbclient.SetMapKeys(ovsdbclient.TableInterface, portRow, "external_ids", map[string]string{
+				"iface-id":    getBgpRedirectLspName(s, extConnection.Iface),
+				BgpManagedTag: "true",
+			}),
 		)
+	}
+
+	_, err = ovs.Transact(ctx, ovsOperations...)
+	if err != nil {
+		return fmt.Errorf("failed to create ports for BGP redirect: %v", err)
+	}
+	return nil
+}
+
+// rollbackTimeout limits the time that rollback of partially created resources may take. Rollback
+// runs even if the context of the failed operation was cancelled.
+const rollbackTimeout = 60 * time.Second
+
+// rollbackHook returns hook for revert.Reverter that runs "undo" to roll back "what". Rollback runs after
+// another error was already returned, its failure is therefore only logged.
+func rollbackHook(ctx context.Context, what string, undo func(ctx context.Context) error) revert.Hook {
+	return func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+		defer cancel()
+
+		err := undo(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to create port for BGP redirect '%s': %v", brgIface, err)
+			logger.Warnf("Failed to roll back %s: %v", what, err)
 		}
 	}
-	return nil
 }
 
-// teardownAll removes all resources that were created/configured as part of setting up of
-// the BGP redirect. This includes:
-//   - Logical Router
-//   - Logical Switches
-//   - OVS external bridges
-//   - OVS ports
-//   - OVN bridge mappings
-//
-// Other OVN resources remain untouched.
-func teardownAll(ctx context.Context, s state.State) error {
-	var allErrors error
-	// Find and remove Logical Router used for BGP redirect
-	logicalRouter := getLrName(s)
-	_, err := ovnCmd.NBCtlCluster(ctx, s, "lr-del", logicalRouter)
+// setupRedirect creates all resources required to redirect BGP+BFD traffic from external networks,
+// defined in "extConnections" argument, to the VRF specified by "tableID". If any step fails, resources
+// created by the previous steps are removed in reverse order. On success, it returns hook that removes
+// the created resources, so that the caller can roll back the redirect if its later step fails. Interfaces
+// in the local system are created with the network "backend".
+func setupRedirect(ctx context.Context, s state.State, backend network.Backend, extConnections []types.BgpExternalConnection, tableID string) (revert.Hook, error) {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	err := createExternalBridges(ctx, s, extConnections)
 	if err != nil {
-		allErrors = errors.Join(allErrors, fmt.Errorf("failed to delete Logical Router '%s': %v", logicalRouter, err))
+		return nil, err
 	}
+	reverter.Add(rollbackHook(ctx, "external bridges", func(ctx context.Context) error {
+		return removeExternalBridges(ctx, s, extConnections)
+	}))
 
-	err = netplan.Cleanup(ctx, "90-microovn-bgp-veth.yaml")
+	err = createExternalNetworks(ctx, s, extConnections)
+	if err != nil {
+		// MAC addresses are allocated before the transaction that may fail
+		reverter.Add(rollbackHook(ctx, "MAC addresses", func(ctx context.Context) error {
+			return releaseLrpMacs(ctx, s, extConnections)
+		}))
+		return nil, err
+	}
+	reverter.Add(rollbackHook(ctx, "external networks", func(ctx context.Context) error {
+		return errors.Join(teardownNB(ctx, s), releaseLrpMacs(ctx, s, extConnections))
+	}))
+
+	// VRF options are set on the Logical Router and its ports, they are removed along with them
+	err = createVrf(ctx, s, extConnections, tableID)
 	if err != nil {
-		allErrors = errors.Join(allErrors, fmt.Errorf("failed to cleanup netplan: %v", err))
+		return nil, err
 	}
 
-	// Find and remove Logical Switches used to connect to external networks on the local chassis
-	logicalSwitches, err := ovnCmd.NBCtlCluster(ctx, s, "--bare", "--columns", "name",
-		"find", "logical_switch", fmt.Sprintf("external-ids:%s=true", BgpManagedTag),
-	)
+	// Network configuration may be recorded even if it fails to be applied, the rollback is therefore
+	// registered in advance. Only interfaces owned by MicroOVN are removed, ports of the veth pairs are
+	// removed from OVS along with the external bridges.
+	reverter.Add(rollbackHook(ctx, "BGP redirect interfaces", func(ctx context.Context) error {
+		return backend.Cleanup(ctx, vethNetplanFile)
+	}))
+	err = generateVeth(ctx, s, backend, extConnections, tableID)
 	if err != nil {
-		allErrors = errors.Join(allErrors, fmt.Errorf("failed to lookup Logical Switches managed by MicroOVN: %v", err))
-	} else {
-		chassisSwitchNamePrefix := getLsNameChassisMatch(s)
-		for _, logicalSwitch := range parseOvnFind(logicalSwitches) {
-			// Remove only those switches that are related to the local chassis
-			if !strings.HasPrefix(logicalSwitch, chassisSwitchNamePrefix) {
-				continue
-			}
-			_, err = ovnCmd.NBCtlCluster(ctx, s, "ls-del", logicalSwitch)
-			if err != nil {
-				allErrors = errors.Join(allErrors, fmt.Errorf("failed to delete Logical Switch '%s': %v", logicalSwitch, err))
-			}
-		}
+		return nil, err
 	}
 
-	// Find and remove external OVS bridges
-	bridges, err := ovnCmd.VSCtl(ctx, s, "--bare", "--columns", "name",
-		"find", "bridge", fmt.Sprintf("external-ids:%s=true", BgpManagedTag),
-	)
+	// Logical Switch Ports of the redirect are removed along with the Logical Switches
+	err = redirectBgp(ctx, s, extConnections, tableID)
 	if err != nil {
-		allErrors = errors.Join(allErrors, fmt.Errorf("failed to lookup OVS Bridges managed by MicroOVN: %v", err))
-	} else {
-		for _, bridge := range parseOvnFind(bridges) {
-			_, err = ovnCmd.VSCtl(ctx, s, "del-br", bridge)
-			if err != nil {
-				allErrors = errors.Join(allErrors, fmt.Errorf("failed to delete OVS Bridge '%s': %v", bridge, err))
-			}
-		}
+		return nil, err
+	}
+
+	cleanup := reverter.Clone().Fail
+	reverter.Success()
+	return cleanup, nil
+}
+
+// teardownNB removes Logical Router and Logical Switches of the local chassis that were created
+// for the purpose of BGP redirect, in a single transaction.
+func teardownNB(ctx context.Context, s state.State) error {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return err
 	}
+	defer nb.Close()
 
-	// Find and remove OVS ports used for BGP redirect
-	ports, err := ovnCmd.VSCtl(ctx, s, "--bare", "--columns", "name",
-		"find", "port", fmt.Sprintf("external-ids:%s=true", BgpManagedTag),
+	// Find Logical Switches used to connect to external networks on the local chassis
+	logicalSwitches, err := ovsdbclient.Find[ovsdbclient.LogicalSwitch](ctx, nb, ovsdbclient.TableLogicalSwitch,
+		ovsdbclient.Includes("external_ids", ovsdbclient.Map(managedExternalIDs())),
 	)
 	if err != nil {
-		allErrors = errors.Join(allErrors, fmt.Errorf("failed to lookup OVS Ports managed by MicroOVN: %v", err))
-	} else {
-		for _, port := range parseOvnFind(ports) {
-			_, err = ovnCmd.VSCtl(ctx, s, "del-port", port)
-			if err != nil {
-				allErrors = errors.Join(allErrors, fmt.Errorf("failed to delete OVS Port '%s': %v", port, err))
-			}
+		return fmt.Errorf("failed to lookup Logical Switches managed by MicroOVN: %v", err)
+	}
+
+	operations := []ovsdbclient.Operation{
+		ovsdbclient.Delete(ovsdbclient.TableLogicalRouter, ovsdbclient.Equal("name", getLrName(s))),
+	}
+
+	chassisSwitchNamePrefix := getLsNameChassisMatch(s)
+	for _, logicalSwitch := range logicalSwitches {
+		// Remove only those switches that are related to the local chassis
+		if !strings.HasPrefix(logicalSwitch.Name, chassisSwitchNamePrefix) {
+			continue
 		}
+		operations = append(operations, ovsdbclient.Delete(ovsdbclient.TableLogicalSwitch, ovsdbclient.HasUUID(logicalSwitch.UUID)))
+	}
+
+	_, err = nb.Transact(ctx, operations...)
+	if err != nil {
+		return fmt.Errorf("failed to delete Logical Router and Logical Switches managed by MicroOVN: %v", err)
+	}
+	return nil
+}
+
+// teardownOVS removes external bridges and OVS ports that were created for the purpose of BGP
+// redirect, along with OVN bridge mappings managed by MicroOVN, in a single transaction.
+func teardownOVS(ctx context.Context, s state.State) error {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	managed := ovsdbclient.Includes("external_ids", ovsdbclient.Map(managedExternalIDs()))
+
+	bridges, err := ovsdbclient.Find[ovsdbclient.Bridge](ctx, ovs, ovsdbclient.TableBridge, managed)
+	if err != nil {
+		return fmt.Errorf("failed to lookup OVS Bridges managed by MicroOVN: %v", err)
 	}
 
-	// Cleanup ovn-bridge mappings for external networks
-	ovnBridgeMapping, err := vsctlGetIfExists(ctx, s, "Open_vSwitch", ".", "external-ids", "ovn-bridge-mappings")
+	ports, err := ovsdbclient.Find[ovsdbclient.Port](ctx, ovs, ovsdbclient.TablePort, managed)
 	if err != nil {
-		allErrors = errors.Join(allErrors, fmt.Errorf("failed to lookup Open_vSwitch ovn-bridge-mappings: %v", err))
-		return allErrors
+		return fmt.Errorf("failed to lookup OVS Ports managed by MicroOVN: %v", err)
 	}
 
-	microOvnBridgeMapping, err := vsctlGetIfExists(ctx, s, "Open_vSwitch", ".", "external-ids", BgpBridgeMapping)
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
 	if err != nil {
-		allErrors = errors.Join(allErrors, fmt.Errorf("failed to lookup OVN bridge mapping managed by MicroOVN: %v", err))
-	} else if len(ovnBridgeMapping) != 0 && len(microOvnBridgeMapping) != 0 {
+		return fmt.Errorf("failed to lookup Open_vSwitch ovn-bridge-mappings: %v", err)
+	}
+	openvSwitchRow := []ovsdbclient.Condition{ovsdbclient.HasUUID(openvSwitch.UUID)}
+
+	var operations []ovsdbclient.Operation
+
+	// Bridges (and ports) that are no longer referenced are removed by the database
+	var bridgeRefs []any
+	for _, bridge := range bridges {
+		bridgeRefs = append(bridgeRefs, ovsdbclient.UUID(bridge.UUID))
+	}
+	if len(bridgeRefs) != 0 {
+		operations = append(operations, ovsdbclient.Mutate(ovsdbclient.TableOpenvSwitch, openvSwitchRow,
+			ovsdbclient.SetDelete("bridges", bridgeRefs...),
+		))
+	}
+
+	for _, port := range ports {
+		portRef := ovsdbclient.UUID(port.UUID)
+		operations = append(operations, ovsdbclient.Mutate(ovsdbclient.TableBridge,
+			[]ovsdbclient.Condition{ovsdbclient.Includes("ports", portRef)},
+			ovsdbclient.SetDelete("ports", portRef),
+		))
+	}
+
+	// Cleanup ovn-bridge mappings for external networks and remove microovn-bgp-bridge-mapping entirely
+	bridgeMappingMutations := []ovsdbclient.Mutation{ovsdbclient.MapDelete("external_ids", BgpBridgeMapping)}
+	ovnBridgeMapping := openvSwitch.ExternalIDs["ovn-bridge-mappings"]
+	microOvnBridgeMapping := openvSwitch.ExternalIDs[BgpBridgeMapping]
+	if len(ovnBridgeMapping) != 0 && len(microOvnBridgeMapping) != 0 {
 		// Proceed with updating ovn-bridge-mapping only if it's present (along with 'microovn-bgp-bridge-mapping')
 		microOvnBridgeMaps := strings.Split(microOvnBridgeMapping, ",")
-		ovnBridgeMaps := strings.Split(ovnBridgeMapping, ",")
-		var newBridgeMapping string
-
-		// Remove ovn-bridge-mappings entries that were added by MicroOVN
-		for _, bridgeMap := range ovnBridgeMaps {
-			if !slices.Contains(microOvnBridgeMaps, bridgeMap) {
-				newBridgeMapping = fmt.Sprintf("%s,%s", newBridgeMapping, bridgeMap)
+		protectedBridgeMaps := external.TrackedElsewhere(openvSwitch.ExternalIDs, BgpBridgeMapping)
+		var newBridgeMaps []string
+
+		// Remove ovn-bridge-mappings entries that were added by MicroOVN for BGP, unless they are also
+		// tracked by other MicroOVN features (e.g. bridge mappings stored in the cluster database)
+		for _, bridgeMap := range strings.Split(ovnBridgeMapping, ",") {
+			if !slices.Contains(microOvnBridgeMaps, bridgeMap) || slices.Contains(protectedBridgeMaps, bridgeMap) {
+				newBridgeMaps = append(newBridgeMaps, bridgeMap)
 			}
 		}
-		newBridgeMapping = strings.Trim(newBridgeMapping, ",")
-
-		if newBridgeMapping == "" {
-			_, err = ovnCmd.VSCtl(ctx, s, "remove", "Open_vSwitch", ".", "external-ids", "ovn-bridge-mappings")
-		} else {
-			_, err = ovnCmd.VSCtl(ctx, s,
-				"set", "Open_vSwitch", ".",
-				fmt.Sprintf("external-ids:ovn-bridge-mappings=%s", newBridgeMapping),
-			)
-		}
-		if err != nil {
-			allErrors = errors.Join(allErrors, fmt.Errorf(
-				"failed to remove MicroOVN managed bridge mappings from ovn-bridge-mappings: %v", err),
-			)
+
+		bridgeMappingMutations = append(bridgeMappingMutations, ovsdbclient.MapDelete("external_ids", "ovn-bridge-mappings"))
+		if len(newBridgeMaps) != 0 {
+			bridgeMappingMutations = append(bridgeMappingMutations, ovsdbclient.MapInsert("external_ids", map[string]string{
+				"ovn-bridge-mappings": strings.Join(newBridgeMaps, ","),
+			}))
 		}
 	}
+	operations = append(operations, ovsdbclient.Mutate(ovsdbclient.TableOpenvSwitch, openvSwitchRow, bridgeMappingMutations...))
+
+	_, err = ovs.Transact(ctx, operations...)
+	if err != nil {
+		return fmt.Errorf("failed to remove OVS resources managed by MicroOVN: %v", err)
+	}
+	return nil
+}
+
+// teardownRedirect removes OVN, OVS and network resources that were created by setupRedirect, regardless
+// of the network backend that created them.
+func teardownRedirect(ctx context.Context, s state.State) error {
+	var allErrors error
+
+	err := teardownNB(ctx, s)
+	if err != nil {
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	err = network.Cleanup(ctx, vethNetplanFile)
+	if err != nil {
+		allErrors = errors.Join(allErrors, fmt.Errorf("failed to cleanup network configuration: %v", err))
+	}
 
-	// Remove microovn-bgp-bridge-mapping entirely
-	_, err = ovnCmd.VSCtl(ctx, s, "remove", "Open_vSwitch", ".", "external-ids", BgpBridgeMapping)
+	err = teardownOVS(ctx, s)
 	if err != nil {
-		allErrors = errors.Join(allErrors, fmt.Errorf("failed to remove %s: %v", BgpBridgeMapping, err))
+		allErrors = errors.Join(allErrors, err)
 	}
 
-	// Backup and reset Bird's config
-	backupConfig := fmt.Sprintf("%s_%d", paths.BirdConfigFile(), time.Now().Unix())
-	_, err = shared.RunCommandContext(ctx, "cp", paths.BirdConfigFile(), backupConfig)
+	return allErrors
+}
+
+// teardownAll removes all resources that were created/configured as part of setting up of
+// the BGP redirect. This includes:
+//   - Logical Router
+//   - Logical Switches
+//   - OVS external bridges
+//   - OVS ports
+//   - OVN bridge mappings
+//   - VXLAN device and bridge of EVPN mode
+//
+// User Logical Routers advertised by this member are withdrawn and configuration of the routing
+// "daemon" is reset to its default. Other OVN resources remain untouched.
+func teardownAll(ctx context.Context, s state.State, daemon RoutingDaemon) error {
+	allErrors := teardownRedirect(ctx, s)
+
+	err := teardownEvpn(ctx)
 	if err != nil {
-		allErrors = errors.Join(allErrors, fmt.Errorf(
-			"failed to backup Bird config. Will not proceed with its removal: %v", err),
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	err = withdrawMemberRouters(ctx, s)
+	if err != nil {
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	err = daemon.ResetConfig(ctx)
+	if err != nil {
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	return allErrors
+}
+
+// checkRedirect checks whether all resources that redirect BGP+BFD traffic from external networks, defined
+// in "extConnections" argument, are present. It returns two values, the first one is "true" if the Logical
+// Router and Logical Switches in OVN are complete, the second one is "true" if the OVS ports and interfaces
+// in the local system are complete. Resources may be missing for example after the member was rebuilt
+// or after OVN central databases were recovered.
+func checkRedirect(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) (bool, bool, error) {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return false, false, err
+	}
+	defer nb.Close()
+
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return false, false, err
+	}
+	defer ovs.Close()
+
+	routers, err := ovsdbclient.Find[ovsdbclient.LogicalRouter](ctx, nb, ovsdbclient.TableLogicalRouter,
+		ovsdbclient.Equal("name", getLrName(s)),
+	)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to lookup Logical Router: %v", err)
+	}
+	nbComplete := len(routers) == 1 && len(routers[0].Ports) == len(extConnections)
+	hostComplete := true
+
+	for _, extConnection := range extConnections {
+		switches, err := ovsdbclient.Find[ovsdbclient.LogicalSwitch](ctx, nb, ovsdbclient.TableLogicalSwitch,
+			ovsdbclient.Equal("name", getLsName(s, extConnection.Iface)),
+		)
+		if err != nil {
+			return false, false, fmt.Errorf("failed to lookup Logical Switch: %v", err)
+		}
+		if len(switches) != 1 {
+			nbComplete = false
+		}
+
+		ports, err := ovsdbclient.Find[ovsdbclient.Port](ctx, ovs, ovsdbclient.TablePort,
+			ovsdbclient.Equal("name", getBgpRedirectIfacePeerName(extConnection.Iface)),
+			ovsdbclient.Includes("external_ids", ovsdbclient.Map(managedExternalIDs())),
 		)
-	} else {
-		_, err = shared.RunCommandContext(ctx, "cp", paths.BirdDefaultConfig(), paths.BirdConfigDir())
 		if err != nil {
-			allErrors = errors.Join(allErrors, fmt.Errorf("failed to reset Bird config: %v", err))
+			return false, false, fmt.Errorf("failed to lookup OVS Port: %v", err)
+		}
+		if len(ports) != 1 {
+			hostComplete = false
+		}
+
+		if !shared.PathExists(fmt.Sprintf("/sys/class/net/%s", getBgpRedirectIfaceName(extConnection.Iface))) {
+			hostComplete = false
 		}
 	}
 
-	return allErrors
+	return nbComplete, hostComplete, nil
 }
@@ -0,0 +1,223 @@
+package bgp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+// BgpAdvertisedBy - a key used in "external_ids" of user Logical Routers that were opted into route
+// advertisement. Its value is the name of the cluster member whose BGP speaker advertises the routes.
+const BgpAdvertisedBy = "microovn-bgp-advertised-by"
+
+// dynamicRoutingOptions lists Logical Router options that MicroOVN sets on user Logical Routers to
+// advertise their routes.
+var dynamicRoutingOptions = []string{"dynamic-routing", "dynamic-routing-redistribute", "dynamic-routing-vrf-id"}
+
+// routerToBgpRouter converts Logical Router "router" advertised by MicroOVN to its API representation.
+func routerToBgpRouter(router ovsdbclient.LogicalRouter) types.BgpRouter {
+	var redistribute []string
+	if value := router.Options["dynamic-routing-redistribute"]; value != "" {
+		redistribute = strings.Split(value, ",")
+	}
+
+	return types.BgpRouter{
+		Name:         router.Name,
+		Member:       router.ExternalIDs[BgpAdvertisedBy],
+		Redistribute: redistribute,
+	}
+}
+
+// AdvertisedRouters returns all user Logical Routers whose routes are advertised through BGP speaker
+// of any cluster member.
+func AdvertisedRouters(ctx context.Context, s state.State) (types.BgpRouters, error) {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	defer nb.Close()
+
+	routers, err := ovsdbclient.Find[ovsdbclient.LogicalRouter](ctx, nb, ovsdbclient.TableLogicalRouter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logical routers: %w", err)
+	}
+
+	bgpRouters := types.BgpRouters{}
+	for _, router := range routers {
+		if _, ok := router.ExternalIDs[BgpAdvertisedBy]; ok {
+			bgpRouters = append(bgpRouters, routerToBgpRouter(router))
+		}
+	}
+
+	slices.SortFunc(bgpRouters, func(a, b types.BgpRouter) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return bgpRouters, nil
+}
+
+// AdvertiseRouter opts user Logical Router "name" into route advertisement through BGP speaker of
+// the local member. OVN redistributes routes of types listed in "redistribute" into the VRF table used
+// by the BGP integration on this member, from where the routing daemon announces them to BGP peers.
+// Routes are redistributed on chassis where the router has a gateway port bound.
+//
+// Routers that already use dynamic routing configured outside MicroOVN, or that are advertised by
+// a different member, are rejected. Advertising a router again updates its set of route types.
+func AdvertiseRouter(ctx context.Context, s state.State, name string, redistribute []string) (types.BgpRouter, error) {
+	if len(redistribute) == 0 {
+		redistribute = types.BgpDefaultRedistribute
+	}
+	err := types.ValidateRedistribute(redistribute)
+	if err != nil {
+		return types.BgpRouter{}, err
+	}
+
+	config, err := loadAppliedConfig(ctx, s)
+	if err != nil {
+		return types.BgpRouter{}, err
+	}
+	if config == nil {
+		return types.BgpRouter{}, errors.New("BGP configuration of this member is not known, enable 'bgp' service with extra config first")
+	}
+
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return types.BgpRouter{}, err
+	}
+	defer nb.Close()
+
+	routers, err := ovsdbclient.Find[ovsdbclient.LogicalRouter](ctx, nb, ovsdbclient.TableLogicalRouter,
+		ovsdbclient.Equal("name", name),
+	)
+	if err != nil {
+		return types.BgpRouter{}, fmt.Errorf("failed to look up logical router '%s': %w", name, err)
+	}
+	if len(routers) != 1 {
+		return types.BgpRouter{}, fmt.Errorf("logical router '%s' does not exist", name)
+	}
+	router := routers[0]
+
+	if _, ok := router.ExternalIDs[BgpManagedTag]; ok {
+		return types.BgpRouter{}, fmt.Errorf("logical router '%s' is managed by MicroOVN and it can not be advertised", name)
+	}
+
+	owner, advertised := router.ExternalIDs[BgpAdvertisedBy]
+	if advertised && owner != s.Name() {
+		return types.BgpRouter{}, fmt.Errorf("routes of logical router '%s' are already advertised by member '%s'", name, owner)
+	}
+	if !advertised && router.Options["dynamic-routing"] == "true" {
+		return types.BgpRouter{}, fmt.Errorf("logical router '%s' already uses dynamic routing configured outside MicroOVN", name)
+	}
+
+	// Guard against concurrent changes of the router between the lookup above and this transaction
+	guard := ovsdbclient.AssertPresent(ovsdbclient.TableLogicalRouter,
+		ovsdbclient.HasUUID(router.UUID),
+		ovsdbclient.Includes("external_ids", ovsdbclient.Map(map[string]string{BgpAdvertisedBy: s.Name()})),
+	)
+	if !advertised {
+		guard = ovsdbclient.AssertAbsent(ovsdbclient.TableLogicalRouter,
+			ovsdbclient.HasUUID(router.UUID),
+			ovsdbclient.Includes("options", ovsdbclient.Map(map[string]string{"dynamic-routing": "true"})),
+		)
+	}
+
+	lrRow := []ovsdbclient.Condition{ovsdbclient.HasUUID(router.UUID)}
+	_, err = nb.Transact(ctx,
+		guard,
+		ovsdbclient.SetMapKeys(ovsdbclient.TableLogicalRouter, lrRow, "options", map[string]string{
+			"dynamic-routing":              "true",
+			"dynamic-routing-redistribute": strings.Join(redistribute, ","),
+			"dynamic-routing-vrf-id":       config.Vrf,
+		}),
+		ovsdbclient.SetMapKeys(ovsdbclient.TableLogicalRouter, lrRow, "external_ids", map[string]string{
+			BgpAdvertisedBy: s.Name(),
+		}),
+	)
+	if err != nil {
+		return types.BgpRouter{}, fmt.Errorf("failed to advertise routes of logical router '%s': %w", name, err)
+	}
+
+	return types.BgpRouter{Name: name, Member: s.Name(), Redistribute: redistribute}, nil
+}
+
+// WithdrawRouter stops advertising routes of user Logical Router "name" through BGP. Dynamic routing
+// options set by MicroOVN are removed from the router, other options remain untouched.
+func WithdrawRouter(ctx context.Context, s state.State, name string) error {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer nb.Close()
+
+	routers, err := ovsdbclient.Find[ovsdbclient.LogicalRouter](ctx, nb, ovsdbclient.TableLogicalRouter,
+		ovsdbclient.Equal("name", name),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to look up logical router '%s': %w", name, err)
+	}
+	if len(routers) != 1 {
+		return fmt.Errorf("logical router '%s' does not exist", name)
+	}
+	if _, ok := routers[0].ExternalIDs[BgpAdvertisedBy]; !ok {
+		return fmt.Errorf("routes of logical router '%s' are not advertised by MicroOVN", name)
+	}
+
+	_, err = nb.Transact(ctx, withdrawRouterOperation(ovsdbclient.HasUUID(routers[0].UUID)))
+	if err != nil {
+		return fmt.Errorf("failed to withdraw routes of logical router '%s': %w", name, err)
+	}
+	return nil
+}
+
+// withdrawRouterOperation returns operation that removes dynamic routing options set by MicroOVN from
+// Logical Routers matching "where".
+func withdrawRouterOperation(where ...ovsdbclient.Condition) ovsdbclient.Operation {
+	return ovsdbclient.Mutate(ovsdbclient.TableLogicalRouter, where,
+		ovsdbclient.MapDelete("options", dynamicRoutingOptions...),
+		ovsdbclient.MapDelete("external_ids", BgpAdvertisedBy),
+	)
+}
+
+// memberRoutersCondition returns condition that matches user Logical Routers advertised by the local member.
+func memberRoutersCondition(s state.State) ovsdbclient.Condition {
+	return ovsdbclient.Includes("external_ids", ovsdbclient.Map(map[string]string{BgpAdvertisedBy: s.Name()}))
+}
+
+// withdrawMemberRouters stops advertising routes of all user Logical Routers advertised by the local member.
+func withdrawMemberRouters(ctx context.Context, s state.State) error {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer nb.Close()
+
+	_, err = nb.Transact(ctx, withdrawRouterOperation(memberRoutersCondition(s)))
+	if err != nil {
+		return fmt.Errorf("failed to withdraw routes of advertised logical routers: %w", err)
+	}
+	return nil
+}
+
+// updateMemberRouters points user Logical Routers advertised by the local member to VRF table "tableID".
+func updateMemberRouters(ctx context.Context, s state.State, tableID string) error {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer nb.Close()
+
+	_, err = nb.Transact(ctx, ovsdbclient.SetMapKeys(ovsdbclient.TableLogicalRouter,
+		[]ovsdbclient.Condition{memberRoutersCondition(s)},
+		"options", map[string]string{"dynamic-routing-vrf-id": tableID},
+	))
+	if err != nil {
+		return fmt.Errorf("failed to update VRF of advertised logical routers: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package bgp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// encryptedPrefix marks values of the "bgp_config" table that are encrypted with encryptSecret.
+// Values without it were stored in plain text by earlier versions.
+const encryptedPrefix = "encrypted:"
+
+// secretKey returns AES-256 key used to encrypt secrets, like BGP session passwords, in the cluster
+// database. It's derived from the private key of the cluster certificate, that is shared by all
+// cluster members.
+func secretKey(s state.State) ([]byte, error) {
+	clusterCert := s.ClusterCert()
+	if clusterCert == nil || len(clusterCert.PrivateKey()) == 0 {
+		return nil, errors.New("cluster key is not available")
+	}
+
+	key := sha256.Sum256(clusterCert.PrivateKey())
+	return key[:], nil
+}
+
+// encryptSecret returns "secret" encrypted with AES-GCM using "key", in a form suitable for the
+// "bgp_config" table.
+func encryptSecret(key []byte, secret string) (string, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecret returns secret from "value" encrypted by encryptSecret using "key". Values without
+// encryptedPrefix are returned unchanged.
+func decryptSecret(key []byte, value string) (string, error) {
+	encoded, encrypted := strings.CutPrefix(value, encryptedPrefix)
+	if !encrypted {
+		return value, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("failed to decrypt secret: value is too short")
+	}
+
+	secret, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return string(secret), nil
+}
+
+// isSecretKey returns "true" if "key" of the "bgp_config" table holds a secret.
+func isSecretKey(key string) bool {
+	return strings.HasSuffix(key, "."+types.BgpPeerOptionPassword)
+}
@@ -4,133 +4,62 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"text/template"
 
-	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/revert"
 	"github.com/canonical/microcluster/v3/state"
 	"github.com/canonical/microovn/microovn/api/types"
-	"github.com/canonical/microovn/microovn/ovn/paths"
-	"github.com/canonical/microovn/microovn/snap"
+	"github.com/canonical/microovn/microovn/network"
 	"github.com/zitadel/logging"
 )
 
-// BirdService - Name of the Bird routing daemon service managed by MicroOVN
-const BirdService = "bird"
-
-// birdTemplateInput - input data for the birdConfTemplate
-type birdTemplateInput struct {
-	VrfTableID     string
-	VrfName        string
-	RouterID       string
-	ExtConnections []types.BgpExternalConnection
-	ASN            string
-}
-
-// birdConfTemplate - a template of a Bird configuration file that enables BGP daemon in dynamic
-// mode on specified interfaces.
-var birdConfTemplate = template.Must(
-	template.New("bird.conf").
-		Funcs(template.FuncMap{"ifaceName": getBgpRedirectIfaceName}).
-		Parse(`
-log syslog all;
-protocol device {};
-protocol direct {
-	disabled;	# Disable learning directly connected routes
-	ipv4;
-	ipv6;
-}
-
-protocol kernel kernel4 {
-	ipv4 {
-		export all;
-	};
-	learn;
-	kernel table {{ .VrfTableID }};
-	merge paths yes;
-}
-
-protocol kernel kernel6 {
-	ipv6 {
-		export all;
-	};
-	learn;
-	kernel table {{ .VrfTableID }};
-	merge paths yes;
-}
-
-protocol static {
-	ipv4;
-}
-
-protocol bfd {
-	# The BIRD BFD code is currently not fully VRF aware, as long as we
-	# only have interfaces in VRFs, using strict bind allows it to work.
-	#
-	# In the event we some time in the future want to speak on both VRF
-	# and non-VRF interfaces, we can instantiate multiple BFD instances.
-	#
-	# Ref: https://bird.network.cz/?get_doc&v=30&f=bird-6.html#ss6.3
-	strict bind yes;
-}
-
-filter no_default_v4 {
-	if net = 0.0.0.0/0 then reject;
-	accept;
-}
-
-filter no_default_v6 {
-	if net = ::/0 then reject;
-	accept;
-}
-{{ range .ExtConnections }}
-protocol bgp microovn_{{ .Iface }} {
-	router id {{ $.RouterID }};
-	interface "{{ ifaceName .Iface }}";
-	vrf "{{ $.VrfName }}";
-	local as {{ $.ASN }};
-	neighbor range fe80::/10 external;
-	dynamic name "dyn_microovn_{{ .Iface }}_";
-	ipv4 {
-		next hop self ebgp;
-		extended next hop on;
-		require extended next hop on;
-		import all;
-		export filter no_default_v4;
-	};
-	ipv6 {
-		import all;
-		export filter no_default_v6;
-	};
-	bfd {
-		# We only want to use BFD for liveness and failure detection if
-		# our peer has it configured.
-		passive yes;
-	};
-}
-{{ end }}
-`))
-
 // EnableService starts BGP service managed by MicroOVN. If external connections are specified in the
 // "extraConfig" parameter, it also sets up additional OVS ports (one for each external connection) and
-// redirects BGP+BFD traffic from the external networks to them.
+// redirects BGP+BFD traffic from the external networks to them. Enabling is all-or-nothing, if any step
+// fails, changes made by the previous steps are rolled back.
 func EnableService(ctx context.Context, s state.State, extraConfig *types.ExtraBgpConfig) error {
+	var daemonName string
 	if extraConfig != nil {
 		err := extraConfig.Validate()
 		if err != nil {
 			return fmt.Errorf("failed to validate BGP config. Services won't be started: %v", err)
 		}
+		daemonName = extraConfig.Daemon
 	}
 
-	err := snap.Start(ctx, BirdService, true)
+	daemon, err := getRoutingDaemon(daemonName)
 	if err != nil {
-		logging.Errorf("Failed to start %s service: %s", BirdService, err)
-		err = errors.New("failed to start BGP service")
-		return errors.Join(err, DisableService(ctx, s))
+		return err
+	}
+
+	if extraConfig != nil {
+		err = daemon.Validate(*extraConfig)
+		if err != nil {
+			return fmt.Errorf("failed to validate BGP config. Services won't be started: %v", err)
+		}
+
+		// The local system is checked before any resources are created, so that they don't need
+		// to be rolled back
+		err = checkError(Preflight(ctx, s, *extraConfig))
+		if err != nil {
+			return fmt.Errorf("BGP preflight checks failed. Services won't be started: %v", err)
+		}
+	}
+
+	// Every step registers rollback of its changes, so that failed enable does not leave any
+	// resources behind. Steps are rolled back in reverse order.
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Routing daemon may be partially started even if it fails to start
+	reverter.Add(rollbackHook(ctx, "routing daemon start", daemon.Stop))
+	err = daemon.Start(ctx)
+	if err != nil {
+		logging.Errorf("Failed to start %s routing daemon: %s", daemon.Name(), err)
+		return errors.New("failed to start BGP service")
 	}
 
 	if extraConfig == nil {
+		reverter.Success()
 		return nil
 	}
 
@@ -139,79 +68,117 @@ func EnableService(ctx context.Context, s state.State, extraConfig *types.ExtraB
 		logging.Errorf("Failed to parse external connections: %v", err)
 	}
 
-	// Auto-select VRF table ID if not provided by the user
-	vrfTableID := extraConfig.Vrf
-	if vrfTableID == "" {
-		vrfTableID, err = findAvailableVrfTableID(ctx, s)
-		if err != nil {
-			return errors.Join(fmt.Errorf("failed to auto-select VRF table ID: %v", err), DisableService(ctx, s))
-		}
-		logging.Debugf("Auto-selected VRF table ID: %s", vrfTableID)
-	}
+	// All values allocated to this member in the cluster database are released on failure
+	reverter.Add(rollbackHook(ctx, "BGP allocations", func(ctx context.Context) error {
+		return releaseAll(ctx, s)
+	}))
 
-	err = createExternalBridges(ctx, s, extConnections)
+	// VRF table ID is allocated in the cluster database, it's auto-selected if not provided by the user
+	vrfTableID, err := allocateVrfTableID(ctx, s, extraConfig.Vrf)
 	if err != nil {
-		return errors.Join(err, DisableService(ctx, s))
+		return fmt.Errorf("failed to allocate VRF table ID: %v", err)
 	}
+	logging.Debugf("Allocated VRF table ID: %s", vrfTableID)
 
-	err = createExternalNetworks(ctx, s, extConnections)
-	if err != nil {
-		return errors.Join(err, DisableService(ctx, s))
+	// ASN is allocated before any resources are created, so that conflicting ASN is rejected early
+	var asn string
+	if !extraConfig.ManualBgpdConfig {
+		asn, err = allocateAsn(ctx, s, extraConfig.Asn, extraConfig.AsnRange)
+		if err != nil {
+			return fmt.Errorf("failed to allocate ASN: %v", err)
+		}
+		logging.Debugf("Allocated ASN: %s", asn)
 	}
 
-	err = createVrf(ctx, s, extConnections, vrfTableID)
+	backend, err := network.GetBackend(extraConfig.NetworkBackend)
 	if err != nil {
-		return errors.Join(err, DisableService(ctx, s))
+		return err
 	}
 
-	err = redirectBgp(ctx, s, extConnections, vrfTableID)
+	removeRedirect, err := setupRedirect(ctx, s, backend, extConnections, vrfTableID)
 	if err != nil {
-		return errors.Join(err, DisableService(ctx, s))
+		return err
 	}
+	reverter.Add(removeRedirect)
 
-	// Check if BIRD configuration should be skipped for manual configuration
-	if extraConfig.ManualBgpdConfig {
-		logging.Debugf("Skipping automatic BIRD daemon configuration as per user request")
-		return nil
+	if extraConfig.Evpn.IsEnabled() {
+		// Network configuration may be recorded even if it fails to be applied
+		reverter.Add(rollbackHook(ctx, "EVPN interfaces", teardownEvpn))
+		err = setupEvpn(ctx, s, backend, extraConfig.Evpn, vrfTableID)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Autoselect ASN if not provided by the user
-	asn := extraConfig.Asn
-	if asn == "" {
-		// Determine the ASN range to use
-		asnRange := extraConfig.AsnRange
-		if asnRange[0] == 0 && asnRange[1] == 0 {
-			// The values 4200000000-4209999999 is reserved for lower tier network
-			// infrastructure to enable structured ASN allocation schemes
-			asnRange = [2]uint64{4210000000, 4294967294}
+	applied := appliedConfig{ExtraBgpConfig: *extraConfig}
+	applied.Vrf = vrfTableID
+
+	// Check if routing daemon configuration should be skipped for manual configuration
+	if extraConfig.ManualBgpdConfig {
+		logging.Debugf("Skipping automatic routing daemon configuration as per user request")
+	} else {
+		applied.Asn = asn
+		applied.RouterID, err = allocateRouterID(ctx, s, "", getLrpName(s, extConnections[0].Iface))
+		if err != nil {
+			return err
 		}
 
-		asn, err = generateAsnFromClusterMemberID(ctx, s, asnRange)
+		// Configuration may be rendered even if the routing daemon fails to apply it
+		reverter.Add(rollbackHook(ctx, "routing daemon configuration", daemon.ResetConfig))
+		err = daemon.Configure(ctx, extConnections, applied)
 		if err != nil {
-			return errors.Join(fmt.Errorf("failed to auto-select ASN: %v", err), DisableService(ctx, s))
+			return err
 		}
-		logging.Debugf("Auto-selected ASN: %s", asn)
 	}
 
-	err = configureBirdBgp(ctx, s, extConnections, vrfTableID, asn)
+	err = saveAppliedConfig(ctx, s, applied)
 	if err != nil {
-		return errors.Join(err, DisableService(ctx, s))
+		return err
 	}
 
+	reverter.Success()
 	return nil
 }
 
 // DisableService stops and disables BGP services managed by MicroOVN.
 func DisableService(ctx context.Context, s state.State) error {
+	daemonName := ""
+	config, err := loadAppliedConfig(ctx, s)
+	if err != nil {
+		logging.Warnf("Failed to load BGP configuration, assuming default routing daemon: %s", err)
+	} else if config != nil {
+		daemonName = config.Daemon
+	}
+
+	daemon, err := getRoutingDaemon(daemonName)
+	if err != nil {
+		return err
+	}
+
+	return disableService(ctx, s, daemon)
+}
+
+// disableService stops and disables routing "daemon" and removes all resources of the BGP integration.
+func disableService(ctx context.Context, s state.State, daemon RoutingDaemon) error {
 	var allErrors error
 
-	err := snap.Stop(ctx, BirdService, true)
+	err := daemon.Stop(ctx)
 	if err != nil {
-		logging.Warnf("Failed to stop %s service: %s", BirdService, err)
+		logging.Warnf("Failed to stop %s routing daemon: %s", daemon.Name(), err)
 		allErrors = errors.Join(allErrors, errors.New("failed to stop BGP service"))
 	}
 
-	err = teardownAll(ctx, s)
+	err = teardownAll(ctx, s, daemon)
+	if err != nil {
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	err = removeAppliedConfig(ctx, s)
+	if err != nil {
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	err = releaseAll(ctx, s)
 	if err != nil {
 		allErrors = errors.Join(allErrors, err)
 	}
@@ -219,33 +186,90 @@ func DisableService(ctx context.Context, s state.State) error {
 	return allErrors
 }
 
-// configureBirdBgp configures the Bird Routing Daemon to start BGP processes listening on each interface in
-// extConnections.
-// Each BGP daemon is connected to the VRF table specified by "tableID". It will announce routes from the VRF
-// to its peers, and it will insert routes announced by its peers into the same VRF.
-// All BGP daemons will be configured with the provided local ASN.
-func configureBirdBgp(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, tableID string, asn string) error {
-	vrfName := getVrfName(tableID)
+// Reconcile re-applies BGP configuration stored for the local member. Complete redirect of BGP+BFD traffic
+// is left untouched, so that restarts of MicroOVN do not disturb forwarding. If the Logical Router or
+// Logical Switches of the redirect are missing, the redirect is set up again, if only interfaces in the
+// local system are missing, just they are recreated. The same applies to the VXLAN device of EVPN mode.
+// Routing daemon configuration is always rendered again, unchanged BGP sessions are not interrupted by it.
+func Reconcile(ctx context.Context, s state.State) error {
+	config, err := loadAppliedConfig(ctx, s)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		logging.Debugf("BGP configuration of this member is not known, skipping reconciliation")
+		return nil
+	}
+
+	daemon, err := getRoutingDaemon(config.Daemon)
+	if err != nil {
+		return err
+	}
+
+	extConnections, err := config.ParseExternalConnection()
+	if err != nil {
+		return err
+	}
 
-	configFile, err := os.OpenFile(paths.BirdConfigFile(), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	// Values in use are recorded in the cluster database, in case they were allocated by an older version
+	err = recordAllocations(ctx, s, *config, extConnections)
 	if err != nil {
-		return fmt.Errorf("failed to open Bird configuration file for writing: %w", err)
+		logging.Warnf("Failed to record BGP allocations of this member: %s", err)
 	}
 
-	err = birdConfTemplate.Execute(configFile, birdTemplateInput{
-		VrfTableID:     tableID,
-		VrfName:        vrfName,
-		RouterID:       generateBGPRouterID(getLrpName(s, extConnections[0].Iface)),
-		ExtConnections: extConnections,
-		ASN:            asn,
-	})
+	backend, err := network.GetBackend(config.NetworkBackend)
 	if err != nil {
-		return fmt.Errorf("failed to render Bird configuration template: %w", err)
+		return err
 	}
 
-	out, err := shared.RunCommandContext(ctx, filepath.Join(paths.Wrappers(), "birdc"), "configure")
+	nbComplete, hostComplete, err := checkRedirect(ctx, s, extConnections)
 	if err != nil {
-		return fmt.Errorf("failed to apply Bird configuration: %w: %s", err, out)
+		return fmt.Errorf("failed to check BGP redirect: %w", err)
 	}
-	return err
+
+	switch {
+	case !nbComplete:
+		logging.Infof("BGP redirect of this member is incomplete, setting it up again")
+		err = teardownRedirect(ctx, s)
+		if err != nil {
+			logging.Warnf("Failed to remove incomplete BGP redirect: %s", err)
+		}
+
+		_, err = setupRedirect(ctx, s, backend, extConnections, config.Vrf)
+		if err != nil {
+			return err
+		}
+	case !hostComplete:
+		// Logical Router Ports are kept, so that OVN keeps redistributing routes into the VRF and
+		// BGP sessions that are still running are not interrupted.
+		logging.Infof("Interfaces of BGP redirect of this member are incomplete, recreating them")
+		err = generateVeth(ctx, s, backend, extConnections, config.Vrf)
+		if err != nil {
+			return err
+		}
+
+		err = attachRedirectPorts(ctx, s, extConnections, config.Vrf)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !checkEvpn(config.Evpn) {
+		logging.Infof("EVPN interfaces of this member are incomplete, recreating them")
+		err = teardownEvpn(ctx)
+		if err != nil {
+			logging.Warnf("Failed to remove incomplete EVPN interfaces: %s", err)
+		}
+
+		err = setupEvpn(ctx, s, backend, config.Evpn, config.Vrf)
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.ManualBgpdConfig {
+		return nil
+	}
+
+	return daemon.Configure(ctx, extConnections, *config)
 }
@@ -0,0 +1,220 @@
+package bgp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+// birdTimeRegex matches time portion of the "Since" column in BIRD's protocol listing.
+var birdTimeRegex = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}`)
+
+// birdRoutesRegex matches counters in the "Routes:" line of BIRD's protocol channel details, e.g.:
+//
+//	Routes:         3 imported, 2 exported, 3 preferred
+var birdRoutesRegex = regexp.MustCompile(`(\d+) (imported|exported)`)
+
+// ipRoute represents a single route in the JSON output of the "ip route" command.
+type ipRoute struct {
+	Dst      string `json:"dst"`
+	Gateway  string `json:"gateway"`
+	Dev      string `json:"dev"`
+	Nexthops []struct {
+		Gateway string `json:"gateway"`
+		Dev     string `json:"dev"`
+	} `json:"nexthops"`
+}
+
+// parseBirdProtocols parses output of the "birdc show protocols all" command and returns status
+// of every BGP protocol instance found in it.
+func parseBirdProtocols(output string) []types.BgpProtocolStatus {
+	var protocols []types.BgpProtocolStatus
+	var current *types.BgpProtocolStatus
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+
+		// Protocol summary lines are not indented, details about the protocol follow them
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			current = nil
+			fields := strings.Fields(line)
+			if len(fields) < 5 || fields[0] == "BIRD" || fields[0] == "Name" || fields[1] != "BGP" {
+				continue
+			}
+
+			protocol := types.BgpProtocolStatus{Name: fields[0], State: fields[3], Since: fields[4]}
+			infoStart := 5
+			if len(fields) > 5 && birdTimeRegex.MatchString(fields[5]) {
+				protocol.Since = fields[4] + " " + fields[5]
+				infoStart = 6
+			}
+			if len(fields) > infoStart {
+				protocol.Info = strings.Join(fields[infoStart:], " ")
+			}
+
+			protocols = append(protocols, protocol)
+			current = &protocols[len(protocols)-1]
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "Neighbor address":
+			current.NeighborAddress = value
+		case "Neighbor AS":
+			current.NeighborAs = value
+		case "Routes":
+			for _, match := range birdRoutesRegex.FindAllStringSubmatch(value, -1) {
+				count, _ := strconv.Atoi(match[1])
+				if match[2] == "imported" {
+					current.ImportedRoutes += count
+				} else {
+					current.ExportedRoutes += count
+				}
+			}
+		}
+	}
+
+	return protocols
+}
+
+// parseIPRoutes parses JSON output of the "ip route" command.
+func parseIPRoutes(output string) ([]types.BgpRoute, error) {
+	var ipRoutes []ipRoute
+	if strings.TrimSpace(output) == "" {
+		return nil, nil
+	}
+
+	err := json.Unmarshal([]byte(output), &ipRoutes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse routes: %w", err)
+	}
+
+	routes := make([]types.BgpRoute, 0, len(ipRoutes))
+	for _, route := range ipRoutes {
+		gateway, dev := route.Gateway, route.Dev
+		if len(route.Nexthops) != 0 && gateway == "" {
+			gateway, dev = route.Nexthops[0].Gateway, route.Nexthops[0].Dev
+		}
+		routes = append(routes, types.BgpRoute{Prefix: route.Dst, Gateway: gateway, Interface: dev})
+	}
+
+	return routes, nil
+}
+
+// getLocalVrfName returns name of the VRF used for BGP redirect on the local chassis. The name is
+// looked up from "external_ids" of OVS ports used for BGP redirect.
+func getLocalVrfName(ctx context.Context, s state.State) (string, error) {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return "", err
+	}
+	defer ovs.Close()
+
+	ports, err := ovsdbclient.Find[ovsdbclient.Port](ctx, ovs, ovsdbclient.TablePort,
+		ovsdbclient.Includes("external_ids", ovsdbclient.Map(managedExternalIDs())),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to lookup OVS Ports managed by MicroOVN: %w", err)
+	}
+
+	for _, port := range ports {
+		if vrfName := port.ExternalIDs[BgpVrfTable]; vrfName != "" {
+			return vrfName, nil
+		}
+	}
+
+	return "", nil
+}
+
+// getVrfRoutes returns IPv4 and IPv6 routes installed into VRF "vrfName" by the routing daemon
+// "daemon".
+func getVrfRoutes(ctx context.Context, vrfName string, daemon RoutingDaemon) ([]types.BgpRoute, error) {
+	var routes []types.BgpRoute
+	for _, family := range []string{"-4", "-6"} {
+		output, err := shared.RunCommandContext(ctx, "ip", "-j", family, "route", "show", "vrf", vrfName, "proto", daemon.RouteProtocol())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list routes in VRF '%s': %w", vrfName, err)
+		}
+
+		familyRoutes, err := parseIPRoutes(output)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, familyRoutes...)
+	}
+
+	return routes, nil
+}
+
+// LocalStatus returns state of BGP sessions of the local routing daemon and routes learned into the
+// VRF table. Errors are reported in the "Error" field of the returned status.
+func LocalStatus(ctx context.Context, s state.State) types.BgpMemberStatus {
+	status := types.BgpMemberStatus{Member: s.Name(), Protocols: []types.BgpProtocolStatus{}, Routes: []types.BgpRoute{}}
+
+	daemonName := ""
+	config, err := loadAppliedConfig(ctx, s)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	if config != nil {
+		daemonName = config.Daemon
+	}
+
+	daemon, err := getRoutingDaemon(daemonName)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	vrfName, err := getLocalVrfName(ctx, s)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	sessions, err := daemon.Sessions(ctx, vrfName)
+	if err != nil {
+		status.Error = fmt.Sprintf("failed to query BGP daemon: %s", err)
+		return status
+	}
+	status.Protocols = append(status.Protocols, sessions...)
+
+	if vrfName == "" {
+		return status
+	}
+
+	routes, err := getVrfRoutes(ctx, vrfName, daemon)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Routes = append(status.Routes, routes...)
+
+	return status
+}
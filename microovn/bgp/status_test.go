@@ -0,0 +1,83 @@
+package bgp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestParseBirdProtocols(t *testing.T) {
+	output := `BIRD 2.15.1 ready.
+Name       Proto      Table      State  Since         Info
+device1    Device     ---        up     2024-05-02 15:21:14
+
+microovn_eth1 BGP        ---        start  15:21:14.086  Passive
+  BGP state:          Passive
+    Neighbor range:   fe80::/10
+    Neighbor AS:      external
+
+dyn_microovn_eth1_1 BGP        ---        up     2024-05-02 15:37:34  Established
+  Created:            15:37:34.578
+  BGP state:          Established
+    Neighbor address: fe80::1%veth1-bgp
+    Neighbor AS:      4200000001
+    Local AS:         4210000000
+  Channel ipv4
+    State:          UP
+    Table:          ovnvrf10
+    Routes:         3 imported, 2 exported, 3 preferred
+  Channel ipv6
+    State:          UP
+    Table:          ovnvrf10v6
+    Routes:         1 imported, 0 filtered, 1 exported, 1 preferred
+`
+
+	expected := []types.BgpProtocolStatus{
+		{
+			Name:       "microovn_eth1",
+			State:      "start",
+			Since:      "15:21:14.086",
+			Info:       "Passive",
+			NeighborAs: "external",
+		},
+		{
+			Name:            "dyn_microovn_eth1_1",
+			State:           "up",
+			Since:           "2024-05-02 15:37:34",
+			Info:            "Established",
+			NeighborAddress: "fe80::1%veth1-bgp",
+			NeighborAs:      "4200000001",
+			ImportedRoutes:  4,
+			ExportedRoutes:  3,
+		},
+	}
+
+	protocols := parseBirdProtocols(output)
+	if !reflect.DeepEqual(protocols, expected) {
+		t.Errorf("expected %+v, got %+v", expected, protocols)
+	}
+}
+
+func TestParseIPRoutes(t *testing.T) {
+	output := `[{"dst":"10.0.0.0/24","gateway":"192.0.2.1","dev":"veth1-bgp","protocol":"bird","metric":32,"flags":[]},` +
+		`{"dst":"default","protocol":"bird","metric":32,"flags":[],"nexthops":[{"gateway":"fe80::1","dev":"veth1-bgp"},{"gateway":"fe80::2","dev":"veth2-bgp"}]}]`
+
+	expected := []types.BgpRoute{
+		{Prefix: "10.0.0.0/24", Gateway: "192.0.2.1", Interface: "veth1-bgp"},
+		{Prefix: "default", Gateway: "fe80::1", Interface: "veth1-bgp"},
+	}
+
+	routes, err := parseIPRoutes(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf("expected %+v, got %+v", expected, routes)
+	}
+
+	routes, err = parseIPRoutes("")
+	if err != nil || len(routes) != 0 {
+		t.Errorf("expected no routes and no error for empty output, got %v, %v", routes, err)
+	}
+}
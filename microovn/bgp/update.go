@@ -0,0 +1,571 @@
+package bgp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/lxd/shared/revert"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/external"
+	"github.com/canonical/microovn/microovn/netplan"
+	"github.com/canonical/microovn/microovn/network"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+// diffExternalConnections compares "current" and "desired" external connections and returns those
+// that need to be removed and those that need to be added. Connections with changed addresses are
+// present in both lists.
+func diffExternalConnections(current []types.BgpExternalConnection, desired []types.BgpExternalConnection) ([]types.BgpExternalConnection, []types.BgpExternalConnection) {
+	var removed, added []types.BgpExternalConnection
+	for _, connection := range current {
+		if !slices.Contains(desired, connection) {
+			removed = append(removed, connection)
+		}
+	}
+
+	for _, connection := range desired {
+		if !slices.Contains(current, connection) {
+			added = append(added, connection)
+		}
+	}
+
+	return removed, added
+}
+
+// removedInterfaces returns connections from "removed" whose interface is not used by any connection in
+// "extConnections".
+func removedInterfaces(removed []types.BgpExternalConnection, extConnections []types.BgpExternalConnection) []types.BgpExternalConnection {
+	var connections []types.BgpExternalConnection
+	for _, connection := range removed {
+		if !slices.ContainsFunc(extConnections, func(c types.BgpExternalConnection) bool { return c.Iface == connection.Iface }) {
+			connections = append(connections, connection)
+		}
+	}
+	return connections
+}
+
+// mergeBgpConfig returns configuration that results from applying "update" on top of "current"
+// configuration. Options that are not set in "update" keep their current value, per-connection, route
+// policy, graceful restart and EVPN options listed in "unset" are cleared. Per-connection options of removed
+// external connections are dropped.
+func mergeBgpConfig(current types.ExtraBgpConfig, update types.ExtraBgpConfig, unset []string) (types.ExtraBgpConfig, error) {
+	merged := current
+	if update.ExternalConnection != "" {
+		merged.ExternalConnection = update.ExternalConnection
+	}
+
+	if update.Vrf != "" {
+		merged.Vrf = update.Vrf
+	}
+
+	if update.Daemon != "" {
+		merged.Daemon = update.Daemon
+	}
+
+	if update.NetworkBackend != "" {
+		merged.NetworkBackend = update.NetworkBackend
+	}
+
+	// Explicit ASN takes precedence over the ASN range, new ASN is selected from the range later
+	if update.Asn != "" {
+		merged.Asn = update.Asn
+		merged.AsnRange = [2]uint64{}
+	} else if update.AsnRange != [2]uint64{} {
+		merged.Asn = ""
+		merged.AsnRange = update.AsnRange
+	}
+
+	merged.Policy = current.Policy.Merge(update.Policy)
+	merged.GracefulRestart = current.GracefulRestart.Merge(update.GracefulRestart)
+	merged.Evpn = current.Evpn.Merge(update.Evpn)
+
+	merged.Peers = make(map[string]types.BgpPeerConfig)
+	for iface, peer := range current.Peers {
+		merged.Peers[iface] = peer
+	}
+	for iface, peer := range update.Peers {
+		merged.Peers[iface] = merged.Peers[iface].Merge(peer)
+	}
+
+	for _, key := range unset {
+		idx := strings.LastIndex(key, ".")
+		if idx <= 0 {
+			if merged.Policy.Unset(key) == nil || merged.GracefulRestart.Unset(key) == nil || merged.Evpn.Unset(key) == nil {
+				continue
+			}
+			return merged, fmt.Errorf("only per-connection, route policy, graceful restart and EVPN options can be unset: %s", key)
+		}
+
+		iface := key[:idx]
+		peer := merged.Peers[iface]
+		err := peer.Unset(key[idx+1:])
+		if err != nil {
+			return merged, err
+		}
+		merged.Peers[iface] = peer
+	}
+
+	extConnections, err := merged.ParseExternalConnection()
+	if err != nil {
+		return merged, err
+	}
+	for iface, peer := range merged.Peers {
+		if peer.IsEmpty() || !slices.ContainsFunc(extConnections, func(c types.BgpExternalConnection) bool { return c.Iface == iface }) {
+			delete(merged.Peers, iface)
+		}
+	}
+	if len(merged.Peers) == 0 {
+		merged.Peers = nil
+	}
+
+	return merged, nil
+}
+
+// removeExternalConnections removes resources that redirect BGP+BFD traffic from external networks
+// defined in "extConnections" argument. Resources of other external connections remain untouched.
+func removeExternalConnections(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) error {
+	err := removeExternalNetworks(ctx, s, extConnections)
+	if err != nil {
+		return err
+	}
+
+	return removeExternalBridges(ctx, s, extConnections)
+}
+
+// removeExternalNetworks removes Logical Switches of external networks defined in "extConnections" argument
+// and disconnects them from the Logical Router, in a single transaction.
+func removeExternalNetworks(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) error {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer nb.Close()
+
+	lrRow := []ovsdbclient.Condition{ovsdbclient.Equal("name", getLrName(s))}
+	var nbOperations []ovsdbclient.Operation
+	for _, extConnection := range extConnections {
+		lrPorts, err := ovsdbclient.Find[ovsdbclient.LogicalRouterPort](ctx, nb, ovsdbclient.TableLogicalRouterPort,
+			ovsdbclient.Equal("name", getLrpName(s, extConnection.Iface)),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to lookup Logical Router Port for '%s': %v", extConnection.Iface, err)
+		}
+
+		// Logical Router Ports are removed by the database once they are not referenced by the router
+		for _, lrPort := range lrPorts {
+			nbOperations = append(nbOperations, ovsdbclient.Mutate(ovsdbclient.TableLogicalRouter, lrRow,
+				ovsdbclient.SetDelete("ports", ovsdbclient.UUID(lrPort.UUID)),
+			))
+		}
+		nbOperations = append(nbOperations, ovsdbclient.Delete(ovsdbclient.TableLogicalSwitch,
+			ovsdbclient.Equal("name", getLsName(s, extConnection.Iface)),
+		))
+	}
+
+	_, err = nb.Transact(ctx, nbOperations...)
+	if err != nil {
+		return fmt.Errorf("failed to remove OVN external networks: %v", err)
+	}
+	return nil
+}
+
+// removeExternalBridges removes OVS bridges of external connections defined in "extConnections" argument,
+// along with their OVN bridge mappings and ports of their BGP redirect veth pairs, in a single transaction.
+func removeExternalBridges(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) error {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return fmt.Errorf("failed to lookup ovn-bridge-mappings: %v", err)
+	}
+	openvSwitchRow := []ovsdbclient.Condition{ovsdbclient.HasUUID(openvSwitch.UUID)}
+
+	var removedBridgeMaps []string
+	var ovsOperations []ovsdbclient.Operation
+	for _, extConnection := range extConnections {
+		bridgeName := external.BridgeName(extConnection.Iface)
+		removedBridgeMaps = append(removedBridgeMaps, fmt.Sprintf("%s:%s", getPhysnetName(s, extConnection.Iface), bridgeName))
+
+		bridges, err := ovsdbclient.Find[ovsdbclient.Bridge](ctx, ovs, ovsdbclient.TableBridge, ovsdbclient.Equal("name", bridgeName))
+		if err != nil {
+			return fmt.Errorf("failed to lookup OVS Bridge '%s': %v", bridgeName, err)
+		}
+		for _, bridge := range bridges {
+			ovsOperations = append(ovsOperations, ovsdbclient.Mutate(ovsdbclient.TableOpenvSwitch, openvSwitchRow,
+				ovsdbclient.SetDelete("bridges", ovsdbclient.UUID(bridge.UUID)),
+			))
+		}
+
+		// Port of the BGP redirect veth pair in the integration bridge
+		portName := getBgpRedirectIfacePeerName(extConnection.Iface)
+		ports, err := ovsdbclient.Find[ovsdbclient.Port](ctx, ovs, ovsdbclient.TablePort, ovsdbclient.Equal("name", portName))
+		if err != nil {
+			return fmt.Errorf("failed to lookup OVS Port '%s': %v", portName, err)
+		}
+		for _, port := range ports {
+			portRef := ovsdbclient.UUID(port.UUID)
+			ovsOperations = append(ovsOperations, ovsdbclient.Mutate(ovsdbclient.TableBridge,
+				[]ovsdbclient.Condition{ovsdbclient.Includes("ports", portRef)},
+				ovsdbclient.SetDelete("ports", portRef),
+			))
+		}
+	}
+
+	filterBridgeMaps := func(value string, protected []string) string {
+		var bridgeMaps []string
+		for _, bridgeMap := range strings.Split(value, ",") {
+			if bridgeMap != "" && (!slices.Contains(removedBridgeMaps, bridgeMap) || slices.Contains(protected, bridgeMap)) {
+				bridgeMaps = append(bridgeMaps, bridgeMap)
+			}
+		}
+		return strings.Join(bridgeMaps, ",")
+	}
+	// Entries also tracked by other MicroOVN features stay in ovn-bridge-mappings
+	protectedBridgeMaps := external.TrackedElsewhere(openvSwitch.ExternalIDs, BgpBridgeMapping)
+	ovsOperations = append(ovsOperations, ovsdbclient.SetMapKeys(ovsdbclient.TableOpenvSwitch, openvSwitchRow, "external_ids", map[string]string{
+		"ovn-bridge-mappings": filterBridgeMaps(openvSwitch.ExternalIDs["ovn-bridge-mappings"], protectedBridgeMaps),
+		BgpBridgeMapping:      filterBridgeMaps(openvSwitch.ExternalIDs[BgpBridgeMapping], nil),
+	}))
+
+	_, err = ovs.Transact(ctx, ovsOperations...)
+	if err != nil {
+		return fmt.Errorf("failed to remove OVS resources of external connections: %v", err)
+	}
+
+	return nil
+}
+
+// configUpdate - BGP configuration of the local member before and after an update, with the routing daemon
+// and network backend that apply it
+type configUpdate struct {
+	current appliedConfig
+	desired appliedConfig
+	daemon  RoutingDaemon
+	backend network.Backend
+}
+
+// prepareUpdate merges "update" and "unset" into the BGP configuration of the local member and validates
+// the result. Changes of the routing daemon and the network backend are rejected.
+func prepareUpdate(ctx context.Context, s state.State, update *types.ExtraBgpConfig, unset []string) (*configUpdate, error) {
+	current, err := loadAppliedConfig(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errors.New("BGP configuration of this member is not known, disable and enable 'bgp' service to manage it")
+	}
+
+	merged, err := mergeBgpConfig(current.ExtraBgpConfig, *update, unset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge BGP config: %w", err)
+	}
+
+	desired := appliedConfig{ExtraBgpConfig: merged, RouterID: current.RouterID}
+	err = desired.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate BGP config: %w", err)
+	}
+
+	daemon, err := getRoutingDaemon(current.Daemon)
+	if err != nil {
+		return nil, err
+	}
+	desiredDaemon, err := getRoutingDaemon(desired.Daemon)
+	if err != nil {
+		return nil, err
+	}
+	if desiredDaemon.Name() != daemon.Name() {
+		return nil, errors.New("routing daemon can not be changed, disable and enable 'bgp' service to change it")
+	}
+
+	backend, err := network.GetBackend(current.NetworkBackend)
+	if err != nil {
+		return nil, err
+	}
+	desiredBackend, err := network.GetBackend(desired.NetworkBackend)
+	if err != nil {
+		return nil, err
+	}
+	if desiredBackend.Name() != backend.Name() {
+		return nil, errors.New("network backend can not be changed, disable and enable 'bgp' service to change it")
+	}
+
+	err = daemon.Validate(desired.ExtraBgpConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate BGP config: %w", err)
+	}
+
+	return &configUpdate{current: *current, desired: desired, daemon: daemon, backend: backend}, nil
+}
+
+// PreviewUpdate returns changes of network configuration that UpdateService would make with "update" and
+// "unset", without changing anything. It returns the resulting configuration as well, values that are
+// not allocated yet are left unresolved.
+func PreviewUpdate(ctx context.Context, s state.State, update *types.ExtraBgpConfig, unset []string) (*types.BgpConfigPreview, error) {
+	prepared, err := prepareUpdate(ctx, s, update, unset)
+	if err != nil {
+		return nil, err
+	}
+	desired := prepared.desired
+
+	// Conflicting VRF table ID is rejected the same way as by the update
+	_, err = previewAllocation(ctx, s, allocationVrf, "", desired.Vrf, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredConnections, err := desired.ParseExternalConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	vethNp, err := vethConfig(ctx, s, desiredConnections, desired.Vrf, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var evpnNp *netplan.Config
+	if desired.Evpn.IsEnabled() {
+		evpnNp, err = evpnConfig(desired.Evpn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	preview := &types.BgpConfigPreview{Member: s.Name(), Config: desired.ExtraBgpConfig}
+	for name, np := range map[string]*netplan.Config{vethNetplanFile: vethNp, evpnNetplanFile: evpnNp} {
+		diff, err := prepared.backend.Diff(name, np)
+		if err != nil {
+			return nil, err
+		}
+		if diff != "" {
+			preview.Network = append(preview.Network, types.BgpNetworkDiff{Name: name, Diff: diff})
+		}
+	}
+	slices.SortFunc(preview.Network, func(a, b types.BgpNetworkDiff) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return preview, nil
+}
+
+// UpdateService applies changes in BGP configuration of the local member without disabling the BGP
+// service. Options that are not set in "update" keep their current value, per-connection and route policy
+// options listed in "unset" are cleared. Only external connections
+// that were added, removed or whose addresses changed are reconfigured, BGP sessions on other external
+// connections are not interrupted. Change of the VRF table ID requires all external connections to be
+// set up again. VXLAN device of EVPN mode is created again only if the VNI, the VTEP address or the VRF
+// changes. If any step fails, the current configuration is restored with restoreConfig.
+//
+// It returns the resulting configuration, with auto-selected values resolved.
+func UpdateService(ctx context.Context, s state.State, update *types.ExtraBgpConfig, unset []string) (*types.ExtraBgpConfig, error) {
+	prepared, err := prepareUpdate(ctx, s, update, unset)
+	if err != nil {
+		return nil, err
+	}
+	current, desired, daemon, backend := prepared.current, prepared.desired, prepared.daemon, prepared.backend
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	currentConnections, err := current.ParseExternalConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	// Values of the current configuration are allocated again on failure
+	reverter.Add(rollbackHook(ctx, "BGP allocations", func(ctx context.Context) error {
+		return recordAllocations(ctx, s, current, currentConnections)
+	}))
+
+	// Conflicting VRF table ID and ASN are rejected before any resources are changed
+	desired.Vrf, err = allocateVrfTableID(ctx, s, desired.Vrf)
+	if err != nil {
+		return nil, err
+	}
+	if !desired.ManualBgpdConfig {
+		desired.Asn, err = allocateAsn(ctx, s, desired.Asn, desired.AsnRange)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	desiredConnections, err := desired.ParseExternalConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	// Resources may be changed only partially, the current configuration is set up again on failure
+	reverter.Add(rollbackHook(ctx, "BGP configuration update", func(ctx context.Context) error {
+		return restoreConfig(ctx, s, daemon, backend, current)
+	}))
+
+	if desired.Vrf != current.Vrf {
+		// Interfaces have to be moved to a different VRF, the whole redirect is set up again
+		logger.Infof("VRF table changed from %s to %s, setting up BGP redirect again", current.Vrf, desired.Vrf)
+		err = teardownRedirect(ctx, s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove BGP redirect: %w", err)
+		}
+
+		err = releaseLrpMacs(ctx, s, removedInterfaces(currentConnections, desiredConnections))
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = setupRedirect(ctx, s, backend, desiredConnections, desired.Vrf)
+		if err != nil {
+			return nil, err
+		}
+
+		err = updateMemberRouters(ctx, s, desired.Vrf)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		removed, added := diffExternalConnections(currentConnections, desiredConnections)
+		err = updateRedirect(ctx, s, backend, desiredConnections, removed, added, desired.Vrf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if evpnDevicesChanged(current.Evpn, desired.Evpn, current.Vrf, desired.Vrf) {
+		err = teardownEvpn(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = setupEvpn(ctx, s, backend, desired.Evpn, desired.Vrf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !desired.ManualBgpdConfig {
+		if desired.RouterID == "" {
+			desired.RouterID, err = allocateRouterID(ctx, s, "", getLrpName(s, desiredConnections[0].Iface))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Unchanged BGP sessions are kept running by the routing daemon when the configuration is reloaded
+		err = daemon.Configure(ctx, desiredConnections, desired)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = saveAppliedConfig(ctx, s, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	reverter.Success()
+	return &desired.ExtraBgpConfig, nil
+}
+
+// restoreConfig sets up applied BGP "config" of the local member again, after an update failed partway.
+// Values of the configuration are allocated again, resources of the BGP redirect and EVPN mode are
+// removed and created from scratch and the routing "daemon" is configured with "config". Interfaces are
+// created with the network "backend".
+func restoreConfig(ctx context.Context, s state.State, daemon RoutingDaemon, backend network.Backend, config appliedConfig) error {
+	extConnections, err := config.ParseExternalConnection()
+	if err != nil {
+		return err
+	}
+
+	err = recordAllocations(ctx, s, config, extConnections)
+	if err != nil {
+		return err
+	}
+
+	err = errors.Join(teardownEvpn(ctx), teardownRedirect(ctx, s))
+	if err != nil {
+		logger.Warnf("Failed to remove BGP resources before restoring them: %v", err)
+	}
+
+	_, err = setupRedirect(ctx, s, backend, extConnections, config.Vrf)
+	if err != nil {
+		return err
+	}
+
+	err = updateMemberRouters(ctx, s, config.Vrf)
+	if err != nil {
+		return err
+	}
+
+	err = setupEvpn(ctx, s, backend, config.Evpn, config.Vrf)
+	if err != nil {
+		return err
+	}
+
+	if config.ManualBgpdConfig {
+		return nil
+	}
+	return daemon.Configure(ctx, extConnections, config)
+}
+
+// updateRedirect removes BGP redirect of "removed" external connections and sets it up for "added"
+// external connections. Argument "extConnections" contains all external connections that should be
+// present after the update. Interfaces are created with the network "backend".
+func updateRedirect(ctx context.Context, s state.State, backend network.Backend, extConnections, removed, added []types.BgpExternalConnection, tableID string) error {
+	if len(removed) == 0 && len(added) == 0 {
+		return nil
+	}
+
+	if len(removed) != 0 {
+		err := removeExternalConnections(ctx, s, removed)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(added) != 0 {
+		err := createExternalBridges(ctx, s, added)
+		if err != nil {
+			return err
+		}
+
+		err = addExternalNetworks(ctx, s, added)
+		if err != nil {
+			return err
+		}
+
+		err = createVrf(ctx, s, added, tableID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := generateVeth(ctx, s, backend, extConnections, tableID)
+	if err != nil {
+		return err
+	}
+
+	// Veths of removed connections were removed by the network backend. Connections with changed
+	// addresses are recreated, their MAC addresses must not be released.
+	removedVeths := removedInterfaces(removed, extConnections)
+
+	err = releaseLrpMacs(ctx, s, removedVeths)
+	if err != nil {
+		return err
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	return redirectBgp(ctx, s, added, tableID)
+}
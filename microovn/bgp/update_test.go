@@ -0,0 +1,145 @@
+package bgp
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestDiffExternalConnections(t *testing.T) {
+	eth1 := types.BgpExternalConnection{Iface: "eth1"}
+	eth2 := types.BgpExternalConnection{Iface: "eth2", IPv4: netip.MustParsePrefix("192.0.2.1/24")}
+	eth2Changed := types.BgpExternalConnection{Iface: "eth2", IPv4: netip.MustParsePrefix("192.0.2.2/24")}
+	eth3 := types.BgpExternalConnection{Iface: "eth3"}
+
+	removed, added := diffExternalConnections(
+		[]types.BgpExternalConnection{eth1, eth2},
+		[]types.BgpExternalConnection{eth1, eth2Changed, eth3},
+	)
+
+	if !reflect.DeepEqual(removed, []types.BgpExternalConnection{eth2}) {
+		t.Errorf("unexpected removed connections: %+v", removed)
+	}
+	if !reflect.DeepEqual(added, []types.BgpExternalConnection{eth2Changed, eth3}) {
+		t.Errorf("unexpected added connections: %+v", added)
+	}
+
+	removed, added = diffExternalConnections(
+		[]types.BgpExternalConnection{eth1, eth2},
+		[]types.BgpExternalConnection{eth2, eth1},
+	)
+	if len(removed) != 0 || len(added) != 0 {
+		t.Errorf("expected no changes, got removed %+v, added %+v", removed, added)
+	}
+}
+
+func TestMergeBgpConfig(t *testing.T) {
+	current := types.ExtraBgpConfig{ExternalConnection: "eth1", Vrf: "10", Asn: "4210000001"}
+
+	tests := []struct {
+		name     string
+		update   types.ExtraBgpConfig
+		expected types.ExtraBgpConfig
+	}{
+		{
+			name:     "no changes",
+			update:   types.ExtraBgpConfig{},
+			expected: current,
+		},
+		{
+			name:     "external connections",
+			update:   types.ExtraBgpConfig{ExternalConnection: "eth1,eth2"},
+			expected: types.ExtraBgpConfig{ExternalConnection: "eth1,eth2", Vrf: "10", Asn: "4210000001"},
+		},
+		{
+			name:     "ASN range replaces ASN",
+			update:   types.ExtraBgpConfig{AsnRange: [2]uint64{4220000000, 4220000100}},
+			expected: types.ExtraBgpConfig{ExternalConnection: "eth1", Vrf: "10", AsnRange: [2]uint64{4220000000, 4220000100}},
+		},
+		{
+			name:     "ASN and VRF",
+			update:   types.ExtraBgpConfig{Asn: "65001", Vrf: "20"},
+			expected: types.ExtraBgpConfig{ExternalConnection: "eth1", Vrf: "20", Asn: "65001"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := mergeBgpConfig(current, tt.update, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(merged, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, merged)
+			}
+		})
+	}
+}
+
+func TestMergeBgpConfigPeers(t *testing.T) {
+	current := types.ExtraBgpConfig{
+		ExternalConnection: "eth1,eth2",
+		Vrf:                "10",
+		Peers: map[string]types.BgpPeerConfig{
+			"eth1": {Neighbor: "192.0.2.2", Password: "secret"},
+			"eth2": {PeerAsn: "65002"},
+		},
+	}
+
+	update := types.ExtraBgpConfig{Peers: map[string]types.BgpPeerConfig{"eth1": {HoldTime: "9"}}}
+	merged, err := mergeBgpConfig(current, update, []string{"eth1.password"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]types.BgpPeerConfig{
+		"eth1": {Neighbor: "192.0.2.2", HoldTime: "9"},
+		"eth2": {PeerAsn: "65002"},
+	}
+	if !reflect.DeepEqual(merged.Peers, expected) {
+		t.Errorf("expected peers %+v, got %+v", expected, merged.Peers)
+	}
+	if current.Peers["eth1"].Password != "secret" {
+		t.Errorf("current configuration must not be modified")
+	}
+
+	// Redacted password returned by the API must not replace the stored password
+	update = types.ExtraBgpConfig{Peers: map[string]types.BgpPeerConfig{"eth1": {Password: types.BgpRedactedSecret}}}
+	merged, err = mergeBgpConfig(current, update, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged.Validate() == nil {
+		t.Errorf("expected redacted password to be rejected")
+	}
+
+	// Options of removed external connections are dropped
+	merged, err = mergeBgpConfig(current, types.ExtraBgpConfig{ExternalConnection: "eth1"}, []string{"eth1.neighbor", "eth1.password"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged.Peers != nil {
+		t.Errorf("expected no peers, got %+v", merged.Peers)
+	}
+
+	// Route policy options are merged and cleared as well
+	current.Policy = types.BgpPolicyConfig{ExportPrepend: "2", ExportLocalPref: "200"}
+	update = types.ExtraBgpConfig{Policy: types.BgpPolicyConfig{ExportCommunities: "65000:1"}}
+	merged, err = mergeBgpConfig(current, update, []string{"export_as_path_prepend"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedPolicy := types.BgpPolicyConfig{ExportLocalPref: "200", ExportCommunities: "65000:1"}
+	if merged.Policy != expectedPolicy {
+		t.Errorf("expected policy %+v, got %+v", expectedPolicy, merged.Policy)
+	}
+
+	for _, unset := range [][]string{{"vrf"}, {"eth1.unknown"}} {
+		_, err = mergeBgpConfig(current, types.ExtraBgpConfig{}, unset)
+		if err == nil {
+			t.Errorf("expected unset of %v to fail", unset)
+		}
+	}
+}
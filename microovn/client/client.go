@@ -183,7 +183,7 @@ func DisableService(ctx context.Context, c microTypes.Client, serviceName string
 	}
 
 	regenerateEnvResponse := types.RegenerateEnvResponse{}
-	if types.SrvName(serviceName) == types.SrvCentral {
+	if types.SrvName(serviceName) == types.SrvCentral || types.SrvName(serviceName) == types.SrvRelay {
 		regenerateEnvResponse, err = RegenerateEnvironment(ctx, c)
 		if err != nil {
 			return types.WarningSet{}, types.RegenerateEnvResponse{}, err
@@ -205,7 +205,7 @@ func EnableService(ctx context.Context, c microTypes.Client, serviceName string,
 	}
 
 	regenerateEnvResponse := types.RegenerateEnvResponse{}
-	if types.SrvName(serviceName) == types.SrvCentral {
+	if types.SrvName(serviceName) == types.SrvCentral || types.SrvName(serviceName) == types.SrvRelay {
 		regenerateEnvResponse, err = RegenerateEnvironment(ctx, c)
 		if err != nil {
 			return types.WarningSet{}, types.RegenerateEnvResponse{}, err
@@ -265,3 +265,411 @@ func DeleteConfig(ctx context.Context, c microTypes.Client, key string) (types.D
 
 	return responseData, err
 }
+
+// GetRaftMembership queries MicroOVN cluster for RAFT servers of OVN Northbound and Southbound
+// databases. Servers that do not belong to any member with "central" service are marked as stale.
+func GetRaftMembership(ctx context.Context, c microTypes.Client) (types.RaftMembershipReport, error) {
+	return raftMembership(ctx, c, "GET")
+}
+
+// RepairRaftMembership sends request to kick stale servers from RAFT clusters of OVN Northbound
+// and Southbound databases.
+func RepairRaftMembership(ctx context.Context, c microTypes.Client) (types.RaftMembershipReport, error) {
+	return raftMembership(ctx, c, "POST")
+}
+
+// raftMembership is a general function that targets /1.0/database/membership endpoint with
+// the specified HTTP method.
+func raftMembership(ctx context.Context, c microTypes.Client, method string) (types.RaftMembershipReport, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	var response types.RaftMembershipReport
+	err := c.Query(queryCtx, method, types.APIVersion, &url.URL{Path: "database/membership"}, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query RAFT membership: %w", err)
+	}
+
+	return response, nil
+}
+
+// RecoverDatabase sends request to recover OVN central databases from the "target" member after
+// a permanent loss of quorum. Once the databases are recovered, environment files are regenerated
+// on every cluster member.
+func RecoverDatabase(ctx context.Context, c microTypes.Client, force bool, target string) (types.DatabaseRecoverResponse, types.RegenerateEnvResponse, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*120)
+	defer cancel()
+
+	requestData := types.DatabaseRecoverRequest{Force: force}
+	response := types.DatabaseRecoverResponse{}
+	err := c.Query(queryCtx, "POST", types.APIVersion, &url.URL{Path: "database/recover", RawQuery: "target=" + target}, requestData, &response)
+	if err != nil {
+		return response, types.RegenerateEnvResponse{}, fmt.Errorf("failed to recover OVN central databases: %w", err)
+	}
+
+	regenerateEnvResponse, err := RegenerateEnvironment(ctx, c)
+	if err != nil {
+		return response, types.RegenerateEnvResponse{}, err
+	}
+
+	return response, regenerateEnvResponse, nil
+}
+
+// GetOvsdbUpgradeStatus queries MicroOVN cluster for schema upgrade status of OVN central databases.
+func GetOvsdbUpgradeStatus(ctx context.Context, c microTypes.Client) (types.OvsdbUpgradeStatus, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.OvsdbUpgradeStatus{}
+	err := c.Query(queryCtx, "GET", types.APIVersion, &url.URL{Path: "database/upgrade"}, nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to get database upgrade status: %w", err)
+	}
+
+	return response, nil
+}
+
+// SetOvsdbUpgradeHold sends request to put automatic schema upgrades of OVN central databases
+// on hold, or to release the hold.
+func SetOvsdbUpgradeHold(ctx context.Context, c microTypes.Client, hold bool) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	requestData := types.OvsdbUpgradeHoldRequest{Hold: hold}
+	err := c.Query(queryCtx, "PUT", types.APIVersion, &url.URL{Path: "database/upgrade"}, requestData, nil)
+	if err != nil {
+		return fmt.Errorf("failed to set database upgrade hold: %w", err)
+	}
+
+	return nil
+}
+
+// TriggerOvsdbUpgrade sends request to explicitly upgrade schemas of OVN central databases.
+func TriggerOvsdbUpgrade(ctx context.Context, c microTypes.Client, force bool) (types.OvsdbUpgradeReport, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*180)
+	defer cancel()
+
+	requestData := types.OvsdbUpgradeTriggerRequest{Force: force}
+	var response types.OvsdbUpgradeReport
+	err := c.Query(queryCtx, "POST", types.APIVersion, &url.URL{Path: "database/upgrade"}, requestData, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to trigger database upgrade: %w", err)
+	}
+
+	return response, nil
+}
+
+// MigrateInCentral sends request to seed OVN central services on the "target" member with the contents
+// of an external OVN central cluster. Once the migration is complete, environment files are regenerated
+// on every cluster member, pointing chassis to the new OVN central.
+func MigrateInCentral(ctx context.Context, c microTypes.Client, nbRemote string, sbRemote string, target string) (types.WarningSet, types.RegenerateEnvResponse, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*180)
+	defer cancel()
+
+	requestData := types.CentralMigrateInRequest{NbRemote: nbRemote, SbRemote: sbRemote}
+	scr := types.ServiceControlResponse{}
+	err := c.Query(queryCtx, "POST", types.APIVersion, &url.URL{Path: "central/migrate-in", RawQuery: "target=" + target}, requestData, &scr)
+	if err != nil {
+		return types.WarningSet{}, types.RegenerateEnvResponse{}, fmt.Errorf("failed to migrate OVN central: %w", err)
+	}
+
+	regenerateEnvResponse, err := RegenerateEnvironment(ctx, c)
+	if err != nil {
+		return types.WarningSet{}, types.RegenerateEnvResponse{}, err
+	}
+
+	return scr.Warnings, regenerateEnvResponse, nil
+}
+
+// GetBgpStatus queries MicroOVN cluster for state of BGP sessions and routes learned by the BGP
+// daemon on each member that has "bgp" service enabled.
+func GetBgpStatus(ctx context.Context, c microTypes.Client) (types.BgpStatus, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.BgpStatus{}
+	err := c.Query(queryCtx, "GET", types.APIVersion, &url.URL{Path: "bgp/status"}, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BGP status: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetLocalBgpStatus queries MicroOVN member for state of its BGP sessions and routes learned by
+// its BGP daemon.
+func GetLocalBgpStatus(ctx context.Context, c microTypes.Client) (types.BgpMemberStatus, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*20)
+	defer cancel()
+
+	response := types.BgpMemberStatus{}
+	err := c.Query(queryCtx, "GET", types.APIVersion, &url.URL{Path: "bgp/status/local"}, nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to get BGP status: %w", err)
+	}
+
+	return response, nil
+}
+
+// UpdateBgpConfig sends request to apply changes in BGP configuration of the "target" member. Options
+// that are not set in "config" keep their current value, per-connection and route policy options listed
+// in "unset" are cleared. It returns the resulting BGP configuration.
+func UpdateBgpConfig(ctx context.Context, c microTypes.Client, config types.ExtraBgpConfig, unset []string, target string) (types.ExtraBgpConfig, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*120)
+	defer cancel()
+
+	response := types.ExtraBgpConfig{}
+	err := c.Query(queryCtx, "PUT", types.APIVersion, &url.URL{Path: "bgp", RawQuery: "target=" + target}, types.BgpConfigUpdateRequest{Config: config, Unset: unset}, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to update BGP configuration: %w", err)
+	}
+
+	return response, nil
+}
+
+// PreviewBgpConfig sends request to preview changes in BGP configuration of the "target" member, without
+// applying them. It returns the resulting configuration and changes of the network configuration.
+func PreviewBgpConfig(ctx context.Context, c microTypes.Client, config types.ExtraBgpConfig, unset []string, target string) (types.BgpConfigPreview, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.BgpConfigPreview{}
+	err := c.Query(queryCtx, "POST", types.APIVersion, &url.URL{Path: "bgp/preview", RawQuery: "target=" + target}, types.BgpConfigUpdateRequest{Config: config, Unset: unset}, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to preview BGP configuration: %w", err)
+	}
+
+	return response, nil
+}
+
+// CheckBgpConfig sends request to run preflight checks of BGP configuration "config" on the "target"
+// member, without enabling the "bgp" service.
+func CheckBgpConfig(ctx context.Context, c microTypes.Client, config types.ExtraBgpConfig, target string) (types.BgpCheckReport, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.BgpCheckReport{}
+	err := c.Query(queryCtx, "POST", types.APIVersion, &url.URL{Path: "bgp/check", RawQuery: "target=" + target}, config, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to check BGP configuration: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetBgpConfigs queries MicroOVN cluster for BGP configuration of each member that has "bgp" service
+// enabled.
+func GetBgpConfigs(ctx context.Context, c microTypes.Client) (types.BgpConfigs, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	response := types.BgpConfigs{}
+	err := c.Query(queryCtx, "GET", types.APIVersion, &url.URL{Path: "bgp"}, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BGP configuration: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetBgpRouters queries MicroOVN cluster for user logical routers whose routes are advertised through BGP.
+func GetBgpRouters(ctx context.Context, c microTypes.Client) (types.BgpRouters, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	response := types.BgpRouters{}
+	err := c.Query(queryCtx, "GET", types.APIVersion, &url.URL{Path: "bgp/routers"}, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BGP routers: %w", err)
+	}
+
+	return response, nil
+}
+
+// AdvertiseBgpRouter sends request to advertise routes of user logical router "router" through BGP
+// speaker of the "target" member. Only route types listed in "redistribute" are advertised.
+func AdvertiseBgpRouter(ctx context.Context, c microTypes.Client, router string, redistribute []string, target string) (types.BgpRouter, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.BgpRouter{}
+	endpoint := &url.URL{Path: "bgp/routers/" + router, RawQuery: "target=" + target}
+	err := c.Query(queryCtx, "PUT", types.APIVersion, endpoint, types.BgpRouterRequest{Redistribute: redistribute}, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to advertise router '%s': %w", router, err)
+	}
+
+	return response, nil
+}
+
+// WithdrawBgpRouter sends request to stop advertising routes of user logical router "router" through BGP.
+func WithdrawBgpRouter(ctx context.Context, c microTypes.Client, router string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "DELETE", types.APIVersion, &url.URL{Path: "bgp/routers/" + router}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to withdraw router '%s': %w", router, err)
+	}
+
+	return nil
+}
+
+// GetExternalNetworks queries MicroOVN cluster for external networks defined in the cluster.
+func GetExternalNetworks(ctx context.Context, c microTypes.Client) (types.ExternalNetworks, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	networks := types.ExternalNetworks{}
+	err := c.Query(queryCtx, "GET", types.APIVersion, &url.URL{Path: "networks/external"}, nil, &networks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list external networks: %w", err)
+	}
+
+	return networks, nil
+}
+
+// AddExternalNetwork sends request to add external network "network" and to set it up on cluster members
+// with "chassis" service.
+func AddExternalNetwork(ctx context.Context, c microTypes.Client, network types.ExternalNetwork) (types.ExternalNetworkResponse, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	response := types.ExternalNetworkResponse{}
+	err := c.Query(queryCtx, "POST", types.APIVersion, &url.URL{Path: "networks/external"}, network, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to add external network '%s': %w", network.Name, err)
+	}
+
+	return response, nil
+}
+
+// RemoveExternalNetwork sends request to remove external network "name" from the cluster.
+func RemoveExternalNetwork(ctx context.Context, c microTypes.Client, name string) (types.ExternalNetworkResponse, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	response := types.ExternalNetworkResponse{}
+	err := c.Query(queryCtx, "DELETE", types.APIVersion, &url.URL{Path: "networks/external/" + url.PathEscape(name)}, nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to remove external network '%s': %w", name, err)
+	}
+
+	return response, nil
+}
+
+// GetBridgeMappings queries MicroOVN cluster for bridge mappings of all cluster members.
+func GetBridgeMappings(ctx context.Context, c microTypes.Client) (types.BridgeMappings, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	mappings := types.BridgeMappings{}
+	err := c.Query(queryCtx, "GET", types.APIVersion, &url.URL{Path: "networks/bridge-mappings"}, nil, &mappings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bridge mappings: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// SetBridgeMapping sends request to map physical network "physnet" to OVS bridge "bridge" on the "target"
+// member.
+func SetBridgeMapping(ctx context.Context, c microTypes.Client, physnet string, bridge string, target string) (types.BridgeMapping, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.BridgeMapping{}
+	endpoint := &url.URL{Path: "networks/bridge-mappings/" + url.PathEscape(physnet), RawQuery: "target=" + target}
+	err := c.Query(queryCtx, "PUT", types.APIVersion, endpoint, types.BridgeMapping{Physnet: physnet, Bridge: bridge}, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to map physical network '%s': %w", physnet, err)
+	}
+
+	return response, nil
+}
+
+// RemoveBridgeMapping sends request to remove mapping of physical network "physnet" from the "target"
+// member.
+func RemoveBridgeMapping(ctx context.Context, c microTypes.Client, physnet string, target string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	endpoint := &url.URL{Path: "networks/bridge-mappings/" + url.PathEscape(physnet), RawQuery: "target=" + target}
+	err := c.Query(queryCtx, "DELETE", types.APIVersion, endpoint, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove mapping of physical network '%s': %w", physnet, err)
+	}
+
+	return nil
+}
+
+// GetChassisGateways queries MicroOVN cluster for gateway roles of cluster members.
+func GetChassisGateways(ctx context.Context, c microTypes.Client) (types.ChassisGateways, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	gateways := types.ChassisGateways{}
+	err := c.Query(queryCtx, "GET", types.APIVersion, &url.URL{Path: "chassis/gateways"}, nil, &gateways)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list gateway chassis: %w", err)
+	}
+
+	return gateways, nil
+}
+
+// EnableChassisGateway sends request to mark chassis of the "target" member as a gateway, with options
+// set in "gateway".
+func EnableChassisGateway(ctx context.Context, c microTypes.Client, gateway types.ChassisGateway, target string) (types.ChassisGateway, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.ChassisGateway{}
+	err := c.Query(queryCtx, "PUT", types.APIVersion, &url.URL{Path: "chassis/gateway", RawQuery: "target=" + target}, gateway, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to enable gateway chassis: %w", err)
+	}
+
+	return response, nil
+}
+
+// DisableChassisGateway sends request to stop marking chassis of the "target" member as a gateway.
+func DisableChassisGateway(ctx context.Context, c microTypes.Client, target string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "DELETE", types.APIVersion, &url.URL{Path: "chassis/gateway", RawQuery: "target=" + target}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to disable gateway chassis: %w", err)
+	}
+
+	return nil
+}
+
+// GetChassisEncap queries tunnel encapsulation of the chassis of the "target" member.
+func GetChassisEncap(ctx context.Context, c microTypes.Client, target string) (types.ChassisEncap, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	encap := types.ChassisEncap{}
+	err := c.Query(queryCtx, "GET", types.APIVersion, &url.URL{Path: "chassis/encap", RawQuery: "target=" + target}, nil, &encap)
+	if err != nil {
+		return encap, fmt.Errorf("failed to get chassis encapsulation: %w", err)
+	}
+
+	return encap, nil
+}
+
+// SetChassisEncap sends request to change tunnel encapsulation of the chassis of the "target" member to
+// options set in "encap".
+func SetChassisEncap(ctx context.Context, c microTypes.Client, encap types.ChassisEncap, target string) (types.ChassisEncap, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.ChassisEncap{}
+	err := c.Query(queryCtx, "PUT", types.APIVersion, &url.URL{Path: "chassis/encap", RawQuery: "target=" + target}, encap, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to set chassis encapsulation: %w", err)
+	}
+
+	return response, nil
+}
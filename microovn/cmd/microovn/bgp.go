@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdBgp struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn bgp" subcommand
+func (c *cmdBgp) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bgp",
+		Short: "Manage BGP integration",
+	}
+
+	bgpStatusCmd := cmdBgpStatus{common: c.common, bgp: c}
+	cmd.AddCommand(bgpStatusCmd.Command())
+
+	bgpShowCmd := cmdBgpShow{common: c.common, bgp: c}
+	cmd.AddCommand(bgpShowCmd.Command())
+
+	bgpSetCmd := cmdBgpSet{common: c.common, bgp: c}
+	cmd.AddCommand(bgpSetCmd.Command())
+
+	bgpRouterCmd := cmdBgpRouter{common: c.common, bgp: c}
+	cmd.AddCommand(bgpRouterCmd.Command())
+
+	return cmd
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdBgpRouter struct {
+	common *CmdControl
+	bgp    *cmdBgp
+}
+
+// Command returns definition for "microovn bgp router" subcommand
+func (c *cmdBgpRouter) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "router",
+		Short: "Manage route advertisement of user logical routers",
+	}
+
+	routerAddCmd := cmdBgpRouterAdd{common: c.common, router: c}
+	cmd.AddCommand(routerAddCmd.Command())
+
+	routerRemoveCmd := cmdBgpRouterRemove{common: c.common, router: c}
+	cmd.AddCommand(routerRemoveCmd.Command())
+
+	routerListCmd := cmdBgpRouterList{common: c.common, router: c}
+	cmd.AddCommand(routerListCmd.Command())
+
+	return cmd
+}
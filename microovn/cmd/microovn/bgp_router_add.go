@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdBgpRouterAdd struct {
+	common *CmdControl
+	router *cmdBgpRouter
+
+	flagRedistribute []string
+	nodeName         string
+}
+
+// Command returns definition for "microovn bgp router add" subcommand
+func (c *cmdBgpRouterAdd) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <ROUTER>",
+		Short: "Advertise routes of a logical router through BGP",
+		Long: "Advertise routes of an OVN logical router, created for example by a CMS, to BGP\n" +
+			"peers of a cluster member. OVN redistributes routes of the selected types into\n" +
+			"the member's VRF table on the chassis where the router has its gateway port bound.\n\n" +
+			"Supported route types are: connected, static, nat and lb. Running the command\n" +
+			"again for the same router changes the set of advertised route types.",
+		Args: cobra.ExactArgs(1),
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringSliceVar(&c.flagRedistribute, "redistribute", types.BgpDefaultRedistribute, "Comma-separated list of route types to advertise")
+	cmd.Flags().StringVar(&c.nodeName, "node", "", "Optional name of the node to target")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn bgp router add" subcommand
+func (c *cmdBgpRouterAdd) Run(_ *cobra.Command, args []string) error {
+	err := types.ValidateRedistribute(c.flagRedistribute)
+	if err != nil {
+		return err
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	router, err := client.AdvertiseBgpRouter(context.Background(), cli, args[0], c.flagRedistribute, c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Routes of logical router '%s' are advertised by member '%s': %s\n",
+		router.Name, router.Member, strings.Join(router.Redistribute, ","))
+	return nil
+}
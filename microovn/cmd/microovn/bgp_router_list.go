@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdBgpRouterList struct {
+	common *CmdControl
+	router *cmdBgpRouter
+
+	flagFormat string
+}
+
+// Command returns definition for "microovn bgp router list" subcommand
+func (c *cmdBgpRouterList) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List logical routers whose routes are advertised through BGP",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn bgp router list" subcommand
+func (c *cmdBgpRouterList) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	routers, err := client.GetBgpRouters(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, router := range routers {
+		data = append(data, []string{router.Name, router.Member, strings.Join(router.Redistribute, ",")})
+	}
+
+	header := []string{"ROUTER", "MEMBER", "REDISTRIBUTE"}
+	return lxdCmd.RenderTable(c.flagFormat, header, data, routers)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdBgpRouterRemove struct {
+	common *CmdControl
+	router *cmdBgpRouter
+}
+
+// Command returns definition for "microovn bgp router remove" subcommand
+func (c *cmdBgpRouterRemove) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <ROUTER>",
+		Short: "Stop advertising routes of a logical router through BGP",
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn bgp router remove" subcommand
+func (c *cmdBgpRouterRemove) Run(_ *cobra.Command, args []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.WithdrawBgpRouter(context.Background(), cli, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Routes of logical router '%s' are no longer advertised\n", args[0])
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	microTypes "github.com/canonical/microcluster/v3/microcluster/types"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdBgpSet struct {
+	common *CmdControl
+	bgp    *cmdBgp
+
+	nodeName string
+	dryRun   bool
+}
+
+// Command returns definition for "microovn bgp set" subcommand
+func (c *cmdBgpSet) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <KEY>=<VALUE>...",
+		Short: "Change BGP configuration without disabling the service",
+		Long: "Change BGP configuration of a cluster member without disabling the 'bgp' service.\n" +
+			"Accepts the same options as 'microovn enable bgp --config'. Options that are not\n" +
+			"specified keep their current value. Only external connections that are added,\n" +
+			"removed or changed are reconfigured, other BGP sessions are not interrupted.\n\n" +
+			"Per-connection options are set with '<iface_name>.<option>' keys and cleared by\n" +
+			"setting an empty value, e.g. 'eth1.password='. Supported options are: neighbor,\n" +
+			"peer_asn, password, auth, hold_time, keepalive_time, bfd_min_rx, bfd_min_tx and\n" +
+			"bfd_multiplier.\n\n" +
+			"Route policy options (import_allow, import_deny, export_allow, export_deny,\n" +
+			"accept_default_route, export_communities, export_local_pref and\n" +
+			"export_as_path_prepend), graceful restart options (graceful_restart,\n" +
+			"graceful_restart_time, long_lived_graceful_restart and long_lived_stale_time)\n" +
+			"and EVPN options (evpn, evpn_vni, evpn_vtep, evpn_import_rt and evpn_export_rt)\n" +
+			"can be cleared in the same way.\n\n" +
+			"With '--dry-run', changes of the network configuration (e.g. netplan files) that\n" +
+			"the update would make are shown, without applying them.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.nodeName, "node", "", "Optional name of the node to target")
+	cmd.Flags().BoolVar(&c.dryRun, "dry-run", false, "Only show changes of the network configuration, do not apply them")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn bgp set" subcommand
+func (c *cmdBgpSet) Run(_ *cobra.Command, args []string) error {
+	rawConfig := make(map[string]string)
+	var unset []string
+	for _, configString := range args {
+		key, value, found := strings.Cut(configString, "=")
+		if !found {
+			return fmt.Errorf("configuration '%s' does not conform to the 'key=value' format", configString)
+		}
+		_, exists := rawConfig[key]
+		if exists || slices.Contains(unset, key) {
+			return fmt.Errorf("configuration '%s' already set", key)
+		}
+		if value == "" {
+			unset = append(unset, key)
+			continue
+		}
+		rawConfig[key] = value
+	}
+
+	bgpConfig := types.ExtraBgpConfig{}
+	err := bgpConfig.SetFromMap(rawConfig)
+	if err != nil {
+		return err
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		return c.preview(cli, bgpConfig, unset)
+	}
+
+	applied, err := client.UpdateBgpConfig(context.Background(), cli, bgpConfig, unset, c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("BGP configuration updated")
+	fmt.Printf("\tExternal connections: %s\n", applied.ExternalConnection)
+	fmt.Printf("\tVRF table: %s\n", applied.Vrf)
+	if applied.ManualBgpdConfig {
+		fmt.Println("\tASN: managed manually")
+	} else {
+		fmt.Printf("\tASN: %s\n", applied.Asn)
+	}
+
+	return nil
+}
+
+// preview prints changes of the network configuration that an update of BGP configuration with "bgpConfig"
+// and "unset" options would make, without applying it.
+func (c *cmdBgpSet) preview(cli microTypes.Client, bgpConfig types.ExtraBgpConfig, unset []string) error {
+	preview, err := client.PreviewBgpConfig(context.Background(), cli, bgpConfig, unset, c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	if len(preview.Network) == 0 {
+		fmt.Printf("No changes of network configuration on member '%s'\n", preview.Member)
+		return nil
+	}
+
+	for _, change := range preview.Network {
+		fmt.Printf("Changes of network configuration '%s' on member '%s':\n", change.Name, preview.Member)
+		fmt.Print(change.Diff)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdBgpShow struct {
+	common *CmdControl
+	bgp    *cmdBgp
+
+	flagFormat string
+}
+
+// Command returns definition for "microovn bgp show" subcommand
+func (c *cmdBgpShow) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show BGP configuration of cluster members",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn bgp show" subcommand
+func (c *cmdBgpShow) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	configs, err := client.GetBgpConfigs(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
+	if len(configs) == 0 {
+		fmt.Println("No cluster member has 'bgp' service enabled.")
+		return nil
+	}
+
+	data := [][]string{}
+	for _, memberConfig := range configs {
+		config := memberConfig.Config
+		if config == nil {
+			data = append(data, []string{memberConfig.Member, "unknown", "", "", ""})
+			continue
+		}
+
+		asn := config.Asn
+		if config.ManualBgpdConfig {
+			asn = "manual"
+		}
+		data = append(data, []string{memberConfig.Member, config.ExternalConnection, config.Vrf, asn, memberConfig.RouterID})
+	}
+
+	header := []string{"MEMBER", "EXTERNAL CONNECTIONS", "VRF", "ASN", "ROUTER ID"}
+	return lxdCmd.RenderTable(c.flagFormat, header, data, configs)
+}
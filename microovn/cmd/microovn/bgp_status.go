@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdBgpStatus struct {
+	common *CmdControl
+	bgp    *cmdBgp
+
+	flagFormat string
+}
+
+// Command returns definition for "microovn bgp status" subcommand
+func (c *cmdBgpStatus) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show state of BGP sessions and learned routes",
+		Long: "Show state of BGP sessions and routes learned into the VRF table on each\n" +
+			"cluster member that has 'bgp' service enabled.",
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn bgp status" subcommand
+func (c *cmdBgpStatus) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	status, err := client.GetBgpStatus(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
+	if len(status) == 0 {
+		fmt.Println("No cluster member has 'bgp' service enabled.")
+		return nil
+	}
+
+	// Structured formats contain complete status, including routes and errors
+	if c.flagFormat == "json" || c.flagFormat == "yaml" {
+		return lxdCmd.RenderTable(c.flagFormat, nil, nil, status)
+	}
+
+	sessions := [][]string{}
+	routes := [][]string{}
+	failed := false
+	for _, member := range status {
+		if member.Error != "" {
+			failed = true
+			sessions = append(sessions, []string{member.Member, "", "error", "", member.Error, "", ""})
+		}
+
+		for _, protocol := range member.Protocols {
+			sessions = append(sessions, []string{
+				member.Member,
+				protocol.Name,
+				protocol.State,
+				protocol.Since,
+				protocol.Info,
+				strconv.Itoa(protocol.ImportedRoutes),
+				strconv.Itoa(protocol.ExportedRoutes),
+			})
+		}
+
+		for _, route := range member.Routes {
+			routes = append(routes, []string{member.Member, route.Prefix, route.Gateway, route.Interface})
+		}
+	}
+
+	header := []string{"MEMBER", "PROTOCOL", "STATE", "SINCE", "INFO", "IMPORTED", "EXPORTED"}
+	err = lxdCmd.RenderTable(c.flagFormat, header, sessions, status)
+	if err != nil {
+		return err
+	}
+
+	if len(routes) != 0 {
+		fmt.Println()
+		header = []string{"MEMBER", "PREFIX", "GATEWAY", "INTERFACE"}
+		err = lxdCmd.RenderTable(c.flagFormat, header, routes, status)
+		if err != nil {
+			return err
+		}
+	}
+
+	if failed {
+		return errors.New("failed to get BGP status from some cluster members")
+	}
+
+	return nil
+}
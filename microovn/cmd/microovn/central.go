@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdCentral struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn central" subcommand
+func (c *cmdCentral) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "central",
+		Short: "Manage OVN central services",
+	}
+
+	centralMigrateInCmd := cmdCentralMigrateIn{common: c.common, central: c}
+	cmd.AddCommand(centralMigrateInCmd.Command())
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdCentralMigrateIn struct {
+	common      *CmdControl
+	central     *cmdCentral
+	flagFrom    string
+	flagMembers []string
+}
+
+// Command returns definition for "microovn central migrate-in" subcommand
+func (c *cmdCentralMigrateIn) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-in --from <NB_REMOTE>,<SB_REMOTE>",
+		Short: "Migrate OVN central services from an external cluster into MicroOVN",
+		Long: "Migrate OVN central services from an external OVN central cluster, configured via the " +
+			"'ovn.central-ips' option, into MicroOVN. Contents of the external Northbound database are " +
+			"used to seed the 'central' service on the first of the selected members, the 'central' " +
+			"service is then enabled on the rest of them. Chassis are switched to the new OVN central " +
+			"and the 'ovn.central-ips' option is removed.",
+		Example: "  microovn central migrate-in --from ssl:10.0.0.1:6641,ssl:10.0.0.1:6642 --members node1,node2,node3",
+		Args:    cobra.NoArgs,
+		RunE:    c.Run,
+	}
+
+	cmd.Flags().StringVar(
+		&c.flagFrom,
+		"from",
+		"",
+		"Comma-separated connection strings of the external Northbound and Southbound databases",
+	)
+	cmd.Flags().StringSliceVar(
+		&c.flagMembers,
+		"members",
+		[]string{},
+		"Comma-separated names of members that will run the 'central' service (default: local member)",
+	)
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn central migrate-in" subcommand
+func (c *cmdCentralMigrateIn) Run(_ *cobra.Command, _ []string) error {
+	remotes := strings.Split(c.flagFrom, ",")
+	if len(remotes) != 2 || remotes[0] == "" || remotes[1] == "" {
+		return fmt.Errorf("option '--from' must be in format '<NB_REMOTE>,<SB_REMOTE>': %s", c.flagFrom)
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	// The first selected member is seeded with the data from the external cluster. Empty
+	// member name targets the local member.
+	members := c.flagMembers
+	if len(members) == 0 {
+		members = []string{""}
+	}
+
+	ws, regenEnv, err := client.MigrateInCentral(context.Background(), cli, remotes[0], remotes[1], members[0])
+	if err != nil {
+		return err
+	}
+	fmt.Println("OVN central migrated from the external cluster")
+	if c.common.FlagLogVerbose {
+		regenEnv.PrettyPrint()
+	}
+
+	for _, member := range members[1:] {
+		ws, regenEnv, err = client.EnableService(context.Background(), cli, types.SrvCentral, &types.ExtraServiceConfig{}, member)
+		if err != nil {
+			return fmt.Errorf("failed to enable 'central' on member '%s': %w", member, err)
+		}
+		fmt.Printf("Service %s enabled on %s\n", types.SrvCentral, member)
+		if c.common.FlagLogVerbose {
+			regenEnv.PrettyPrint()
+		}
+	}
+
+	ws.PrettyPrint(c.common.FlagLogVerbose)
+	return nil
+}
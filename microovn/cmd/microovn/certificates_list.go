@@ -58,6 +58,7 @@ type ovnCertificatePaths struct {
 	Ca      *caCertInfo `json:"ca"`
 	Nb      *certBundle `json:"ovnnb"`
 	Sb      *certBundle `json:"ovnsb"`
+	Relay   *certBundle `json:"ovnsb-relay"`
 	Northd  *certBundle `json:"ovn-northd"`
 	Chassis *certBundle `json:"ovn-controller"`
 	Client  *certBundle `json:"client"`
@@ -169,6 +170,12 @@ func populateExpectedCertificates(expectedCertificates *ovnCertificatePaths, ser
 			expectedCertificates.Northd = &certBundle{northdCert, northdKey, northdCertExpDate.String()}
 		}
 
+		if srv.Service == types.SrvRelay {
+			relayCert, relayKey := paths.PkiOvnSbRelayCertFiles()
+			relayCertExpDate, _, _ := certExpDate(relayCert)
+			expectedCertificates.Relay = &certBundle{relayCert, relayKey, relayCertExpDate.String()}
+		}
+
 		if srv.Service == types.SrvChassis {
 			ctlCert, ctlKey := paths.PkiOvnControllerCertFiles()
 			ctlCertExpDate, _, _ := certExpDate(ctlCert)
@@ -194,6 +201,9 @@ func printOvnCertStatus(certificates *ovnCertificatePaths) {
 	fmt.Println("\n[OVN Southbound Database]")
 	printCert(certificates.Sb)
 
+	fmt.Println("\n[OVN Southbound Relay]")
+	printCert(certificates.Relay)
+
 	fmt.Println("\n[OVN Northd Service]")
 	printCert(certificates.Northd)
 
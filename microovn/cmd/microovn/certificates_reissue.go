@@ -16,6 +16,7 @@ var validCertificates = []string{
 	"client",
 	"ovnnb",
 	"ovnsb",
+	"ovnsb-relay",
 	"ovn-controller",
 	"ovn-northd",
 	"all",
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdChassis struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn chassis" subcommand
+func (c *cmdChassis) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chassis",
+		Short: "Manage OVN chassis configuration",
+	}
+
+	chassisGatewayCmd := cmdChassisGateway{common: c.common, chassis: c}
+	cmd.AddCommand(chassisGatewayCmd.Command())
+
+	chassisEncapCmd := cmdChassisEncap{common: c.common, chassis: c}
+	cmd.AddCommand(chassisEncapCmd.Command())
+
+	return cmd
+}
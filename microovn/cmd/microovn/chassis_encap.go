@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdChassisEncap struct {
+	common  *CmdControl
+	chassis *cmdChassis
+}
+
+// Command returns definition for "microovn chassis encap" subcommand
+func (c *cmdChassisEncap) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encap",
+		Short: "Manage tunnel encapsulation of the chassis",
+	}
+
+	encapShowCmd := cmdChassisEncapShow{common: c.common, encap: c}
+	cmd.AddCommand(encapShowCmd.Command())
+
+	encapSetCmd := cmdChassisEncapSet{common: c.common, encap: c}
+	cmd.AddCommand(encapSetCmd.Command())
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdChassisEncapSet struct {
+	common *CmdControl
+	encap  *cmdChassisEncap
+
+	flagType string
+	flagIPs  []string
+	nodeName string
+}
+
+// Command returns definition for "microovn chassis encap set" subcommand
+func (c *cmdChassisEncapSet) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Change tunnel encapsulation of the chassis",
+		Long: "Change tunnel encapsulation type and/or IPs of the chassis of a cluster member, set as\n" +
+			"\"ovn-encap-type\" and \"ovn-encap-ip\" in the local Open vSwitch. Encapsulation IPs must be\n" +
+			"assigned to interfaces of the member. Options that are not specified are left unchanged.\n\n" +
+			"Tunnels to other chassis are re-established with the new encapsulation, so the overlay\n" +
+			"traffic of the member is briefly interrupted.",
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.flagType, "type", "", fmt.Sprintf("Encapsulation type ('%s' or '%s')", types.EncapTypeGeneve, types.EncapTypeVxlan))
+	cmd.Flags().StringSliceVar(&c.flagIPs, "ip", nil, "Comma-separated list of encapsulation IPs")
+	cmd.Flags().StringVar(&c.nodeName, "node", "", "Optional name of the node to target")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn chassis encap set" subcommand
+func (c *cmdChassisEncapSet) Run(_ *cobra.Command, _ []string) error {
+	encap := types.ChassisEncap{Type: c.flagType, IPs: c.flagIPs}
+	err := encap.Validate()
+	if err != nil {
+		return err
+	}
+
+	if encap.Type == "" && len(encap.IPs) == 0 {
+		return errors.New("at least one of '--type' or '--ip' options is required")
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	encap, err = client.SetChassisEncap(context.Background(), cli, encap, c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Chassis of member '%s' uses '%s' encapsulation with IPs: %s\n", encap.Member, encap.Type, strings.Join(encap.IPs, ", "))
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdChassisEncapShow struct {
+	common *CmdControl
+	encap  *cmdChassisEncap
+
+	nodeName string
+}
+
+// Command returns definition for "microovn chassis encap show" subcommand
+func (c *cmdChassisEncapShow) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show tunnel encapsulation of the chassis",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.nodeName, "node", "", "Optional name of the node to target")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn chassis encap show" subcommand
+func (c *cmdChassisEncapShow) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	encap, err := client.GetChassisEncap(context.Background(), cli, c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Member: %s\n", encap.Member)
+	fmt.Printf("Encapsulation type: %s\n", encap.Type)
+	fmt.Printf("Encapsulation IPs: %s\n", strings.Join(encap.IPs, ", "))
+	return nil
+}
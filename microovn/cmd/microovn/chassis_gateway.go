@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdChassisGateway struct {
+	common  *CmdControl
+	chassis *cmdChassis
+}
+
+// Command returns definition for "microovn chassis gateway" subcommand
+func (c *cmdChassisGateway) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gateway",
+		Short: "Manage gateway role of the chassis",
+	}
+
+	gatewayEnableCmd := cmdChassisGatewayEnable{common: c.common, gateway: c}
+	cmd.AddCommand(gatewayEnableCmd.Command())
+
+	gatewayDisableCmd := cmdChassisGatewayDisable{common: c.common, gateway: c}
+	cmd.AddCommand(gatewayDisableCmd.Command())
+
+	return cmd
+}
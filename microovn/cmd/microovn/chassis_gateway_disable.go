@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdChassisGatewayDisable struct {
+	common  *CmdControl
+	gateway *cmdChassisGateway
+
+	nodeName string
+}
+
+// Command returns definition for "microovn chassis gateway disable" subcommand
+func (c *cmdChassisGatewayDisable) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "disable",
+		Short: "Stop marking the chassis as a gateway",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.nodeName, "node", "", "Optional name of the node to target")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn chassis gateway disable" subcommand
+func (c *cmdChassisGatewayDisable) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.DisableChassisGateway(context.Background(), cli, c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Chassis is no longer a gateway")
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdChassisGatewayEnable struct {
+	common  *CmdControl
+	gateway *cmdChassisGateway
+
+	flagPriority          string
+	flagAvailabilityZones []string
+	nodeName              string
+}
+
+// Command returns definition for "microovn chassis gateway enable" subcommand
+func (c *cmdChassisGatewayEnable) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Mark the chassis as a gateway",
+		Long: "Mark the chassis of a cluster member as a gateway by setting \"enable-chassis-as-gw\"\n" +
+			"in ovn-cms-options. CMSes schedule gateway router ports only on chassis marked this way.\n" +
+			"Optional priority and availability zones are announced in ovn-cms-options as well.\n\n" +
+			"Running the command again on a gateway chassis replaces its options.",
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.flagPriority, "priority", "", "Optional gateway priority (0-32767)")
+	cmd.Flags().StringSliceVar(&c.flagAvailabilityZones, "availability-zones", nil, "Optional comma-separated list of availability zones")
+	cmd.Flags().StringVar(&c.nodeName, "node", "", "Optional name of the node to target")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn chassis gateway enable" subcommand
+func (c *cmdChassisGatewayEnable) Run(_ *cobra.Command, _ []string) error {
+	gateway := types.ChassisGateway{Priority: c.flagPriority, AvailabilityZones: c.flagAvailabilityZones}
+	err := gateway.Validate()
+	if err != nil {
+		return err
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	gateway, err = client.EnableChassisGateway(context.Background(), cli, gateway, c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Chassis of member '%s' is a gateway\n", gateway.Member)
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdDatabase struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn database" subcommand
+func (c *cmdDatabase) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "database",
+		Short: "Manage OVN central databases",
+	}
+
+	databaseRepairMembershipCmd := cmdDatabaseRepairMembership{common: c.common, database: c}
+	cmd.AddCommand(databaseRepairMembershipCmd.Command())
+
+	databaseRecoverCmd := cmdDatabaseRecover{common: c.common, database: c}
+	cmd.AddCommand(databaseRecoverCmd.Command())
+
+	databaseUpgradeCmd := cmdDatabaseUpgrade{common: c.common, database: c}
+	cmd.AddCommand(databaseUpgradeCmd.Command())
+
+	return cmd
+}
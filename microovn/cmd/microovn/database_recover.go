@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdDatabaseRecover struct {
+	common    *CmdControl
+	database  *cmdDatabase
+	flagFrom  string
+	flagForce bool
+}
+
+// Command returns definition for "microovn database recover" subcommand
+func (c *cmdDatabaseRecover) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover --from <MEMBER>",
+		Short: "Recover OVN Northbound and Southbound databases after a permanent loss of quorum",
+		Long: "Recover OVN Northbound and Southbound databases from the selected member after a majority " +
+			"of members with the 'central' service was permanently lost. Databases on the selected member " +
+			"are converted into new single-member clusters and the 'central' service is disabled on every " +
+			"other member. Any changes that were not replicated to the selected member are lost.",
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.flagFrom, "from", "", "Name of the member from which the databases are recovered")
+	cmd.Flags().BoolVar(&c.flagForce, "force", false, "Recover even if the database clusters still have a leader")
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn database recover" subcommand
+func (c *cmdDatabaseRecover) Run(_ *cobra.Command, _ []string) error {
+	if c.flagFrom == "" {
+		return errors.New("name of the member must not be empty")
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	response, regenEnv, err := client.RecoverDatabase(context.Background(), cli, c.flagForce, c.flagFrom)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("OVN central databases recovered from member '%s'\n", c.flagFrom)
+	if len(response.RemovedMembers) != 0 {
+		fmt.Printf("Service central disabled on: %s\n", strings.Join(response.RemovedMembers, ", "))
+	}
+	if c.common.FlagLogVerbose || len(regenEnv.Errors) != 0 {
+		regenEnv.PrettyPrint()
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdDatabaseRepairMembership struct {
+	common     *CmdControl
+	database   *cmdDatabase
+	flagDryRun bool
+	flagFormat string
+}
+
+// Command returns definition for "microovn database repair-membership" subcommand
+func (c *cmdDatabaseRepairMembership) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repair-membership",
+		Short: "Kick stale servers from OVN Northbound and Southbound database clusters",
+		Long: "Compare servers in RAFT clusters of OVN Northbound and Southbound databases with MicroOVN " +
+			"cluster members that have the 'central' service enabled. Servers that do not belong to any " +
+			"such member (e.g. left behind by a member that was forcefully removed while offline) are " +
+			"kicked from the RAFT cluster. When executed on the RAFT leader, servers that did not respond " +
+			"for over an hour are considered dead and kicked as well.",
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, "Only report stale servers, do not kick them")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn database repair-membership" subcommand
+func (c *cmdDatabaseRepairMembership) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	var report types.RaftMembershipReport
+	if c.flagDryRun {
+		report, err = client.GetRaftMembership(context.Background(), cli)
+	} else {
+		report, err = client.RepairRaftMembership(context.Background(), cli)
+	}
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	failed := false
+	for _, result := range report {
+		if result.Error != "" {
+			failed = true
+			data = append(data, []string{result.Database, "", "", "", "error", result.Error})
+			continue
+		}
+
+		for _, srv := range result.Servers {
+			status := "ok"
+			switch {
+			case srv.Kicked:
+				status = "kicked"
+			case srv.Error != "":
+				status = "error"
+				failed = true
+			case srv.Dead:
+				status = "dead"
+			case srv.Stale:
+				status = "stale"
+			}
+			data = append(data, []string{result.Database, srv.ServerID, srv.Address, srv.Member, status, srv.Error})
+		}
+	}
+
+	header := []string{"DATABASE", "SERVER ID", "ADDRESS", "MEMBER", "STATUS", "ERROR"}
+	err = lxdCmd.RenderTable(c.flagFormat, header, data, report)
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errors.New("failed to check or repair membership of some databases")
+	}
+
+	if c.flagDryRun {
+		fmt.Println("Dry run, no servers were kicked.")
+	}
+	return nil
+}
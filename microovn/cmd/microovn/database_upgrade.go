@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdDatabaseUpgrade struct {
+	common   *CmdControl
+	database *cmdDatabase
+}
+
+// Command returns definition for "microovn database upgrade" subcommand
+func (c *cmdDatabaseUpgrade) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Manage schema upgrades of OVN central databases",
+	}
+
+	upgradeStatusCmd := cmdDatabaseUpgradeStatus{common: c.common, upgrade: c}
+	cmd.AddCommand(upgradeStatusCmd.Command())
+
+	upgradeHoldCmd := cmdDatabaseUpgradeHold{common: c.common, upgrade: c}
+	cmd.AddCommand(upgradeHoldCmd.Command())
+
+	upgradeTriggerCmd := cmdDatabaseUpgradeTrigger{common: c.common, upgrade: c}
+	cmd.AddCommand(upgradeTriggerCmd.Command())
+
+	return cmd
+}
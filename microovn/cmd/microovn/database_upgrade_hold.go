@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdDatabaseUpgradeHold struct {
+	common      *CmdControl
+	upgrade     *cmdDatabaseUpgrade
+	flagRelease bool
+}
+
+// Command returns definition for "microovn database upgrade hold" subcommand
+func (c *cmdDatabaseUpgradeHold) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hold",
+		Short: "Put automatic schema upgrades of OVN central databases on hold",
+		Long: "Put automatic schema upgrades of OVN central databases on hold. While on hold, schema " +
+			"upgrades are performed only when triggered with 'microovn database upgrade trigger'.",
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().BoolVar(&c.flagRelease, "release", false, "Release the hold and resume automatic schema upgrades")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn database upgrade hold" subcommand
+func (c *cmdDatabaseUpgradeHold) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.SetOvsdbUpgradeHold(context.Background(), cli, !c.flagRelease)
+	if err != nil {
+		return err
+	}
+
+	if c.flagRelease {
+		fmt.Println("Automatic schema upgrades resumed")
+	} else {
+		fmt.Println("Automatic schema upgrades put on hold")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdDatabaseUpgradeStatus struct {
+	common  *CmdControl
+	upgrade *cmdDatabaseUpgrade
+}
+
+// Command returns definition for "microovn database upgrade status" subcommand
+func (c *cmdDatabaseUpgradeStatus) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show schema upgrade status of OVN central databases",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+	return cmd
+}
+
+// Run method is an implementation of the "microovn database upgrade status" subcommand
+func (c *cmdDatabaseUpgradeStatus) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	status, err := client.GetOvsdbUpgradeStatus(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
+	if status.Hold {
+		fmt.Println("Automatic upgrades: on hold")
+	} else {
+		fmt.Println("Automatic upgrades: enabled")
+	}
+
+	for _, db := range status.Databases {
+		fmt.Printf("\n%s:\n", db.Database)
+		if db.Error != "" {
+			fmt.Printf("\tError: %s\n", db.Error)
+		}
+		if db.ActiveVersion == "" {
+			continue
+		}
+
+		fmt.Printf("\tActive schema: %s\n", db.ActiveVersion)
+		fmt.Printf("\tTarget schema: %s\n", db.TargetVersion)
+		fmt.Printf("\tUpgrade required: %t\n", db.UpgradeRequired)
+
+		if len(db.Blockers) == 0 {
+			continue
+		}
+		fmt.Println("\tMembers not ready for upgrade:")
+		for _, member := range db.Blockers {
+			switch member.Error {
+			case types.OvsdbSchemaFetchErrorNone:
+				fmt.Printf("\t\t%s: expects schema %s\n", member.Host, member.SchemaVersion)
+			case types.OvsdbSchemaFetchErrorNotSupported:
+				fmt.Printf("\t\t%s: missing API endpoint, MicroOVN likely needs upgrade\n", member.Host)
+			default:
+				fmt.Printf("\t\t%s: failed to get expected schema version\n", member.Host)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdDatabaseUpgradeTrigger struct {
+	common    *CmdControl
+	upgrade   *cmdDatabaseUpgrade
+	flagForce bool
+}
+
+// Command returns definition for "microovn database upgrade trigger" subcommand
+func (c *cmdDatabaseUpgradeTrigger) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trigger",
+		Short: "Upgrade schemas of OVN central databases now",
+		Long: "Upgrade schemas of OVN central databases now, regardless of whether automatic upgrades " +
+			"are on hold. A snapshot of each database is taken before its schema is converted.",
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().BoolVar(&c.flagForce, "force", false, "Upgrade even if some members are not ready for the upgrade")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn database upgrade trigger" subcommand
+func (c *cmdDatabaseUpgradeTrigger) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	report, err := client.TriggerOvsdbUpgrade(context.Background(), cli, c.flagForce)
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	for _, result := range report {
+		switch {
+		case result.Error != "":
+			failed = true
+			fmt.Printf("%s: upgrade failed: %s\n", result.Database, result.Error)
+		case result.Upgraded:
+			fmt.Printf("%s: upgraded from %s to %s (snapshot: %s)\n", result.Database, result.PreviousVersion, result.TargetVersion, result.Snapshot)
+		default:
+			fmt.Printf("%s: already at schema %s\n", result.Database, result.TargetVersion)
+		}
+	}
+
+	if failed {
+		return errors.New("failed to upgrade some of the databases, see 'microovn database upgrade status'")
+	}
+	return nil
+}
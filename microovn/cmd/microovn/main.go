@@ -78,6 +78,21 @@ func main() {
 	var cmdConfig = cmdConfig{common: &commonCmd}
 	app.AddCommand(cmdConfig.Command())
 
+	var cmdDatabase = cmdDatabase{common: &commonCmd}
+	app.AddCommand(cmdDatabase.Command())
+
+	var cmdCentral = cmdCentral{common: &commonCmd}
+	app.AddCommand(cmdCentral.Command())
+
+	var cmdBgp = cmdBgp{common: &commonCmd}
+	app.AddCommand(cmdBgp.Command())
+
+	var cmdNetwork = cmdNetwork{common: &commonCmd}
+	app.AddCommand(cmdNetwork.Command())
+
+	var cmdChassis = cmdChassis{common: &commonCmd}
+	app.AddCommand(cmdChassis.Command())
+
 	app.InitDefaultHelpCmd()
 
 	err := app.Execute()
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdNetwork struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn network" subcommand
+func (c *cmdNetwork) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Manage networks provided by MicroOVN",
+	}
+
+	networkExternalCmd := cmdNetworkExternal{common: c.common, network: c}
+	cmd.AddCommand(networkExternalCmd.Command())
+
+	networkBridgeMappingCmd := cmdNetworkBridgeMapping{common: c.common, network: c}
+	cmd.AddCommand(networkBridgeMappingCmd.Command())
+
+	return cmd
+}
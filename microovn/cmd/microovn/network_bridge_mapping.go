@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdNetworkBridgeMapping struct {
+	common  *CmdControl
+	network *cmdNetwork
+}
+
+// Command returns definition for "microovn network bridge-mapping" subcommand
+func (c *cmdNetworkBridgeMapping) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bridge-mapping",
+		Short: "Manage ovn-bridge-mappings of cluster members",
+	}
+
+	mappingAddCmd := cmdNetworkBridgeMappingAdd{common: c.common, mapping: c}
+	cmd.AddCommand(mappingAddCmd.Command())
+
+	mappingRemoveCmd := cmdNetworkBridgeMappingRemove{common: c.common, mapping: c}
+	cmd.AddCommand(mappingRemoveCmd.Command())
+
+	mappingListCmd := cmdNetworkBridgeMappingList{common: c.common, mapping: c}
+	cmd.AddCommand(mappingListCmd.Command())
+
+	return cmd
+}
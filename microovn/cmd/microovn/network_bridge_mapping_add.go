@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdNetworkBridgeMappingAdd struct {
+	common  *CmdControl
+	mapping *cmdNetworkBridgeMapping
+
+	nodeName string
+}
+
+// Command returns definition for "microovn network bridge-mapping add" subcommand
+func (c *cmdNetworkBridgeMappingAdd) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <PHYSNET> <BRIDGE>",
+		Short: "Map a physical network to an OVS bridge",
+		Long: "Map a physical network to an existing OVS bridge in ovn-bridge-mappings of a cluster\n" +
+			"member. The mapping is stored in the cluster database and re-applied when MicroOVN\n" +
+			"starts, entries added by the BGP integration or set manually are left untouched.\n\n" +
+			"Running the command again for the same physical network changes its bridge.",
+		Args: cobra.ExactArgs(2),
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.nodeName, "node", "", "Optional name of the node to target")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn network bridge-mapping add" subcommand
+func (c *cmdNetworkBridgeMappingAdd) Run(_ *cobra.Command, args []string) error {
+	err := types.BridgeMapping{Physnet: args[0], Bridge: args[1]}.Validate()
+	if err != nil {
+		return err
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	mapping, err := client.SetBridgeMapping(context.Background(), cli, args[0], args[1], c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Physical network '%s' is mapped to bridge '%s' on member '%s'\n", mapping.Physnet, mapping.Bridge, mapping.Member)
+	return nil
+}
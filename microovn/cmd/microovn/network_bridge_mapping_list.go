@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdNetworkBridgeMappingList struct {
+	common  *CmdControl
+	mapping *cmdNetworkBridgeMapping
+
+	flagFormat string
+}
+
+// Command returns definition for "microovn network bridge-mapping list" subcommand
+func (c *cmdNetworkBridgeMappingList) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List bridge mappings of all cluster members",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn network bridge-mapping list" subcommand
+func (c *cmdNetworkBridgeMappingList) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	mappings, err := client.GetBridgeMappings(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, mapping := range mappings {
+		data = append(data, []string{mapping.Member, mapping.Physnet, mapping.Bridge})
+	}
+
+	header := []string{"MEMBER", "PHYSNET", "BRIDGE"}
+	return lxdCmd.RenderTable(c.flagFormat, header, data, mappings)
+}
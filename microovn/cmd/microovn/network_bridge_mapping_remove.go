@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdNetworkBridgeMappingRemove struct {
+	common  *CmdControl
+	mapping *cmdNetworkBridgeMapping
+
+	nodeName string
+}
+
+// Command returns definition for "microovn network bridge-mapping remove" subcommand
+func (c *cmdNetworkBridgeMappingRemove) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <PHYSNET>",
+		Short: "Remove mapping of a physical network",
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.nodeName, "node", "", "Optional name of the node to target")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn network bridge-mapping remove" subcommand
+func (c *cmdNetworkBridgeMappingRemove) Run(_ *cobra.Command, args []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.RemoveBridgeMapping(context.Background(), cli, args[0], c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Physical network '%s' is no longer mapped\n", args[0])
+	return nil
+}
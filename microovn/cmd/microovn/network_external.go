@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+type cmdNetworkExternal struct {
+	common  *CmdControl
+	network *cmdNetwork
+}
+
+// Command returns definition for "microovn network external" subcommand
+func (c *cmdNetworkExternal) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "external",
+		Short: "Manage external (provider) networks",
+	}
+
+	externalAddCmd := cmdNetworkExternalAdd{common: c.common, external: c}
+	cmd.AddCommand(externalAddCmd.Command())
+
+	externalRemoveCmd := cmdNetworkExternalRemove{common: c.common, external: c}
+	cmd.AddCommand(externalRemoveCmd.Command())
+
+	externalListCmd := cmdNetworkExternalList{common: c.common, external: c}
+	cmd.AddCommand(externalListCmd.Command())
+
+	return cmd
+}
+
+// printMemberErrors prints errors of cluster members that failed to apply change of an external network.
+func printMemberErrors(memberErrors []string) {
+	if len(memberErrors) == 0 {
+		return
+	}
+
+	fmt.Println("\n[Errors]")
+	for _, errMsg := range memberErrors {
+		fmt.Println(errMsg)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdNetworkExternalAdd struct {
+	common   *CmdControl
+	external *cmdNetworkExternal
+
+	flagVlan    string
+	flagPhysnet string
+}
+
+// Command returns definition for "microovn network external add" subcommand
+func (c *cmdNetworkExternalAdd) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <INTERFACE>",
+		Short: "Connect OVN to a physical network",
+		Long: "Connect OVN to a physical network through the interface present on each cluster\n" +
+			"member with chassis service. The interface is plugged to an OVS bridge \"br-<INTERFACE>\"\n" +
+			"that is mapped to the physical network in ovn-bridge-mappings, and a logical switch\n" +
+			"\"ls-ext-<PHYSNET>\" with a localnet port is created in the OVN Northbound database.\n\n" +
+			"Members that enable chassis service later set up the network automatically.",
+		Args: cobra.ExactArgs(1),
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.flagVlan, "vlan", "", "Optional VLAN tag of the localnet port")
+	cmd.Flags().StringVar(&c.flagPhysnet, "physnet", "", "Name of the physical network (defaults to the interface name)")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn network external add" subcommand
+func (c *cmdNetworkExternalAdd) Run(_ *cobra.Command, args []string) error {
+	network := types.ExternalNetwork{Name: c.flagPhysnet, Interface: args[0], Vlan: c.flagVlan}
+	if network.Name == "" {
+		network.Name = network.Interface
+	}
+
+	err := network.Validate()
+	if err != nil {
+		return err
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.AddExternalNetwork(context.Background(), cli, network)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("External network '%s' added on interface '%s' (logical switch '%s')\n",
+		response.Network.Name, response.Network.Interface, response.Network.Switch)
+	printMemberErrors(response.Errors)
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdNetworkExternalList struct {
+	common   *CmdControl
+	external *cmdNetworkExternal
+
+	flagFormat string
+}
+
+// Command returns definition for "microovn network external list" subcommand
+func (c *cmdNetworkExternalList) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List external networks",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn network external list" subcommand
+func (c *cmdNetworkExternalList) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	networks, err := client.GetExternalNetworks(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, network := range networks {
+		data = append(data, []string{network.Name, network.Interface, network.Vlan, network.Switch})
+	}
+
+	header := []string{"PHYSNET", "INTERFACE", "VLAN", "SWITCH"}
+	return lxdCmd.RenderTable(c.flagFormat, header, data, networks)
+}
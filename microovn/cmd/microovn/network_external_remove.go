@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdNetworkExternalRemove struct {
+	common   *CmdControl
+	external *cmdNetworkExternal
+}
+
+// Command returns definition for "microovn network external remove" subcommand
+func (c *cmdNetworkExternalRemove) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <PHYSNET>",
+		Short: "Disconnect OVN from a physical network",
+		Long: "Remove the external network from the cluster. The command fails while logical\n" +
+			"switch ports other than the localnet port are connected to the network's switch.",
+		Args: cobra.ExactArgs(1),
+		RunE: c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn network external remove" subcommand
+func (c *cmdNetworkExternalRemove) Run(_ *cobra.Command, args []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.RemoveExternalNetwork(context.Background(), cli, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("External network '%s' removed\n", args[0])
+	printMemberErrors(response.Errors)
+	return nil
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"strings"
 
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
 	"github.com/canonical/microcluster/v3/microcluster"
+	microTypes "github.com/canonical/microcluster/v3/microcluster/types"
 	"github.com/spf13/cobra"
 
 	"github.com/canonical/microovn/microovn/api/types"
@@ -69,6 +72,8 @@ type cmdEnable struct {
 	extraConfig      []string
 	nodeName         string
 	manualBgpdConfig bool
+	check            bool
+	flagFormat       string
 }
 
 func (c *cmdEnable) Command() *cobra.Command {
@@ -93,7 +98,22 @@ func (c *cmdEnable) Command() *cobra.Command {
 		&c.manualBgpdConfig,
 		"manual-bgpd-config",
 		false,
-		"Skip automatic BIRD daemon configuration for manual BGP setup",
+		"Skip automatic routing daemon configuration for manual BGP setup",
+	)
+
+	cmd.Flags().BoolVar(
+		&c.check,
+		"check",
+		false,
+		"Only run preflight checks of the BGP configuration, without enabling the service",
+	)
+
+	cmd.Flags().StringVarP(
+		&c.flagFormat,
+		"format",
+		"f",
+		"table",
+		i18n.G("Format of preflight check results (csv|json|table|yaml|compact)")+"``",
 	)
 
 	cmd.Flags().StringVar(
@@ -119,6 +139,10 @@ func (c *cmdEnable) Run(_ *cobra.Command, args []string) error {
 
 	targetService := args[0]
 
+	if c.check {
+		return c.runCheck(cli, targetService)
+	}
+
 	if targetService == types.SrvBgp {
 		fmt.Printf("Please note, BGP functionality is currently experimental and likely to change.")
 	}
@@ -141,26 +165,84 @@ func (c *cmdEnable) Run(_ *cobra.Command, args []string) error {
 	return nil
 }
 
-// parseExtraConfig parses extra arguments passed to the cmdEnable in form of "--config key=value". Based
-// on the service that's being enabled, it the initializes appropriate extra config structure from these values.
-func (c *cmdEnable) parseExtraConfig(targetService types.SrvName) (types.ExtraServiceConfig, error) {
-	extraConfig := types.ExtraServiceConfig{}
-	rawConfig := map[string]string{}
+// runCheck runs preflight checks of the BGP configuration passed with "--config" on the target member
+// and prints their results. It returns error if any of the checks failed.
+func (c *cmdEnable) runCheck(cli microTypes.Client, targetService types.SrvName) error {
+	if targetService != types.SrvBgp {
+		return fmt.Errorf("preflight checks are not supported for service '%s'", targetService)
+	}
+
+	rawConfig, err := c.parseRawConfig()
+	if err != nil {
+		return err
+	}
+
+	// Configuration is validated by the preflight checks, so that all problems are reported together
+	bgpConfig := types.ExtraBgpConfig{}
+	err = bgpConfig.SetFromMap(rawConfig)
+	if err != nil {
+		return err
+	}
+	bgpConfig.ManualBgpdConfig = c.manualBgpdConfig
+
+	report, err := client.CheckBgpConfig(context.Background(), cli, bgpConfig, c.nodeName)
+	if err != nil {
+		return err
+	}
+
+	if c.flagFormat == "json" || c.flagFormat == "yaml" {
+		err = lxdCmd.RenderTable(c.flagFormat, nil, nil, report)
+	} else {
+		rows := [][]string{}
+		for _, check := range report.Checks {
+			result := "ok"
+			if !check.Passed {
+				result = "failed"
+			}
+			rows = append(rows, []string{check.Check, check.Subject, result, check.Message})
+		}
+
+		header := []string{"CHECK", "SUBJECT", "RESULT", "MESSAGE"}
+		err = lxdCmd.RenderTable(c.flagFormat, header, rows, report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !report.Passed() {
+		return fmt.Errorf("BGP preflight checks failed on member '%s'", report.Member)
+	}
+	return nil
+}
 
+// parseRawConfig parses extra arguments passed to the cmdEnable in form of "--config key=value" into
+// a map of keys and values.
+func (c *cmdEnable) parseRawConfig() (map[string]string, error) {
+	rawConfig := map[string]string{}
 	for _, configString := range c.extraConfig {
 		key, value, found := strings.Cut(configString, "=")
 		if !found {
-			err := fmt.Errorf("configuration '%s' does not conform to the 'key=value' format", configString)
-			return extraConfig, err
+			return nil, fmt.Errorf("configuration '%s' does not conform to the 'key=value' format", configString)
 		}
 		_, exists := rawConfig[key]
 		if exists {
-			err := fmt.Errorf("configuration '%s' already set", key)
-			return extraConfig, err
+			return nil, fmt.Errorf("configuration '%s' already set", key)
 		}
 		rawConfig[key] = value
 	}
 
+	return rawConfig, nil
+}
+
+// parseExtraConfig parses extra arguments passed to the cmdEnable in form of "--config key=value". Based
+// on the service that's being enabled, it the initializes appropriate extra config structure from these values.
+func (c *cmdEnable) parseExtraConfig(targetService types.SrvName) (types.ExtraServiceConfig, error) {
+	extraConfig := types.ExtraServiceConfig{}
+	rawConfig, err := c.parseRawConfig()
+	if err != nil {
+		return extraConfig, err
+	}
+
 	if len(rawConfig) == 0 {
 		return extraConfig, nil
 	}
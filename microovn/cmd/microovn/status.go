@@ -48,6 +48,12 @@ func (c *cmdStatus) Run(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
+	// Get gateway roles of chassis.
+	gateways, err := client.GetChassisGateways(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
 	// Get cluster members.
 	clusterMembers, err := m.GetClusterMembers(context.Background())
 	if err != nil {
@@ -70,6 +76,12 @@ func (c *cmdStatus) Run(_ *cobra.Command, _ []string) error {
 
 		fmt.Printf("- %s (%s)\n", server.Name, server.Address.Addr().String())
 		fmt.Printf("  Services: %s\n", strings.Join(srvServices, ", "))
+
+		for _, gateway := range gateways {
+			if gateway.Member == server.Name {
+				fmt.Printf("  Gateway: %s\n", gatewaySummary(gateway))
+			}
+		}
 	}
 
 	// Get OVN clustered DB schema version status
@@ -100,6 +112,21 @@ func (c *cmdStatus) Run(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// gatewaySummary returns human-readable description of the gateway options of a chassis.
+func gatewaySummary(gateway types.ChassisGateway) string {
+	var options []string
+	if gateway.Priority != "" {
+		options = append(options, "priority "+gateway.Priority)
+	}
+	if len(gateway.AvailabilityZones) != 0 {
+		options = append(options, "availability zones "+strings.Join(gateway.AvailabilityZones, ":"))
+	}
+	if len(options) == 0 {
+		return "enabled"
+	}
+	return strings.Join(options, ", ")
+}
+
 // reportOvsdbSchemaStatus fetches currently active schema version and list of expected schema version from each
 // node in the deployment. Based on the results it then prints a report for the user.
 func reportOvsdbSchemaStatus(m *microcluster.MicroCluster, cli *microTypes.Client, ovsdbType ovnCmd.OvsdbType) {
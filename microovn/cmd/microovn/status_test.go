@@ -66,3 +66,16 @@ func TestUnexported_ovsdbSchemaRequiresAttentionClusterDiff(t *testing.T) {
 	_ovsdbSchemaRequiresAttention(clusterSchema, nodeError, activeSchema,
 		true, t)
 }
+
+func TestUnexported_gatewaySummary(t *testing.T) {
+	for expected, gateway := range map[string]types.ChassisGateway{
+		"enabled":                                {Member: "a"},
+		"priority 100":                           {Member: "a", Priority: "100"},
+		"priority 0, availability zones az1:az2": {Member: "a", Priority: "0", AvailabilityZones: []string{"az1", "az2"}},
+	} {
+		summary := gatewaySummary(gateway)
+		if summary != expected {
+			t.Errorf("gatewaySummary(%+v) returned '%s', expected '%s'", gateway, summary, expected)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package database
+
+//go:generate -command mapper lxd-generate db mapper -t bgp_allocation.mapper.go
+//go:generate mapper reset
+//
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation objects table=bgp_allocations
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation objects-by-Member table=bgp_allocations
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation objects-by-Kind table=bgp_allocations
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation id table=bgp_allocations
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation create table=bgp_allocations
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation delete-by-Kind-and-Value table=bgp_allocations
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation delete-by-Member table=bgp_allocations
+//
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation GetMany table=bgp_allocations
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation ID table=bgp_allocations
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation Exists table=bgp_allocations
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation Create table=bgp_allocations
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation DeleteOne-by-Kind-and-Value table=bgp_allocations
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpAllocation DeleteMany-by-Member table=bgp_allocations
+
+// BgpAllocation is used to track values allocated to cluster members for the BGP integration, like
+// router IDs, MAC addresses, VRF table IDs and ASNs. Each value of a kind can be allocated only once
+// in the cluster.
+type BgpAllocation struct {
+	ID     int
+	Member string `db:"join=core_cluster_members.name&joinon=bgp_allocations.member_id"`
+	Kind   string `db:"primary=yes"`
+	Name   string
+	Value  string `db:"primary=yes"`
+}
+
+// BgpAllocationFilter is a required struct for use with lxd-generate. It is used for filtering fields on database fetches.
+type BgpAllocationFilter struct {
+	Member *string
+	Kind   *string
+}
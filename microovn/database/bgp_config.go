@@ -0,0 +1,34 @@
+package database
+
+//go:generate -command mapper lxd-generate db mapper -t bgp_config.mapper.go
+//go:generate mapper reset
+//
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem objects table=bgp_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem objects-by-Member table=bgp_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem objects-by-Member-and-Key table=bgp_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem id table=bgp_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem create table=bgp_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem delete-by-Member table=bgp_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem update table=bgp_config
+//
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem GetMany table=bgp_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem GetOne table=bgp_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem ID table=bgp_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem Exists table=bgp_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem Create table=bgp_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem DeleteMany-by-Member table=bgp_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BgpConfigItem Update table=bgp_config
+
+// BgpConfigItem is used to track BGP configuration of a particular cluster member.
+type BgpConfigItem struct {
+	ID     int
+	Member string `db:"primary=yes&join=core_cluster_members.name&joinon=bgp_config.member_id"`
+	Key    string `db:"primary=yes"`
+	Value  string
+}
+
+// BgpConfigItemFilter is a required struct for use with lxd-generate. It is used for filtering fields on database fetches.
+type BgpConfigItemFilter struct {
+	Member *string
+	Key    *string
+}
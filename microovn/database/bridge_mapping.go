@@ -0,0 +1,34 @@
+package database
+
+//go:generate -command mapper lxd-generate db mapper -t bridge_mapping.mapper.go
+//go:generate mapper reset
+//
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping objects table=bridge_mappings
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping objects-by-Member table=bridge_mappings
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping objects-by-Member-and-Physnet table=bridge_mappings
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping id table=bridge_mappings
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping create table=bridge_mappings
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping delete-by-Member-and-Physnet table=bridge_mappings
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping update table=bridge_mappings
+//
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping GetMany table=bridge_mappings
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping GetOne table=bridge_mappings
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping ID table=bridge_mappings
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping Exists table=bridge_mappings
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping Create table=bridge_mappings
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping DeleteOne-by-Member-and-Physnet table=bridge_mappings
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e BridgeMapping Update table=bridge_mappings
+
+// BridgeMapping is used to track "ovn-bridge-mappings" entries of a particular cluster member.
+type BridgeMapping struct {
+	ID      int
+	Member  string `db:"primary=yes&join=core_cluster_members.name&joinon=bridge_mappings.member_id"`
+	Physnet string `db:"primary=yes"`
+	Bridge  string
+}
+
+// BridgeMappingFilter is a required struct for use with lxd-generate. It is used for filtering fields on database fetches.
+type BridgeMappingFilter struct {
+	Member  *string
+	Physnet *string
+}
@@ -0,0 +1,34 @@
+package database
+
+//go:generate -command mapper lxd-generate db mapper -t chassis_config.mapper.go
+//go:generate mapper reset
+//
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem objects table=chassis_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem objects-by-Member table=chassis_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem objects-by-Member-and-Key table=chassis_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem id table=chassis_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem create table=chassis_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem delete-by-Member-and-Key table=chassis_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem update table=chassis_config
+//
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem GetMany table=chassis_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem GetOne table=chassis_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem ID table=chassis_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem Exists table=chassis_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem Create table=chassis_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem DeleteOne-by-Member-and-Key table=chassis_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ChassisConfigItem Update table=chassis_config
+
+// ChassisConfigItem is used to track OVN chassis configuration of a particular cluster member.
+type ChassisConfigItem struct {
+	ID     int
+	Member string `db:"primary=yes&join=core_cluster_members.name&joinon=chassis_config.member_id"`
+	Key    string `db:"primary=yes"`
+	Value  string
+}
+
+// ChassisConfigItemFilter is a required struct for use with lxd-generate. It is used for filtering fields on database fetches.
+type ChassisConfigItemFilter struct {
+	Member *string
+	Key    *string
+}
@@ -0,0 +1,33 @@
+package database
+
+//go:generate -command mapper lxd-generate db mapper -t external_network.mapper.go
+//go:generate mapper reset
+//
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork objects table=external_networks
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork objects-by-Name table=external_networks
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork objects-by-Interface table=external_networks
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork id table=external_networks
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork create table=external_networks
+//go:generate mapper stmt -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork delete-by-Name table=external_networks
+//
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork GetMany table=external_networks
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork GetOne table=external_networks
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork ID table=external_networks
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork Exists table=external_networks
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork Create table=external_networks
+//go:generate mapper method -i -d github.com/canonical/microcluster/v3/microcluster/db -e ExternalNetwork DeleteOne-by-Name table=external_networks
+
+// ExternalNetwork is used to track provider networks that connect OVN to a physical network through
+// the same interface on every cluster member with "chassis" service.
+type ExternalNetwork struct {
+	ID        int
+	Name      string `db:"primary=yes"`
+	Interface string
+	Vlan      string
+}
+
+// ExternalNetworkFilter is a required struct for use with lxd-generate. It is used for filtering fields on database fetches.
+type ExternalNetworkFilter struct {
+	Name      *string
+	Interface *string
+}
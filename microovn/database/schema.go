@@ -16,6 +16,11 @@ var SchemaExtensions = []db.Update{
 	schemaUpdate1,
 	schemaUpdate2,
 	schemaUpdate3,
+	schemaUpdate4,
+	schemaUpdate5,
+	schemaUpdate6,
+	schemaUpdate7,
+	schemaUpdate8,
 }
 
 // getClusterTableName returns the name of the table that holds the record of cluster members from sqlite_master.
@@ -114,3 +119,95 @@ ALTER TABLE services_new RENAME TO services;
 
 	return err
 }
+
+// schemaUpdate4 adds the `bgp_config` table that keeps BGP configuration of each cluster member.
+func schemaUpdate4(ctx context.Context, tx *sql.Tx) error {
+	stmt := `
+CREATE TABLE bgp_config (
+  id                            INTEGER  PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id                     INTEGER  NOT  NULL,
+  key                           TEXT     NOT  NULL,
+  value                         TEXT     NOT  NULL,
+  FOREIGN KEY (member_id) REFERENCES "core_cluster_members" (id) ON DELETE CASCADE
+  UNIQUE(member_id, key)
+);
+	`
+
+	_, err := tx.ExecContext(ctx, stmt)
+
+	return err
+}
+
+// schemaUpdate5 adds the `bgp_allocations` table that keeps values allocated to cluster members for the
+// BGP integration. Each value of a kind can be allocated only once in the cluster.
+func schemaUpdate5(ctx context.Context, tx *sql.Tx) error {
+	stmt := `
+CREATE TABLE bgp_allocations (
+  id                            INTEGER  PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id                     INTEGER  NOT  NULL,
+  kind                          TEXT     NOT  NULL,
+  name                          TEXT     NOT  NULL,
+  value                         TEXT     NOT  NULL,
+  FOREIGN KEY (member_id) REFERENCES "core_cluster_members" (id) ON DELETE CASCADE
+  UNIQUE(kind, value)
+);
+	`
+
+	_, err := tx.ExecContext(ctx, stmt)
+
+	return err
+}
+
+// schemaUpdate6 adds the `external_networks` table that keeps provider networks set up on every cluster
+// member with "chassis" service.
+func schemaUpdate6(ctx context.Context, tx *sql.Tx) error {
+	stmt := `
+CREATE TABLE external_networks (
+  id                            INTEGER  PRIMARY KEY AUTOINCREMENT NOT NULL,
+  name                          TEXT     NOT  NULL,
+  interface                     TEXT     NOT  NULL,
+  vlan                          TEXT     NOT  NULL,
+  UNIQUE(name)
+);
+	`
+
+	_, err := tx.ExecContext(ctx, stmt)
+
+	return err
+}
+
+// schemaUpdate7 adds the `bridge_mappings` table that keeps "ovn-bridge-mappings" entries of each cluster member.
+func schemaUpdate7(ctx context.Context, tx *sql.Tx) error {
+	stmt := `
+CREATE TABLE bridge_mappings (
+  id                            INTEGER  PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id                     INTEGER  NOT  NULL,
+  physnet                       TEXT     NOT  NULL,
+  bridge                        TEXT     NOT  NULL,
+  FOREIGN KEY (member_id) REFERENCES "core_cluster_members" (id) ON DELETE CASCADE
+  UNIQUE(member_id, physnet)
+);
+	`
+
+	_, err := tx.ExecContext(ctx, stmt)
+
+	return err
+}
+
+// schemaUpdate8 adds the `chassis_config` table that keeps OVN chassis configuration of each cluster member.
+func schemaUpdate8(ctx context.Context, tx *sql.Tx) error {
+	stmt := `
+CREATE TABLE chassis_config (
+  id                            INTEGER  PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id                     INTEGER  NOT  NULL,
+  key                           TEXT     NOT  NULL,
+  value                         TEXT     NOT  NULL,
+  FOREIGN KEY (member_id) REFERENCES "core_cluster_members" (id) ON DELETE CASCADE
+  UNIQUE(member_id, key)
+);
+	`
+
+	_, err := tx.ExecContext(ctx, stmt)
+
+	return err
+}
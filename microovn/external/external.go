@@ -0,0 +1,244 @@
+// Package external implements provider networks that connect OVN to physical networks of cluster
+// members. Each network is represented by a Logical Switch with a localnet port in the OVN Northbound
+// database and by an OVS bridge, with a physical interface plugged to it, on each member with
+// "chassis" service.
+package external
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/state"
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+// ManagedTag - a key used in "external_ids" of OVS bridges and OVN Logical Switches that are created
+// for external networks. Its value is the name of the external network.
+const ManagedTag = "microovn-external-network"
+
+// BridgeMapping - a key used in "external_ids" of Open_vSwitch table, to keep track of
+// "ovn-bridge-mappings" added for external networks.
+const BridgeMapping = "microovn-external-bridge-mapping"
+
+// BridgeName returns name of the OVS bridge to which physical interface "iface" is plugged.
+func BridgeName(iface string) string {
+	return fmt.Sprintf("br-%s", iface)
+}
+
+// getLsName returns name of the Logical Switch that represents external network "name".
+func getLsName(name string) string {
+	return fmt.Sprintf("ls-ext-%s", name)
+}
+
+// getLocalnetName returns name of the localnet Logical Switch Port of external network "name".
+func getLocalnetName(name string) string {
+	return fmt.Sprintf("ln-ext-%s", name)
+}
+
+// getBridgeMap returns "ovn-bridge-mappings" entry of external network "network".
+func getBridgeMap(network types.ExternalNetwork) string {
+	return fmt.Sprintf("%s:%s", network.Name, BridgeName(network.Interface))
+}
+
+// toAPI converts database record of external network to its API representation.
+func toAPI(network database.ExternalNetwork) types.ExternalNetwork {
+	return types.ExternalNetwork{
+		Name:      network.Name,
+		Interface: network.Interface,
+		Vlan:      network.Vlan,
+		Switch:    getLsName(network.Name),
+	}
+}
+
+// splitMappings returns entries of comma-separated bridge mappings "value".
+func splitMappings(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// List returns all external networks defined in the cluster.
+func List(ctx context.Context, s state.State) (types.ExternalNetworks, error) {
+	networks := types.ExternalNetworks{}
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		records, err := database.GetExternalNetworks(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			networks = append(networks, toAPI(record))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup external networks: %w", err)
+	}
+	return networks, nil
+}
+
+// Add records external network "network" in the cluster database and creates its Logical Switch in
+// the OVN Northbound database. The OVS side of the network has to be set up on each chassis with
+// SetupLocal.
+func Add(ctx context.Context, s state.State, network types.ExternalNetwork) (types.ExternalNetwork, error) {
+	err := network.Validate()
+	if err != nil {
+		return types.ExternalNetwork{}, err
+	}
+
+	record := database.ExternalNetwork{Name: network.Name, Interface: network.Interface, Vlan: network.Vlan}
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ExternalNetworkExists(ctx, tx, network.Name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("external network '%s' already exists", network.Name)
+		}
+
+		existing, err := database.GetExternalNetworks(ctx, tx, database.ExternalNetworkFilter{Interface: &network.Interface})
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf("interface '%s' is already used by external network '%s'", network.Interface, existing[0].Name)
+		}
+
+		mappings, err := database.GetBridgeMappings(ctx, tx)
+		if err != nil {
+			return err
+		}
+		for _, mapping := range mappings {
+			if mapping.Physnet == network.Name {
+				return fmt.Errorf("physical network '%s' is already mapped on member '%s'", network.Name, mapping.Member)
+			}
+		}
+
+		_, err = database.CreateExternalNetwork(ctx, tx, record)
+		return err
+	})
+	if err != nil {
+		return types.ExternalNetwork{}, err
+	}
+
+	err = createSwitch(ctx, s, network)
+	if err != nil {
+		dbErr := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			return database.DeleteExternalNetwork(ctx, tx, network.Name)
+		})
+		return types.ExternalNetwork{}, errors.Join(err, dbErr)
+	}
+
+	return toAPI(record), nil
+}
+
+// Remove deletes Logical Switch of external network "name" from the OVN Northbound database and removes
+// the network from the cluster database. The network can't be removed while other ports are connected
+// to its switch. The OVS side of the network has to be removed on each chassis with TeardownLocal.
+func Remove(ctx context.Context, s state.State, name string) error {
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := database.GetExternalNetwork(ctx, tx, name)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lookup external network '%s': %w", name, err)
+	}
+
+	err = deleteSwitch(ctx, s, name)
+	if err != nil {
+		return err
+	}
+
+	return s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteExternalNetwork(ctx, tx, name)
+	})
+}
+
+// createSwitch creates Logical Switch with a localnet port that connects OVN to external network "network".
+func createSwitch(ctx context.Context, s state.State, network types.ExternalNetwork) error {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer nb.Close()
+
+	lsName := getLsName(network.Name)
+	localnet := map[string]any{
+		"name":      getLocalnetName(network.Name),
+		"type":      "localnet",
+		"options":   ovsdbclient.Map(map[string]string{"network_name": network.Name}),
+		"addresses": ovsdbclient.StringSet("unknown"),
+	}
+	if network.Vlan != "" {
+		tag, _ := strconv.Atoi(network.Vlan)
+		localnet["tag_request"] = tag
+	}
+
+	_, err = nb.Transact(ctx,
+		ovsdbclient.AssertAbsent(ovsdbclient.TableLogicalSwitch, ovsdbclient.Equal("name", lsName)),
+		ovsdbclient.Insert(ovsdbclient.TableLogicalSwitchPort, localnet, "localnet"),
+		ovsdbclient.Insert(ovsdbclient.TableLogicalSwitch, map[string]any{
+			"name":         lsName,
+			"ports":        ovsdbclient.Set(ovsdbclient.NamedUUID("localnet")),
+			"external_ids": ovsdbclient.Map(map[string]string{ManagedTag: network.Name}),
+		}, ""),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create Logical Switch '%s': %w", lsName, err)
+	}
+	return nil
+}
+
+// deleteSwitch removes Logical Switch of external network "name", unless ports other than the localnet
+// port are connected to it.
+func deleteSwitch(ctx context.Context, s state.State, name string) error {
+	nb, err := ovsdbclient.ConnectNBCluster(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer nb.Close()
+
+	lsName := getLsName(name)
+	switches, err := ovsdbclient.Find[ovsdbclient.LogicalSwitch](ctx, nb, ovsdbclient.TableLogicalSwitch,
+		ovsdbclient.Equal("name", lsName),
+		ovsdbclient.Includes("external_ids", ovsdbclient.Map(map[string]string{ManagedTag: name})),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lookup Logical Switch '%s': %w", lsName, err)
+	}
+	if len(switches) == 0 {
+		logger.Warnf("Logical Switch '%s' of external network '%s' does not exist", lsName, name)
+		return nil
+	}
+
+	ports, err := ovsdbclient.Find[ovsdbclient.LogicalSwitchPort](ctx, nb, ovsdbclient.TableLogicalSwitchPort)
+	if err != nil {
+		return fmt.Errorf("failed to lookup Logical Switch Ports: %w", err)
+	}
+
+	localnetName := getLocalnetName(name)
+	var operations []ovsdbclient.Operation
+	for _, logicalSwitch := range switches {
+		for _, port := range ports {
+			if slices.Contains(logicalSwitch.Ports, port.UUID) && port.Name != localnetName {
+				return fmt.Errorf("external network '%s' is still in use by port '%s'", name, port.Name)
+			}
+		}
+		operations = append(operations, ovsdbclient.Delete(ovsdbclient.TableLogicalSwitch, ovsdbclient.HasUUID(logicalSwitch.UUID)))
+	}
+
+	_, err = nb.Transact(ctx, operations...)
+	if err != nil {
+		return fmt.Errorf("failed to delete Logical Switch '%s': %w", lsName, err)
+	}
+	return nil
+}
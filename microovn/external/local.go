@@ -0,0 +1,253 @@
+package external
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/state"
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+// CreateBridgeOperations returns OVS operations that create bridge "br-<iface>", with its internal port
+// and physical interface "iface" plugged to it, and add the bridge to the "openvSwitch" row.
+// "externalIDs" are set on the bridge, so that its owner can recognize it later. Operations for
+// multiple interfaces can be combined in a single transaction.
+func CreateBridgeOperations(openvSwitch ovsdbclient.OpenvSwitch, iface string, externalIDs map[string]string) []ovsdbclient.Operation {
+	bridgeName := BridgeName(iface)
+
+	// Named UUIDs may contain only letters, digits and '_', interface names are hex-encoded to fit
+	ref := hex.EncodeToString([]byte(iface))
+	bridgeIfaceRef := "bridge_iface_" + ref
+	bridgePortRef := "bridge_port_" + ref
+	extIfaceRef := "ext_iface_" + ref
+	extPortRef := "ext_port_" + ref
+	bridgeRef := "bridge_" + ref
+
+	return []ovsdbclient.Operation{
+		ovsdbclient.Insert(ovsdbclient.TableInterface, map[string]any{
+			"name": bridgeName,
+			"type": "internal",
+		}, bridgeIfaceRef),
+		ovsdbclient.Insert(ovsdbclient.TablePort, map[string]any{
+			"name":       bridgeName,
+			"interfaces": ovsdbclient.NamedUUID(bridgeIfaceRef),
+		}, bridgePortRef),
+		ovsdbclient.Insert(ovsdbclient.TableInterface, map[string]any{
+			"name": iface,
+		}, extIfaceRef),
+		ovsdbclient.Insert(ovsdbclient.TablePort, map[string]any{
+			"name":       iface,
+			"interfaces": ovsdbclient.NamedUUID(extIfaceRef),
+		}, extPortRef),
+		ovsdbclient.Insert(ovsdbclient.TableBridge, map[string]any{
+			"name":         bridgeName,
+			"ports":        ovsdbclient.Set(ovsdbclient.NamedUUID(bridgePortRef), ovsdbclient.NamedUUID(extPortRef)),
+			"external_ids": ovsdbclient.Map(externalIDs),
+		}, bridgeRef),
+		ovsdbclient.Mutate(ovsdbclient.TableOpenvSwitch, []ovsdbclient.Condition{ovsdbclient.HasUUID(openvSwitch.UUID)},
+			ovsdbclient.SetInsert("bridges", ovsdbclient.NamedUUID(bridgeRef)),
+			ovsdbclient.Increment("next_cfg", 1),
+		),
+	}
+}
+
+// AddBridgeMapsOperation returns OVS operation that adds "bridgeMaps" to "ovn-bridge-mappings" of the
+// "openvSwitch" row. Added mappings are also tracked under "trackingKey" in its "external_ids", so that
+// only they are removed later.
+func AddBridgeMapsOperation(openvSwitch ovsdbclient.OpenvSwitch, trackingKey string, bridgeMaps ...string) ovsdbclient.Operation {
+	current := splitMappings(openvSwitch.ExternalIDs["ovn-bridge-mappings"])
+	tracked := splitMappings(openvSwitch.ExternalIDs[trackingKey])
+	for _, bridgeMap := range bridgeMaps {
+		if !slices.Contains(current, bridgeMap) {
+			current = append(current, bridgeMap)
+		}
+		if !slices.Contains(tracked, bridgeMap) {
+			tracked = append(tracked, bridgeMap)
+		}
+	}
+
+	return ovsdbclient.SetMapKeys(ovsdbclient.TableOpenvSwitch, []ovsdbclient.Condition{ovsdbclient.HasUUID(openvSwitch.UUID)},
+		"external_ids", map[string]string{
+			"ovn-bridge-mappings": strings.Join(current, ","),
+			trackingKey:           strings.Join(tracked, ","),
+		})
+}
+
+// SetupLocal creates OVS bridge of external network "network" on the local chassis, plugs the physical
+// interface to it and maps the physical network to the bridge in "ovn-bridge-mappings". Network that
+// is already set up is left untouched.
+func SetupLocal(ctx context.Context, s state.State, network types.ExternalNetwork) error {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	bridgeName := BridgeName(network.Interface)
+	bridges, err := ovsdbclient.Find[ovsdbclient.Bridge](ctx, ovs, ovsdbclient.TableBridge, ovsdbclient.Equal("name", bridgeName))
+	if err != nil {
+		return fmt.Errorf("failed to lookup OVS bridge '%s': %w", bridgeName, err)
+	}
+	for _, bridge := range bridges {
+		if bridge.ExternalIDs[ManagedTag] != network.Name {
+			return fmt.Errorf("OVS bridge '%s' already exists and is not managed by external network '%s'", bridgeName, network.Name)
+		}
+	}
+
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return fmt.Errorf("failed to lookup ovn-bridge-mappings: %w", err)
+	}
+
+	bridgeMap := getBridgeMap(network)
+	bridgeMaps := splitMappings(openvSwitch.ExternalIDs["ovn-bridge-mappings"])
+	for _, existing := range bridgeMaps {
+		if strings.HasPrefix(existing, network.Name+":") && existing != bridgeMap {
+			return fmt.Errorf("physical network '%s' is already mapped in ovn-bridge-mappings: %s", network.Name, existing)
+		}
+	}
+	if len(bridges) != 0 && slices.Contains(bridgeMaps, bridgeMap) {
+		return nil
+	}
+
+	var operations []ovsdbclient.Operation
+	if len(bridges) == 0 {
+		ports, err := ovsdbclient.Find[ovsdbclient.Port](ctx, ovs, ovsdbclient.TablePort, ovsdbclient.Equal("name", network.Interface))
+		if err != nil {
+			return fmt.Errorf("failed to lookup OVS port '%s': %w", network.Interface, err)
+		}
+		if len(ports) != 0 {
+			return fmt.Errorf("interface '%s' is already plugged to an OVS bridge", network.Interface)
+		}
+
+		operations = CreateBridgeOperations(openvSwitch, network.Interface, map[string]string{ManagedTag: network.Name})
+	}
+	operations = append(operations, AddBridgeMapsOperation(openvSwitch, BridgeMapping, bridgeMap))
+
+	_, err = ovs.Transact(ctx, operations...)
+	if err != nil {
+		return fmt.Errorf("failed to set up external network '%s': %w", network.Name, err)
+	}
+	return nil
+}
+
+// TeardownLocal removes OVS bridge and bridge mapping of external network "name" from the local chassis.
+func TeardownLocal(ctx context.Context, s state.State, name string) error {
+	return removeBridges(ctx, s, func(network string) bool { return network == name })
+}
+
+// Cleanup removes OVS bridges and bridge mappings of all external networks, as well as bridge mappings
+// stored in the cluster database, from the local chassis.
+func Cleanup(ctx context.Context, s state.State) error {
+	err := removeBridges(ctx, s, func(string) bool { return true })
+	return errors.Join(err, applyBridgeMappings(ctx, s, nil))
+}
+
+// Reconcile makes sure that every external network defined in the cluster is set up on the local chassis
+// and that bridges of networks that were removed in the meantime are gone. Bridge mappings of the local
+// member, stored in the cluster database, are re-applied as well.
+func Reconcile(ctx context.Context, s state.State) error {
+	networks, err := List(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	allErrors := removeBridges(ctx, s, func(name string) bool {
+		return !slices.ContainsFunc(networks, func(network types.ExternalNetwork) bool { return network.Name == name })
+	})
+
+	err = ReconcileBridgeMappings(ctx, s)
+	if err != nil {
+		allErrors = errors.Join(allErrors, err)
+	}
+
+	for _, network := range networks {
+		err = SetupLocal(ctx, s, network)
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+		}
+	}
+	return allErrors
+}
+
+// removeBridges removes OVS bridges, along with their ports and bridge mappings, of external networks for
+// which "selected" returns true, in a single transaction.
+func removeBridges(ctx context.Context, s state.State, selected func(name string) bool) error {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	bridges, err := ovsdbclient.Find[ovsdbclient.Bridge](ctx, ovs, ovsdbclient.TableBridge)
+	if err != nil {
+		return fmt.Errorf("failed to lookup OVS bridges: %w", err)
+	}
+
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return fmt.Errorf("failed to lookup ovn-bridge-mappings: %w", err)
+	}
+	openvSwitchRow := []ovsdbclient.Condition{ovsdbclient.HasUUID(openvSwitch.UUID)}
+
+	// Bridges (and ports) that are no longer referenced are removed by the database
+	var bridgeRefs []any
+	for _, bridge := range bridges {
+		name := bridge.ExternalIDs[ManagedTag]
+		if name == "" || !selected(name) {
+			continue
+		}
+		logger.Infof("Removing OVS bridge '%s' of external network '%s'", bridge.Name, name)
+		bridgeRefs = append(bridgeRefs, ovsdbclient.UUID(bridge.UUID))
+	}
+
+	// Remove mappings of selected networks that were added by MicroOVN
+	var removedMaps, keptMaps []string
+	for _, bridgeMap := range splitMappings(openvSwitch.ExternalIDs[BridgeMapping]) {
+		name, _, _ := strings.Cut(bridgeMap, ":")
+		if selected(name) {
+			removedMaps = append(removedMaps, bridgeMap)
+		} else {
+			keptMaps = append(keptMaps, bridgeMap)
+		}
+	}
+
+	if len(bridgeRefs) == 0 && len(removedMaps) == 0 {
+		return nil
+	}
+
+	protectedMaps := TrackedElsewhere(openvSwitch.ExternalIDs, BridgeMapping)
+	var bridgeMaps []string
+	for _, bridgeMap := range splitMappings(openvSwitch.ExternalIDs["ovn-bridge-mappings"]) {
+		if !slices.Contains(removedMaps, bridgeMap) || slices.Contains(protectedMaps, bridgeMap) {
+			bridgeMaps = append(bridgeMaps, bridgeMap)
+		}
+	}
+
+	mutations := []ovsdbclient.Mutation{ovsdbclient.MapDelete("external_ids", "ovn-bridge-mappings", BridgeMapping)}
+	newExternalIDs := make(map[string]string)
+	if len(bridgeMaps) != 0 {
+		newExternalIDs["ovn-bridge-mappings"] = strings.Join(bridgeMaps, ",")
+	}
+	if len(keptMaps) != 0 {
+		newExternalIDs[BridgeMapping] = strings.Join(keptMaps, ",")
+	}
+	if len(newExternalIDs) != 0 {
+		mutations = append(mutations, ovsdbclient.MapInsert("external_ids", newExternalIDs))
+	}
+	if len(bridgeRefs) != 0 {
+		mutations = append(mutations, ovsdbclient.SetDelete("bridges", bridgeRefs...))
+	}
+
+	_, err = ovs.Transact(ctx, ovsdbclient.Mutate(ovsdbclient.TableOpenvSwitch, openvSwitchRow, mutations...))
+	if err != nil {
+		return fmt.Errorf("failed to remove OVS resources of external networks: %w", err)
+	}
+	return nil
+}
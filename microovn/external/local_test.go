@@ -0,0 +1,30 @@
+package external
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+func TestCreateBridgeOperations(t *testing.T) {
+	// OVSDB accepts only identifiers as "uuid-name" (RFC 7047, section 5.2.1)
+	idRegex := regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	openvSwitch := ovsdbclient.OpenvSwitch{UUID: "00000000-0000-0000-0000-000000000000"}
+
+	names := make(map[string]bool)
+	for _, iface := range []string{"eth0", "enp5s0.100", "my-nic"} {
+		for _, operation := range CreateBridgeOperations(openvSwitch, iface, map[string]string{ManagedTag: "physnet"}) {
+			if operation.Op != "insert" {
+				continue
+			}
+			if !idRegex.MatchString(operation.UUIDName) {
+				t.Errorf("invalid uuid-name '%s' for interface '%s'", operation.UUIDName, iface)
+			}
+			if names[operation.UUIDName] {
+				t.Errorf("duplicate uuid-name '%s'", operation.UUIDName)
+			}
+			names[operation.UUIDName] = true
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package external
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/microcluster/v3/state"
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+// ClusterBridgeMapping - a key used in "external_ids" of Open_vSwitch table, to keep track of
+// "ovn-bridge-mappings" entries that are stored in the cluster database.
+const ClusterBridgeMapping = "microovn-cluster-bridge-mapping"
+
+// bridgeMappingKeySuffix - suffix shared by all keys in "external_ids" of Open_vSwitch table that keep
+// track of "ovn-bridge-mappings" entries added by MicroOVN.
+const bridgeMappingKeySuffix = "-bridge-mapping"
+
+// TrackedElsewhere returns "ovn-bridge-mappings" entries that are tracked by MicroOVN in "externalIDs"
+// of Open_vSwitch table under any key other than "ownKey". Owner of "ownKey" must not remove these
+// entries, even if it tracks them as well.
+func TrackedElsewhere(externalIDs map[string]string, ownKey string) []string {
+	var bridgeMaps []string
+	for key, value := range externalIDs {
+		if key == ownKey || !strings.HasPrefix(key, "microovn-") || !strings.HasSuffix(key, bridgeMappingKeySuffix) {
+			continue
+		}
+		bridgeMaps = append(bridgeMaps, splitMappings(value)...)
+	}
+	return bridgeMaps
+}
+
+// mergeBridgeMaps returns "ovn-bridge-mappings" entries that result from replacing entries "tracked" by
+// the caller with "desired" entries in "current" entries. Entries in "protected" are tracked by other
+// owners and are never removed. Error is returned if a desired physical network or bridge is already
+// mapped differently by someone else.
+func mergeBridgeMaps(current []string, tracked []string, desired []string, protected []string) ([]string, error) {
+	var merged []string
+	for _, bridgeMap := range current {
+		if slices.Contains(tracked, bridgeMap) && !slices.Contains(desired, bridgeMap) && !slices.Contains(protected, bridgeMap) {
+			continue
+		}
+		merged = append(merged, bridgeMap)
+	}
+
+	var allErrors error
+	for _, bridgeMap := range desired {
+		if slices.Contains(merged, bridgeMap) {
+			continue
+		}
+
+		physnet, bridge, _ := strings.Cut(bridgeMap, ":")
+		conflict := slices.IndexFunc(merged, func(existing string) bool {
+			existingPhysnet, existingBridge, _ := strings.Cut(existing, ":")
+			return existingPhysnet == physnet || existingBridge == bridge
+		})
+		if conflict != -1 {
+			allErrors = errors.Join(allErrors, fmt.Errorf("bridge mapping '%s' conflicts with existing mapping '%s'", bridgeMap, merged[conflict]))
+			continue
+		}
+		merged = append(merged, bridgeMap)
+	}
+
+	return merged, allErrors
+}
+
+// ListBridgeMappings returns bridge mappings of all cluster members.
+func ListBridgeMappings(ctx context.Context, s state.State) (types.BridgeMappings, error) {
+	mappings := types.BridgeMappings{}
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		records, err := database.GetBridgeMappings(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			mappings = append(mappings, types.BridgeMapping{Member: record.Member, Physnet: record.Physnet, Bridge: record.Bridge})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup bridge mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// localBridgeMaps returns "ovn-bridge-mappings" entries of the local member stored in the cluster database.
+func localBridgeMaps(ctx context.Context, s state.State) ([]string, error) {
+	var bridgeMaps []string
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		name := s.Name()
+		records, err := database.GetBridgeMappings(ctx, tx, database.BridgeMappingFilter{Member: &name})
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			bridgeMaps = append(bridgeMaps, fmt.Sprintf("%s:%s", record.Physnet, record.Bridge))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup bridge mappings: %w", err)
+	}
+	return bridgeMaps, nil
+}
+
+// SetBridgeMapping stores bridge mapping "mapping" of the local member in the cluster database, replacing
+// previous mapping of the same physical network. If "apply" is true, the mapping is applied to the local
+// OVS as well, and the database change is reverted if that fails.
+func SetBridgeMapping(ctx context.Context, s state.State, mapping types.BridgeMapping, apply bool) (types.BridgeMapping, error) {
+	mapping.Member = s.Name()
+	err := mapping.Validate()
+	if err != nil {
+		return types.BridgeMapping{}, err
+	}
+
+	var previous *database.BridgeMapping
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ExternalNetworkExists(ctx, tx, mapping.Physnet)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("physical network '%s' is managed by external network of the same name", mapping.Physnet)
+		}
+
+		record := database.BridgeMapping{Member: mapping.Member, Physnet: mapping.Physnet, Bridge: mapping.Bridge}
+		previous, err = database.GetBridgeMapping(ctx, tx, mapping.Member, mapping.Physnet)
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			_, err = database.CreateBridgeMapping(ctx, tx, record)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		return database.UpdateBridgeMapping(ctx, tx, mapping.Member, mapping.Physnet, record)
+	})
+	if err != nil {
+		return types.BridgeMapping{}, err
+	}
+
+	if !apply {
+		return mapping, nil
+	}
+
+	err = ReconcileBridgeMappings(ctx, s)
+	if err != nil {
+		dbErr := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			if previous != nil {
+				return database.UpdateBridgeMapping(ctx, tx, mapping.Member, mapping.Physnet, *previous)
+			}
+			return database.DeleteBridgeMapping(ctx, tx, mapping.Member, mapping.Physnet)
+		})
+		return types.BridgeMapping{}, errors.Join(err, dbErr)
+	}
+
+	return mapping, nil
+}
+
+// RemoveBridgeMapping removes bridge mapping of physical network "physnet" of the local member from the
+// cluster database. If "apply" is true, the mapping is removed from the local OVS as well.
+func RemoveBridgeMapping(ctx context.Context, s state.State, physnet string, apply bool) error {
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteBridgeMapping(ctx, tx, s.Name(), physnet)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove bridge mapping of physical network '%s': %w", physnet, err)
+	}
+
+	if !apply {
+		return nil
+	}
+	return ReconcileBridgeMappings(ctx, s)
+}
+
+// ReconcileBridgeMappings applies bridge mappings of the local member, stored in the cluster database, to
+// "ovn-bridge-mappings" of the local OVS. Entries that were not added by this function, like those of BGP
+// integration or those set manually, are left untouched.
+func ReconcileBridgeMappings(ctx context.Context, s state.State) error {
+	desired, err := localBridgeMaps(ctx, s)
+	if err != nil {
+		return err
+	}
+	return applyBridgeMappings(ctx, s, desired)
+}
+
+// applyBridgeMappings replaces "ovn-bridge-mappings" entries tracked under ClusterBridgeMapping key with
+// "desired" entries, in a single transaction.
+func applyBridgeMappings(ctx context.Context, s state.State, desired []string) error {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return fmt.Errorf("failed to lookup ovn-bridge-mappings: %w", err)
+	}
+
+	current := splitMappings(openvSwitch.ExternalIDs["ovn-bridge-mappings"])
+	tracked := splitMappings(openvSwitch.ExternalIDs[ClusterBridgeMapping])
+	merged, err := mergeBridgeMaps(current, tracked, desired, TrackedElsewhere(openvSwitch.ExternalIDs, ClusterBridgeMapping))
+	if err != nil {
+		return err
+	}
+
+	if slices.Equal(merged, current) && slices.Equal(desired, tracked) {
+		return nil
+	}
+
+	mutations := []ovsdbclient.Mutation{ovsdbclient.MapDelete("external_ids", "ovn-bridge-mappings", ClusterBridgeMapping)}
+	newExternalIDs := make(map[string]string)
+	if len(merged) != 0 {
+		newExternalIDs["ovn-bridge-mappings"] = strings.Join(merged, ",")
+	}
+	if len(desired) != 0 {
+		newExternalIDs[ClusterBridgeMapping] = strings.Join(desired, ",")
+	}
+	if len(newExternalIDs) != 0 {
+		mutations = append(mutations, ovsdbclient.MapInsert("external_ids", newExternalIDs))
+	}
+
+	_, err = ovs.Transact(ctx, ovsdbclient.Mutate(ovsdbclient.TableOpenvSwitch,
+		[]ovsdbclient.Condition{ovsdbclient.HasUUID(openvSwitch.UUID)}, mutations...))
+	if err != nil {
+		return fmt.Errorf("failed to update ovn-bridge-mappings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package external
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMergeBridgeMaps(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   []string
+		tracked   []string
+		desired   []string
+		protected []string
+		expected  []string
+	}{
+		{
+			name:     "add to manual mappings",
+			current:  []string{"manual:br-manual"},
+			desired:  []string{"physnet1:br-ex"},
+			expected: []string{"manual:br-manual", "physnet1:br-ex"},
+		},
+		{
+			name:     "replace tracked mapping",
+			current:  []string{"manual:br-manual", "physnet1:br-ex"},
+			tracked:  []string{"physnet1:br-ex"},
+			desired:  []string{"physnet1:br-provider"},
+			expected: []string{"manual:br-manual", "physnet1:br-provider"},
+		},
+		{
+			name:     "adopt identical manual mapping",
+			current:  []string{"physnet1:br-ex"},
+			desired:  []string{"physnet1:br-ex"},
+			expected: []string{"physnet1:br-ex"},
+		},
+		{
+			name:      "keep mapping tracked elsewhere",
+			current:   []string{"physnet_node1_eth1:br-eth1"},
+			tracked:   []string{"physnet_node1_eth1:br-eth1"},
+			protected: []string{"physnet_node1_eth1:br-eth1"},
+			expected:  []string{"physnet_node1_eth1:br-eth1"},
+		},
+		{
+			name:    "remove all tracked mappings",
+			current: []string{"physnet1:br-ex"},
+			tracked: []string{"physnet1:br-ex"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := mergeBridgeMaps(test.current, test.tracked, test.desired, test.protected)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !slices.Equal(merged, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, merged)
+			}
+		})
+	}
+}
+
+func TestMergeBridgeMapsConflict(t *testing.T) {
+	for _, desired := range []string{"physnet1:br-provider", "physnet2:br-ex"} {
+		_, err := mergeBridgeMaps([]string{"physnet1:br-ex"}, nil, []string{desired}, nil)
+		if err == nil {
+			t.Errorf("expected conflict for '%s'", desired)
+		}
+	}
+}
+
+func TestTrackedElsewhere(t *testing.T) {
+	externalIDs := map[string]string{
+		"ovn-bridge-mappings":           "physnet1:br-ex,physnet_node1_eth1:br-eth1,eth2:br-eth2",
+		"microovn-bgp-bridge-mapping":   "physnet_node1_eth1:br-eth1",
+		BridgeMapping:                   "eth2:br-eth2",
+		ClusterBridgeMapping:            "physnet1:br-ex",
+		"microovn-unrelated-annotation": "foo:bar",
+	}
+
+	tracked := TrackedElsewhere(externalIDs, ClusterBridgeMapping)
+	slices.Sort(tracked)
+	expected := []string{"eth2:br-eth2", "physnet_node1_eth1:br-eth1"}
+	if !slices.Equal(tracked, expected) {
+		t.Errorf("expected %v, got %v", expected, tracked)
+	}
+}
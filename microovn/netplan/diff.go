@@ -0,0 +1,90 @@
+package netplan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Diff returns line diff between "current" serialized configuration and configuration "config", in the
+// format of "diff -u" without hunk headers. Nil "config" stands for configuration that is removed. An
+// empty string is returned if there are no changes.
+func Diff(current []byte, config *Config) (string, error) {
+	var desired []byte
+	if config != nil {
+		var err error
+		desired, err = yaml.Marshal(config)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return diffLines(splitLines(current), splitLines(desired)), nil
+}
+
+// FileDiff returns line diff between netplan configuration file "filename" in /etc/netplan and
+// configuration "config", as described by Diff. Missing file is treated as an empty one.
+func FileDiff(filename string, config *Config) (string, error) {
+	current, err := os.ReadFile(filepath.Join(ConfigDir, filename))
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read netplan config: %v", err)
+	}
+
+	return Diff(current, config)
+}
+
+// splitLines splits "data" into lines, without the trailing newline.
+func splitLines(data []byte) []string {
+	text := strings.TrimSuffix(string(data), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffLines returns lines of "current" and "desired" that are removed ("-"), added ("+") or kept (" "),
+// based on their longest common subsequence. An empty string is returned if they are equal.
+func diffLines(current []string, desired []string) string {
+	// common[i][j] is length of the longest common subsequence of current[i:] and desired[j:]
+	common := make([][]int, len(current)+1)
+	for i := range common {
+		common[i] = make([]int, len(desired)+1)
+	}
+	for i := len(current) - 1; i >= 0; i-- {
+		for j := len(desired) - 1; j >= 0; j-- {
+			if current[i] == desired[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var diff strings.Builder
+	changed := false
+	i, j := 0, 0
+	for i < len(current) || j < len(desired) {
+		switch {
+		case i < len(current) && j < len(desired) && current[i] == desired[j]:
+			diff.WriteString(" " + current[i] + "\n")
+			i++
+			j++
+		case j == len(desired) || (i < len(current) && common[i+1][j] >= common[i][j+1]):
+			diff.WriteString("-" + current[i] + "\n")
+			changed = true
+			i++
+		default:
+			diff.WriteString("+" + desired[j] + "\n")
+			changed = true
+			j++
+		}
+	}
+
+	if !changed {
+		return ""
+	}
+	return diff.String()
+}
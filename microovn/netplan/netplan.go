@@ -16,42 +16,57 @@ import (
 // SupportedVersion is a const for the version of netplan this supports
 const supportedVersion = 2
 
+// ConfigDir is the directory from which netplan loads system configuration
+const ConfigDir = "/etc/netplan"
+
 // Config represents the top-level netplan structure of a yaml file
 type Config struct {
-	Network network `yaml:"network"`
+	Network Network `yaml:"network"`
 }
 
 // Network represents the networks the config is defining
-type network struct {
+type Network struct {
 	Version          int                        `yaml:"version"`
-	VirtualEthernets map[string]virtualEthernet `yaml:"virtual-ethernets,omitempty"`
-	Vrfs             map[string]vrf             `yaml:"vrfs,omitempty"`
-	Bridges          map[string]bridge          `yaml:"bridges,omitempty"`
-	OpenvSwitch      *openvSwitch               `yaml:"openvswitch,omitempty"`
+	VirtualEthernets map[string]VirtualEthernet `yaml:"virtual-ethernets,omitempty"`
+	Vrfs             map[string]VRF             `yaml:"vrfs,omitempty"`
+	Bridges          map[string]Bridge          `yaml:"bridges,omitempty"`
+	Tunnels          map[string]Tunnel          `yaml:"tunnels,omitempty"`
+	OpenvSwitch      *OpenvSwitch               `yaml:"openvswitch,omitempty"`
 }
 
 // VirtualEthernet is a struct for defining virtual ethernets
-type virtualEthernet struct {
+type VirtualEthernet struct {
 	Peer       string   `yaml:"peer"`
 	MacAddress string   `yaml:"macaddress,omitempty"`
 	AcceptRa   bool     `yaml:"accept-ra"`
 	LinkLocal  []string `yaml:"link-local,omitempty"`
+	Addresses  []string `yaml:"addresses,omitempty"`
 }
 
 // VRF defines vrf entires in the netplan config
-type vrf struct {
+type VRF struct {
 	Table      string   `yaml:"table"`
 	Interfaces []string `yaml:"interfaces"`
 }
 
 // Bridge represents a network bridge
-type bridge struct {
-	OpenvSwitch *openvSwitch `yaml:"openvswitch,omitempty"`
+type Bridge struct {
+	OpenvSwitch *OpenvSwitch `yaml:"openvswitch,omitempty"`
 	Interfaces  []string     `yaml:"interfaces,omitempty"`
 }
 
+// Tunnel represents a VXLAN tunnel device
+type Tunnel struct {
+	Mode          string `yaml:"mode"`
+	ID            int    `yaml:"id"`
+	Local         string `yaml:"local"`
+	Port          int    `yaml:"port,omitempty"`
+	MacLearning   bool   `yaml:"mac-learning"`
+	NeighSuppress bool   `yaml:"neigh-suppress"`
+}
+
 // OpenvSwitch options for a bridge
-type openvSwitch struct {
+type OpenvSwitch struct {
 	FailMode    string            `yaml:"fail-mode,omitempty"`
 	ExternalIDs map[string]string `yaml:"external-ids,omitempty"`
 }
@@ -59,19 +74,20 @@ type openvSwitch struct {
 // NewConfig returns a new Netplan config with default version set.
 func NewConfig() *Config {
 	return &Config{
-		Network: network{
+		Network: Network{
 			Version:          supportedVersion,
-			VirtualEthernets: make(map[string]virtualEthernet),
-			Vrfs:             make(map[string]vrf),
-			Bridges:          make(map[string]bridge),
-			OpenvSwitch:      &openvSwitch{ExternalIDs: make(map[string]string)},
+			VirtualEthernets: make(map[string]VirtualEthernet),
+			Vrfs:             make(map[string]VRF),
+			Bridges:          make(map[string]Bridge),
+			Tunnels:          make(map[string]Tunnel),
+			OpenvSwitch:      &OpenvSwitch{ExternalIDs: make(map[string]string)},
 		},
 	}
 }
 
 // AddVeth adds a veth pair to the config.
 func (c *Config) AddVeth(iface string, peer string, mac string, acceptRa bool, linkLocal []string) {
-	c.Network.VirtualEthernets[iface] = virtualEthernet{
+	c.Network.VirtualEthernets[iface] = VirtualEthernet{
 		Peer:       peer,
 		MacAddress: mac,
 		AcceptRa:   acceptRa,
@@ -79,9 +95,20 @@ func (c *Config) AddVeth(iface string, peer string, mac string, acceptRa bool, l
 	}
 }
 
+// SetAddresses sets static addresses, in CIDR notation, of a veth interface that was previously
+// added to the config.
+func (c *Config) SetAddresses(iface string, addresses []string) {
+	veth, ok := c.Network.VirtualEthernets[iface]
+	if !ok {
+		return
+	}
+	veth.Addresses = addresses
+	c.Network.VirtualEthernets[iface] = veth
+}
+
 // AddVRF adds a VRF with interfaces.
 func (c *Config) AddVRF(name string, table string, ifaces []string) {
-	c.Network.Vrfs[name] = vrf{
+	c.Network.Vrfs[name] = VRF{
 		Table:      table,
 		Interfaces: ifaces,
 	}
@@ -89,12 +116,32 @@ func (c *Config) AddVRF(name string, table string, ifaces []string) {
 
 // AddBridge adds a bridge with interfaces.
 func (c *Config) AddBridge(name string, ifaces []string) {
-	c.Network.Bridges[name] = bridge{
-		OpenvSwitch: &openvSwitch{FailMode: "secure"},
+	c.Network.Bridges[name] = Bridge{
+		OpenvSwitch: &OpenvSwitch{FailMode: "secure"},
 		Interfaces:  ifaces,
 	}
 }
 
+// AddLinuxBridge adds a kernel bridge, not managed by OVS, with interfaces.
+func (c *Config) AddLinuxBridge(name string, ifaces []string) {
+	c.Network.Bridges[name] = Bridge{
+		Interfaces: ifaces,
+	}
+}
+
+// AddVxlan adds a VXLAN tunnel device with VNI "vni" and local tunnel endpoint address "local". MAC
+// learning on the device is disabled, as remote endpoints are learned by the routing daemon.
+func (c *Config) AddVxlan(name string, vni int, local string, port int) {
+	c.Network.Tunnels[name] = Tunnel{
+		Mode:          "vxlan",
+		ID:            vni,
+		Local:         local,
+		Port:          port,
+		MacLearning:   false,
+		NeighSuppress: true,
+	}
+}
+
 // CleaupVirtualEthernets cleansup virtual ethernets represented by this config
 func (c *Config) CleanupVirtualEthernets(ctx context.Context) error {
 	deletedPeers := map[string]bool{}
@@ -114,6 +161,27 @@ func (c *Config) CleanupVirtualEthernets(ctx context.Context) error {
 	return nil
 }
 
+// CleanupTunnels removes tunnel devices and bridges that are not managed by OVS represented by this config
+func (c *Config) CleanupTunnels(ctx context.Context) error {
+	var ifaces []string
+	for iface := range c.Network.Tunnels {
+		ifaces = append(ifaces, iface)
+	}
+	for iface, bridgeData := range c.Network.Bridges {
+		if bridgeData.OpenvSwitch == nil {
+			ifaces = append(ifaces, iface)
+		}
+	}
+
+	for _, iface := range ifaces {
+		_, err := shared.RunCommandContext(ctx, "ip", "link", "delete", "dev", iface)
+		if err != nil && !strings.Contains(err.Error(), "Cannot find device") {
+			return fmt.Errorf("failed remove interface '%s': %v", iface, err)
+		}
+	}
+	return nil
+}
+
 // CleanupVRFs cleans up the vrfs represented in the config, and will delete them
 // depending on the "delete" argument
 func (c *Config) CleanupVRFs(ctx context.Context, delete bool) error {
@@ -123,7 +191,7 @@ func (c *Config) CleanupVRFs(ctx context.Context, delete bool) error {
 			return fmt.Errorf("failed to flush vrf '%s': %v", vrf, err)
 		}
 		if delete {
-			_, err = shared.RunCommandContext(ctx, "ip", "link ", "delete", "dev", vrf)
+			_, err = shared.RunCommandContext(ctx, "ip", "link", "delete", "dev", vrf)
 			if err != nil {
 				return fmt.Errorf("failed to delete vrf '%s': %v", vrf, err)
 			}
@@ -133,8 +201,23 @@ func (c *Config) CleanupVRFs(ctx context.Context, delete bool) error {
 }
 
 // WriteToNetplan writes a file and then moves it to the netplan directory, due
-// to permissions issues writing to /etc/netplan directly fails
+// to permissions issues writing to /etc/netplan directly fails. Changes against
+// the current file are logged, and interfaces that the file creates are recorded
+// as owned by MicroOVN before it's written.
 func WriteToNetplan(ctx context.Context, filename string, config Config) error {
+	diff, err := FileDiff(filename, &config)
+	if err != nil {
+		return err
+	}
+	if diff != "" {
+		logger.Infof("Changing netplan configuration '%s':\n%s", filename, diff)
+	}
+
+	err = RecordOwnership(filename, config)
+	if err != nil {
+		return err
+	}
+
 	data, err := yaml.Marshal(&config)
 	if err != nil {
 		return err
@@ -145,7 +228,7 @@ func WriteToNetplan(ctx context.Context, filename string, config Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to write file: %v", err)
 	}
-	_, err = shared.RunCommandContext(ctx, "mv", filepath, "/etc/netplan")
+	_, err = shared.RunCommandContext(ctx, "mv", filepath, ConfigDir)
 	return err
 }
 
@@ -179,8 +262,16 @@ func LoadConfig(filename string) (*Config, error) {
 	return &cfg, nil
 }
 
+// ConfigExists returns "true" if netplan configuration file "filename" is present in /etc/netplan
+func ConfigExists(filename string) bool {
+	_, err := os.Stat(fmt.Sprintf("%s/%s", ConfigDir, filename))
+	return err == nil
+}
+
+// Cleanup removes netplan configuration file "filename" and interfaces that it created. Only interfaces
+// owned by MicroOVN are removed, see OwnedConfig.
 func Cleanup(ctx context.Context, filename string) error {
-	filepath := fmt.Sprintf("/etc/netplan/%s", filename)
+	filepath := fmt.Sprintf("%s/%s", ConfigDir, filename)
 	tmpfilepath := fmt.Sprintf("/tmp/%s", filename)
 
 	if _, err := os.Stat(filepath); os.IsNotExist(err) {
@@ -197,10 +288,20 @@ func Cleanup(ctx context.Context, filename string) error {
 	if err != nil {
 		return fmt.Errorf("cannot read netplan config: %v", err)
 	} else {
+		owned, err := OwnedConfig(filename, *netplanFile)
+		if err != nil {
+			return err
+		}
+		netplanFile = &owned
+
 		err = netplanFile.CleanupVirtualEthernets(ctx)
 		if err != nil {
 			return fmt.Errorf("virtual ethernets cleanup failed: %v", err)
 		}
+		err = netplanFile.CleanupTunnels(ctx)
+		if err != nil {
+			return fmt.Errorf("tunnels cleanup failed: %v", err)
+		}
 		err = netplanFile.CleanupVRFs(ctx, false)
 		if err != nil {
 			return fmt.Errorf("VRF cleanup failed: %v", err)
@@ -212,5 +313,10 @@ func Cleanup(ctx context.Context, filename string) error {
 		return fmt.Errorf("failed to delete netplan config: %v", err)
 	}
 
+	err = ForgetOwnership(filename)
+	if err != nil {
+		return err
+	}
+
 	return Apply(ctx)
 }
@@ -1,6 +1,13 @@
 package netplan
 
-import "testing"
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
 
 func TestNewConfig_Defaults(t *testing.T) {
 	cfg := NewConfig()
@@ -88,3 +95,137 @@ func TestAddMethods(t *testing.T) {
 	}
 
 }
+
+func TestSetAddresses(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SetAddresses("veth413", []string{"192.0.2.1/24"})
+	if _, ok := cfg.Network.VirtualEthernets["veth413"]; ok {
+		t.Errorf("unexpected veth413 in config")
+	}
+
+	cfg.AddVeth("veth413", "veth612", "a1:b2:c3:d4:e5:f6", false, []string{"ipv6"})
+	cfg.SetAddresses("veth413", []string{"192.0.2.1/24", "2001:db8::1/64"})
+
+	veth := cfg.Network.VirtualEthernets["veth413"]
+	if len(veth.Addresses) != 2 {
+		t.Errorf("expected 2 addresses on veth413, got %d", len(veth.Addresses))
+	}
+	if veth.Peer != "veth612" {
+		t.Errorf("peer does not match expected")
+	}
+}
+
+func TestAddVxlan(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddVxlan("ovnvx100", 100, "192.0.2.1", 4789)
+	cfg.AddLinuxBridge("ovnvxbr100", []string{"ovnvx100"})
+
+	vxlan, ok := cfg.Network.Tunnels["ovnvx100"]
+	if !ok {
+		t.Fatalf("expected ovnvx100 in config")
+	}
+	if vxlan.Mode != "vxlan" || vxlan.ID != 100 || vxlan.Local != "192.0.2.1" || vxlan.Port != 4789 {
+		t.Errorf("unexpected tunnel %+v", vxlan)
+	}
+	if vxlan.MacLearning || !vxlan.NeighSuppress {
+		t.Errorf("expected MAC learning to be disabled and neighbor suppression enabled")
+	}
+
+	if cfg.Network.Bridges["ovnvxbr100"].OpenvSwitch != nil {
+		t.Errorf("expected ovnvxbr100 not to be managed by OVS")
+	}
+	if len(cfg.Network.Bridges["ovnvxbr100"].Interfaces) != 1 {
+		t.Errorf("expected 1 item in ovnvxbr100 Interfaces")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddVRF("ovnvrf10", "10", []string{"ovnbgp1"})
+
+	diff, err := Diff(nil, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		if !strings.HasPrefix(line, "+") {
+			t.Errorf("expected only added lines in diff of new config, got: %s", line)
+		}
+	}
+
+	current, err := yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	diff, _ = Diff(current, cfg)
+	if diff != "" {
+		t.Errorf("expected no diff of unchanged config, got:\n%s", diff)
+	}
+
+	cfg.AddVRF("ovnvrf10", "10", []string{"ovnbgp2"})
+	diff, _ = Diff(current, cfg)
+	if !strings.Contains(diff, "\n-                - ovnbgp1\n+                - ovnbgp2\n") ||
+		!strings.HasPrefix(diff, " network:\n") {
+
+		t.Errorf("unexpected diff of changed config:\n%s", diff)
+	}
+
+	diff, _ = Diff(current, nil)
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		if !strings.HasPrefix(line, "-") {
+			t.Errorf("expected only removed lines in diff of removed config, got: %s", line)
+		}
+	}
+}
+
+func TestManifestRecord(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddVeth("ovnbgp1", "ovnbgp1-brg", "", false, nil)
+	cfg.AddVeth("ovnbgp1-brg", "ovnbgp1", "", false, nil)
+	cfg.AddVRF("ovnvrf10", "10", []string{"ovnbgp1"})
+	cfg.AddBridge("br-int", []string{"ovnbgp1-brg"})
+
+	// VRF created by the administrator is not owned
+	existing := []string{"ovnvrf10", "br-int"}
+	m := &manifest{Files: map[string][]string{}}
+	m.record("90-test.yaml", *cfg, func(iface string) bool { return slices.Contains(existing, iface) })
+
+	expected := []string{"ovnbgp1", "ovnbgp1-brg"}
+	if !reflect.DeepEqual(m.Files["90-test.yaml"], expected) {
+		t.Errorf("expected owned interfaces %v, got %v", expected, m.Files["90-test.yaml"])
+	}
+
+	// Owned interfaces stay owned when the config is applied again, removed ones are forgotten
+	existing = append(existing, "ovnbgp1", "ovnbgp1-brg")
+	delete(cfg.Network.VirtualEthernets, "ovnbgp1-brg")
+	m.record("90-test.yaml", *cfg, func(iface string) bool { return slices.Contains(existing, iface) })
+	if !reflect.DeepEqual(m.Files["90-test.yaml"], []string{"ovnbgp1"}) {
+		t.Errorf("expected owned interfaces [ovnbgp1], got %v", m.Files["90-test.yaml"])
+	}
+}
+
+func TestOwnedConfig(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AddVeth("ovnbgp1", "ovnbgp1-brg", "", false, nil)
+	cfg.AddVRF("ovnvrf10", "10", []string{"ovnbgp1"})
+	cfg.AddBridge("br-int", []string{"ovnbgp1-brg"})
+	cfg.AddVxlan("ovnvx100", 100, "192.0.2.10", 4789)
+	cfg.AddLinuxBridge("ovnvxbr100", []string{"ovnvx100"})
+
+	owned := cfg.owned([]string{"ovnbgp1", "ovnvx100"})
+	if _, ok := owned.Network.Vrfs["ovnvrf10"]; ok {
+		t.Errorf("expected VRF that is not owned to be left out")
+	}
+	if _, ok := owned.Network.Bridges["ovnvxbr100"]; ok {
+		t.Errorf("expected bridge that is not owned to be left out")
+	}
+	if _, ok := owned.Network.VirtualEthernets["ovnbgp1"]; !ok {
+		t.Errorf("expected owned veth to be kept")
+	}
+	if _, ok := owned.Network.Tunnels["ovnvx100"]; !ok {
+		t.Errorf("expected owned tunnel to be kept")
+	}
+	if _, ok := owned.Network.Bridges["br-int"]; !ok {
+		t.Errorf("expected OVS bridge to be kept")
+	}
+}
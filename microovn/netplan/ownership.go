@@ -0,0 +1,169 @@
+package netplan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/logger"
+	"gopkg.in/yaml.v3"
+
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// manifest - record of network configuration files written by MicroOVN and of the interfaces that were
+// created by applying them. Interfaces that already existed when the configuration was applied, e.g.
+// a VRF created by the administrator with a coincidentally matching name, are not owned by MicroOVN
+// and are never removed by it.
+type manifest struct {
+	Files map[string][]string `yaml:"files"`
+}
+
+// interfaces returns names of interfaces that can be created by "config". OVS bridges are not included,
+// they are only referenced to plug ports into them.
+func (c *Config) interfaces() []string {
+	var ifaces []string
+	for iface := range c.Network.VirtualEthernets {
+		ifaces = append(ifaces, iface)
+	}
+	for iface := range c.Network.Vrfs {
+		ifaces = append(ifaces, iface)
+	}
+	for iface := range c.Network.Tunnels {
+		ifaces = append(ifaces, iface)
+	}
+	for iface, bridge := range c.Network.Bridges {
+		if bridge.OpenvSwitch == nil {
+			ifaces = append(ifaces, iface)
+		}
+	}
+
+	slices.Sort(ifaces)
+	return ifaces
+}
+
+// owned returns copy of "config" that contains only interfaces "ifaces", and all OVS bridges.
+func (c *Config) owned(ifaces []string) Config {
+	owned := *NewConfig()
+	owned.Network.OpenvSwitch = c.Network.OpenvSwitch
+	for iface, veth := range c.Network.VirtualEthernets {
+		if slices.Contains(ifaces, iface) {
+			owned.Network.VirtualEthernets[iface] = veth
+		}
+	}
+	for iface, vrf := range c.Network.Vrfs {
+		if slices.Contains(ifaces, iface) {
+			owned.Network.Vrfs[iface] = vrf
+		}
+	}
+	for iface, tunnel := range c.Network.Tunnels {
+		if slices.Contains(ifaces, iface) {
+			owned.Network.Tunnels[iface] = tunnel
+		}
+	}
+	for iface, bridge := range c.Network.Bridges {
+		if bridge.OpenvSwitch != nil || slices.Contains(ifaces, iface) {
+			owned.Network.Bridges[iface] = bridge
+		}
+	}
+	return owned
+}
+
+// record updates ownership of interfaces of configuration "filename" that is about to be applied with
+// "config". Interface is owned if it was owned before, or if it does not exist yet.
+func (m *manifest) record(filename string, config Config, exists func(string) bool) {
+	previous := m.Files[filename]
+	var owned []string
+	for _, iface := range config.interfaces() {
+		if slices.Contains(previous, iface) || !exists(iface) {
+			owned = append(owned, iface)
+		}
+	}
+
+	m.Files[filename] = owned
+}
+
+// loadManifest reads the ownership manifest, empty manifest is returned if it does not exist yet.
+func loadManifest() (*manifest, error) {
+	m := &manifest{Files: make(map[string][]string)}
+	data, err := os.ReadFile(paths.NetworkManifestFile())
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network manifest: %v", err)
+	}
+
+	err = yaml.Unmarshal(data, m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode network manifest: %v", err)
+	}
+	if m.Files == nil {
+		m.Files = make(map[string][]string)
+	}
+	return m, nil
+}
+
+// save writes the ownership manifest.
+func (m *manifest) save() error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(paths.NetworkManifestFile(), data, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write network manifest: %v", err)
+	}
+	return nil
+}
+
+// interfaceExists returns "true" if network interface "iface" exists on the local system.
+func interfaceExists(iface string) bool {
+	return shared.PathExists(filepath.Join("/sys/class/net", iface))
+}
+
+// RecordOwnership records configuration "filename" as owned by MicroOVN, along with interfaces of "config"
+// that do not exist yet. It must be called before the configuration is applied.
+func RecordOwnership(filename string, config Config) error {
+	m, err := loadManifest()
+	if err != nil {
+		return err
+	}
+
+	m.record(filename, config, interfaceExists)
+	return m.save()
+}
+
+// OwnedConfig returns copy of "config", read from configuration "filename", that contains only interfaces
+// owned by MicroOVN. Configuration that was written before ownership was tracked is returned whole.
+func OwnedConfig(filename string, config Config) (Config, error) {
+	m, err := loadManifest()
+	if err != nil {
+		return config, err
+	}
+
+	ifaces, ok := m.Files[filename]
+	if !ok {
+		logger.Warnf("Ownership of interfaces in network configuration '%s' is not known, assuming they are owned by MicroOVN", filename)
+		return config, nil
+	}
+
+	return config.owned(ifaces), nil
+}
+
+// ForgetOwnership removes configuration "filename" and its interfaces from the ownership manifest.
+func ForgetOwnership(filename string) error {
+	m, err := loadManifest()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := m.Files[filename]; !ok {
+		return nil
+	}
+	delete(m.Files, filename)
+	return m.save()
+}
@@ -0,0 +1,456 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/state"
+	"golang.org/x/sys/unix"
+	"gopkg.in/yaml.v3"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/netplan"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// netlinkBackend - creates interfaces directly over rtnetlink, without netplan. Applied configuration is
+// recorded in MicroOVN's data directory, so that its interfaces can be removed later. Unlike netplan,
+// it does not touch any other interfaces of the system.
+type netlinkBackend struct{}
+
+// netlinkConfigFile returns path to the record of configuration "name" applied by the netlink backend.
+func netlinkConfigFile(name string) string {
+	return filepath.Join(paths.NetworkConfigDir(), name)
+}
+
+// Name returns name of the network backend
+func (netlinkBackend) Name() string {
+	return types.NetworkBackendNetlink
+}
+
+// Available returns nil, netlink is available on every Linux system
+func (netlinkBackend) Available() error {
+	return nil
+}
+
+// Apply records "config" as configuration "name" and creates its interfaces. Interfaces are created
+// in the order in which they depend on each other: VRFs, veths and tunnels first, then bridges, their
+// ports and VRF members, and at last they are all brought up. Existing interfaces are reused, but they
+// are not owned by MicroOVN.
+func (b netlinkBackend) Apply(ctx context.Context, s state.State, name string, config netplan.Config) error {
+	diff, err := b.Diff(name, &config)
+	if err != nil {
+		return err
+	}
+	if diff != "" {
+		logger.Infof("Changing network configuration '%s':\n%s", name, diff)
+	}
+
+	// Interfaces that are no longer in the configuration are removed before its record is replaced,
+	// ownership of those interfaces would be lost otherwise
+	if shared.PathExists(netlinkConfigFile(name)) {
+		previous, err := netplan.LoadConfig(netlinkConfigFile(name))
+		if err != nil {
+			return fmt.Errorf("cannot read network configuration: %v", err)
+		}
+
+		err = removeStaleLinks(name, *previous, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Configuration is recorded first, so that partially created interfaces can be removed
+	err = netplan.RecordOwnership(name, config)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(&config)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(netlinkConfigFile(name), data, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to record network configuration '%s': %v", name, err)
+	}
+
+	network := config.Network
+	var ifaces []string
+
+	for vrfName, vrf := range network.Vrfs {
+		table, err := strconv.ParseUint(vrf.Table, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid table of VRF '%s': %s", vrfName, vrf.Table)
+		}
+
+		err = linkCreate(vrfName, "vrf", []netlinkAttribute{uint32Attribute(unix.IFLA_VRF_TABLE, uint32(table))})
+		if err != nil {
+			return err
+		}
+		ifaces = append(ifaces, vrfName)
+	}
+
+	for vethName, veth := range network.VirtualEthernets {
+		err = applyVeth(vethName, veth)
+		if err != nil {
+			return err
+		}
+		ifaces = append(ifaces, vethName)
+	}
+
+	for tunnelName, tunnel := range network.Tunnels {
+		err = applyTunnel(tunnelName, tunnel)
+		if err != nil {
+			return err
+		}
+		ifaces = append(ifaces, tunnelName)
+	}
+
+	for bridgeName, bridge := range network.Bridges {
+		if bridge.OpenvSwitch != nil {
+			err = addOvsPorts(ctx, s, bridgeName, bridge.Interfaces)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = applyLinuxBridge(bridgeName, bridge, network.Tunnels)
+		if err != nil {
+			return err
+		}
+		ifaces = append(ifaces, bridgeName)
+	}
+
+	for vrfName, vrf := range network.Vrfs {
+		for _, iface := range vrf.Interfaces {
+			err = linkSetMaster(iface, vrfName)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, iface := range ifaces {
+		err = linkSetUp(iface)
+		if err != nil {
+			return err
+		}
+	}
+
+	if network.OpenvSwitch != nil && len(network.OpenvSwitch.ExternalIDs) > 0 {
+		return setOvsExternalIDs(ctx, s, network.OpenvSwitch.ExternalIDs)
+	}
+
+	return nil
+}
+
+// applyVeth creates veth "vethName", along with its peer, and configures its MAC address, static
+// addresses and acceptance of IPv6 router advertisements. IPv6 link-local addresses are left to the
+// kernel, which generates them for every interface that is up.
+func applyVeth(vethName string, veth netplan.VirtualEthernet) error {
+	_, err := net.InterfaceByName(vethName)
+	if err != nil {
+		err = linkCreateVeth(vethName, veth.Peer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if veth.MacAddress != "" {
+		mac, err := net.ParseMAC(veth.MacAddress)
+		if err != nil {
+			return fmt.Errorf("invalid MAC address of interface '%s': %s", vethName, veth.MacAddress)
+		}
+
+		err = linkSet(vethName, netlinkAttribute{Type: unix.IFLA_ADDRESS, Value: mac})
+		if err != nil {
+			return err
+		}
+	}
+
+	acceptRa := "0"
+	if veth.AcceptRa {
+		acceptRa = "1"
+	}
+	sysctl := fmt.Sprintf("/proc/sys/net/ipv6/conf/%s/accept_ra", vethName)
+	if shared.PathExists(sysctl) {
+		err = os.WriteFile(sysctl, []byte(acceptRa), 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to configure router advertisements of interface '%s': %v", vethName, err)
+		}
+	}
+
+	for _, address := range veth.Addresses {
+		prefix, err := netip.ParsePrefix(address)
+		if err != nil {
+			return fmt.Errorf("invalid address of interface '%s': %s", vethName, address)
+		}
+
+		err = addrAdd(vethName, prefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyTunnel creates VXLAN tunnel device "tunnelName".
+func applyTunnel(tunnelName string, tunnel netplan.Tunnel) error {
+	if tunnel.Mode != "vxlan" {
+		return fmt.Errorf("unsupported mode of tunnel '%s': %s", tunnelName, tunnel.Mode)
+	}
+
+	local, err := netip.ParseAddr(tunnel.Local)
+	if err != nil {
+		return fmt.Errorf("invalid local address of tunnel '%s': %s", tunnelName, tunnel.Local)
+	}
+
+	learning := uint8(0)
+	if tunnel.MacLearning {
+		learning = 1
+	}
+
+	localType := uint16(unix.IFLA_VXLAN_LOCAL)
+	if local.Is6() {
+		localType = unix.IFLA_VXLAN_LOCAL6
+	}
+
+	data := []netlinkAttribute{
+		uint32Attribute(unix.IFLA_VXLAN_ID, uint32(tunnel.ID)),
+		{Type: localType, Value: local.AsSlice()},
+		uint8Attribute(unix.IFLA_VXLAN_LEARNING, learning),
+	}
+	if tunnel.Port != 0 {
+		// Port is the only attribute in the network byte order
+		data = append(data, netlinkAttribute{Type: unix.IFLA_VXLAN_PORT, Value: []byte{byte(tunnel.Port >> 8), byte(tunnel.Port)}})
+	}
+
+	return linkCreate(tunnelName, "vxlan", data)
+}
+
+// applyLinuxBridge creates kernel bridge "bridgeName" and enslaves its interfaces to it. Bridge ports
+// of "tunnels" get MAC learning and neighbor suppression configured as the tunnel requires.
+func applyLinuxBridge(bridgeName string, bridge netplan.Bridge, tunnels map[string]netplan.Tunnel) error {
+	err := linkCreate(bridgeName, "bridge", nil)
+	if err != nil {
+		return err
+	}
+
+	for _, iface := range bridge.Interfaces {
+		err = linkSetMaster(iface, bridgeName)
+		if err != nil {
+			return err
+		}
+
+		tunnel, ok := tunnels[iface]
+		if !ok {
+			continue
+		}
+
+		learning, neighSuppress := uint8(0), uint8(0)
+		if tunnel.MacLearning {
+			learning = 1
+		}
+		if tunnel.NeighSuppress {
+			neighSuppress = 1
+		}
+		err = bridgePortSet(iface,
+			uint8Attribute(unix.IFLA_BRPORT_LEARNING, learning),
+			uint8Attribute(unix.IFLA_BRPORT_NEIGH_SUPPRESS, neighSuppress),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addOvsPorts adds ports "ifaces" to the OVS bridge "bridgeName". Ports that already exist are left
+// untouched.
+func addOvsPorts(ctx context.Context, s state.State, bridgeName string, ifaces []string) error {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	bridgeRow := []ovsdbclient.Condition{ovsdbclient.Equal("name", bridgeName)}
+	operations := []ovsdbclient.Operation{ovsdbclient.AssertPresent(ovsdbclient.TableBridge, bridgeRow...)}
+	for _, iface := range ifaces {
+		ports, err := ovsdbclient.Find[ovsdbclient.Port](ctx, ovs, ovsdbclient.TablePort, ovsdbclient.Equal("name", iface))
+		if err != nil {
+			return fmt.Errorf("failed to lookup OVS Port '%s': %v", iface, err)
+		}
+		if len(ports) != 0 {
+			continue
+		}
+
+		ifaceRef := "iface_" + iface
+		portRef := "port_" + iface
+		operations = append(operations,
+			ovsdbclient.Insert(ovsdbclient.TableInterface, map[string]any{"name": iface}, ifaceRef),
+			ovsdbclient.Insert(ovsdbclient.TablePort, map[string]any{
+				"name":       iface,
+				"interfaces": ovsdbclient.NamedUUID(ifaceRef),
+			}, portRef),
+			ovsdbclient.Mutate(ovsdbclient.TableBridge, bridgeRow,
+				ovsdbclient.SetInsert("ports", ovsdbclient.NamedUUID(portRef)),
+			),
+		)
+	}
+
+	_, err = ovs.Transact(ctx, operations...)
+	if err != nil {
+		return fmt.Errorf("failed to add ports to OVS bridge '%s': %v", bridgeName, err)
+	}
+	return nil
+}
+
+// setOvsExternalIDs sets "externalIDs" keys in the external_ids of the Open_vSwitch table.
+func setOvsExternalIDs(ctx context.Context, s state.State, externalIDs map[string]string) error {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	rows, err := ovsdbclient.Find[ovsdbclient.OpenvSwitch](ctx, ovs, ovsdbclient.TableOpenvSwitch)
+	if err != nil {
+		return err
+	}
+	if len(rows) != 1 {
+		return fmt.Errorf("expected single row in Open_vSwitch table, found %d", len(rows))
+	}
+
+	_, err = ovs.Transact(ctx, ovsdbclient.SetMapKeys(ovsdbclient.TableOpenvSwitch,
+		[]ovsdbclient.Condition{ovsdbclient.HasUUID(rows[0].UUID)}, "external_ids", externalIDs))
+	if err != nil {
+		return fmt.Errorf("failed to set OVS external IDs: %v", err)
+	}
+	return nil
+}
+
+// Diff returns changes between the record of configuration "name" and "config"
+func (netlinkBackend) Diff(name string, config *netplan.Config) (string, error) {
+	current, err := os.ReadFile(netlinkConfigFile(name))
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read network configuration '%s': %v", name, err)
+	}
+
+	return netplan.Diff(current, config)
+}
+
+// Cleanup removes veths, tunnels, kernel bridges and VRFs of configuration "name" that are owned by
+// MicroOVN, and its record. Ports of OVS bridges are left to their owner.
+func (netlinkBackend) Cleanup(_ context.Context, name string) error {
+	configFile := netlinkConfigFile(name)
+	if !shared.PathExists(configFile) {
+		return nil
+	}
+
+	recorded, err := netplan.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("cannot read network configuration: %v", err)
+	}
+	config, err := netplan.OwnedConfig(name, *recorded)
+	if err != nil {
+		return err
+	}
+
+	err = deleteLinks(linkNames(config))
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(configFile)
+	if err != nil {
+		return err
+	}
+	return netplan.ForgetOwnership(name)
+}
+
+// linkNames returns names of veths, tunnels, kernel bridges and VRFs of "config", in the order in which
+// they can be deleted. Only one end of each veth pair is included, deleting it deletes the other one
+// as well.
+func linkNames(config netplan.Config) []string {
+	var ifaces []string
+	for vethName, veth := range config.Network.VirtualEthernets {
+		if !slices.Contains(ifaces, veth.Peer) {
+			ifaces = append(ifaces, vethName)
+		}
+	}
+	for tunnelName := range config.Network.Tunnels {
+		ifaces = append(ifaces, tunnelName)
+	}
+	for bridgeName, bridge := range config.Network.Bridges {
+		if bridge.OpenvSwitch == nil {
+			ifaces = append(ifaces, bridgeName)
+		}
+	}
+	for vrfName := range config.Network.Vrfs {
+		ifaces = append(ifaces, vrfName)
+	}
+	return ifaces
+}
+
+// deleteLinks deletes network interfaces "ifaces", interfaces that do not exist are ignored.
+func deleteLinks(ifaces []string) error {
+	var allErrors error
+	for _, iface := range ifaces {
+		err := linkDelete(iface)
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+		}
+	}
+	return allErrors
+}
+
+// staleLinks returns names of interfaces of the "previous" configuration that are not in "config", in
+// the order in which they can be deleted.
+func staleLinks(previous netplan.Config, config netplan.Config) []string {
+	current := linkNames(config)
+	var stale []string
+	for _, iface := range linkNames(previous) {
+		// Veth pair is kept if any of its ends is still in the configuration
+		_, keptVeth := config.Network.VirtualEthernets[iface]
+		_, keptPeer := config.Network.VirtualEthernets[previous.Network.VirtualEthernets[iface].Peer]
+		if keptVeth || keptPeer || slices.Contains(current, iface) {
+			continue
+		}
+		stale = append(stale, iface)
+	}
+	return stale
+}
+
+// removeStaleLinks deletes interfaces of the previously applied configuration "name", "previous", that
+// are owned by MicroOVN and that are not in "config".
+func removeStaleLinks(name string, previous netplan.Config, config netplan.Config) error {
+	owned, err := netplan.OwnedConfig(name, previous)
+	if err != nil {
+		return err
+	}
+
+	stale := staleLinks(owned, config)
+	if len(stale) != 0 {
+		logger.Infof("Removing interfaces that are no longer in network configuration '%s': %v", name, stale)
+	}
+	return deleteLinks(stale)
+}
+
+// Exists returns "true" if configuration "name" was applied by the netlink backend
+func (netlinkBackend) Exists(name string) bool {
+	return shared.PathExists(netlinkConfigFile(name))
+}
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/netplan"
+)
+
+// netplanBackend - writes configuration into /etc/netplan and lets netplan create the interfaces
+type netplanBackend struct{}
+
+// Name returns name of the network backend
+func (netplanBackend) Name() string {
+	return types.NetworkBackendNetplan
+}
+
+// Available returns error if the local system is not managed by netplan
+func (netplanBackend) Available() error {
+	if !shared.PathExists(netplan.ConfigDir) {
+		return fmt.Errorf("netplan configuration directory '%s' does not exist, the system is not managed by netplan", netplan.ConfigDir)
+	}
+	return nil
+}
+
+// Apply writes "config" into netplan file "name" and applies all netplan configuration. Netplan does not
+// remove interfaces that are no longer in its configuration, they are removed directly.
+func (netplanBackend) Apply(ctx context.Context, _ state.State, name string, config netplan.Config) error {
+	if netplan.ConfigExists(name) {
+		previous, err := netplan.LoadConfig(filepath.Join(netplan.ConfigDir, name))
+		if err != nil {
+			return fmt.Errorf("cannot read netplan config: %v", err)
+		}
+
+		err = removeStaleLinks(name, *previous, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := netplan.WriteToNetplan(ctx, name, config)
+	if err != nil {
+		return err
+	}
+
+	return netplan.Apply(ctx)
+}
+
+// Diff returns changes between netplan file "name" and "config"
+func (netplanBackend) Diff(name string, config *netplan.Config) (string, error) {
+	return netplan.FileDiff(name, config)
+}
+
+// Cleanup removes interfaces of netplan file "name" and the file itself
+func (netplanBackend) Cleanup(ctx context.Context, name string) error {
+	return netplan.Cleanup(ctx, name)
+}
+
+// Exists returns "true" if netplan file "name" exists
+func (netplanBackend) Exists(name string) bool {
+	return netplan.ConfigExists(name)
+}
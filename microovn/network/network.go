@@ -0,0 +1,78 @@
+// Package network implements backends that create network interfaces used by the BGP integration
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/netplan"
+)
+
+// Backend creates and removes veths, VRFs, bridges and tunnels described by netplan configuration. Each
+// configuration is identified by its name, the name of the netplan file.
+type Backend interface {
+	// Name returns name of the backend, as used in the "network_backend" BGP config option
+	Name() string
+	// Available returns error if the backend can not be used on the local system
+	Available() error
+	// Apply creates interfaces described by "config" and records them as configuration "name". Interfaces
+	// of the previously applied configuration "name" that are owned by MicroOVN and that are not in
+	// "config" are removed.
+	Apply(ctx context.Context, s state.State, name string, config netplan.Config) error
+	// Diff returns changes that applying "config" as configuration "name" would make, in the format of
+	// netplan.Diff. Nil "config" stands for removal of the configuration.
+	Diff(name string, config *netplan.Config) (string, error)
+	// Cleanup removes interfaces of configuration "name" that are owned by MicroOVN, it does nothing
+	// if the configuration does not exist
+	Cleanup(ctx context.Context, name string) error
+	// Exists returns "true" if configuration "name" was applied by the backend
+	Exists(name string) bool
+}
+
+// backends lists all supported network backends.
+var backends = []Backend{
+	netplanBackend{},
+	netlinkBackend{},
+}
+
+// GetBackend returns network backend "name". Netplan is used if the name is empty.
+func GetBackend(name string) (Backend, error) {
+	if name == "" {
+		name = types.NetworkBackendNetplan
+	}
+
+	for _, backend := range backends {
+		if backend.Name() == name {
+			return backend, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown network backend: %s", name)
+}
+
+// Cleanup removes interfaces of configuration "name" applied by any backend, so that resources can be
+// removed even if the backend that created them is not known.
+func Cleanup(ctx context.Context, name string) error {
+	var allErrors error
+	for _, backend := range backends {
+		err := backend.Cleanup(ctx, name)
+		if err != nil {
+			allErrors = errors.Join(allErrors, fmt.Errorf("%s: %w", backend.Name(), err))
+		}
+	}
+	return allErrors
+}
+
+// ConfigExists returns "true" if configuration "name" was applied by any backend.
+func ConfigExists(name string) bool {
+	for _, backend := range backends {
+		if backend.Exists(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package network
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/netplan"
+)
+
+func TestGetBackend(t *testing.T) {
+	for name, expected := range map[string]string{
+		"":                          types.NetworkBackendNetplan,
+		types.NetworkBackendNetplan: types.NetworkBackendNetplan,
+		types.NetworkBackendNetlink: types.NetworkBackendNetlink,
+	} {
+		backend, err := GetBackend(name)
+		if err != nil {
+			t.Fatalf("unexpected error for '%s': %s", name, err)
+		}
+		if backend.Name() != expected {
+			t.Errorf("expected backend '%s' for '%s', got '%s'", expected, name, backend.Name())
+		}
+	}
+
+	_, err := GetBackend("ifupdown")
+	if err == nil {
+		t.Errorf("expected error for unknown backend")
+	}
+}
+
+func TestNetlinkAttributeEncode(t *testing.T) {
+	// Length does not include padding, the attribute itself is padded to 4 bytes
+	encoded := stringAttribute(unix.IFLA_IFNAME, "eth1").encode()
+	expected := []byte{9, 0, unix.IFLA_IFNAME, 0, 'e', 't', 'h', '1', 0, 0, 0, 0}
+	if !bytes.Equal(encoded, expected) {
+		t.Errorf("expected %v, got %v", expected, encoded)
+	}
+
+	encoded = uint32Attribute(unix.IFLA_MASTER, 7).encode()
+	if len(encoded) != 8 || binary.NativeEndian.Uint32(encoded[4:8]) != 7 {
+		t.Errorf("unexpected encoding of 32-bit attribute: %v", encoded)
+	}
+}
+
+func TestNetlinkAttributeEncodeNested(t *testing.T) {
+	child := uint8Attribute(unix.IFLA_BRPORT_LEARNING, 1)
+	encoded := nestedAttribute(unix.IFLA_PROTINFO, child).encode()
+	if binary.NativeEndian.Uint16(encoded[0:2]) != 12 {
+		t.Errorf("expected length 12, got %d", binary.NativeEndian.Uint16(encoded[0:2]))
+	}
+	if binary.NativeEndian.Uint16(encoded[2:4]) != unix.IFLA_PROTINFO|unix.NLA_F_NESTED {
+		t.Errorf("expected nested flag in attribute type")
+	}
+	if !bytes.Equal(encoded[4:], child.encode()) {
+		t.Errorf("expected encoded child %v, got %v", child.encode(), encoded[4:])
+	}
+
+	// Nested attribute with a header, e.g. the peer of a veth, is not flagged
+	peer := netlinkAttribute{Type: vethInfoPeer, Value: ifInfoMsg(unix.AF_UNSPEC, 0, 0, 0), Children: []netlinkAttribute{child}}
+	encoded = peer.encode()
+	if binary.NativeEndian.Uint16(encoded[2:4]) != vethInfoPeer {
+		t.Errorf("expected attribute without nested flag")
+	}
+	if len(encoded) != unix.SizeofRtAttr+unix.SizeofIfInfomsg+len(child.encode()) {
+		t.Errorf("unexpected length of attribute with header: %d", len(encoded))
+	}
+}
+
+func TestStaleLinks(t *testing.T) {
+	previous := netplan.NewConfig()
+	previous.AddVeth("v1-bgp", "v1-brg", "", false, nil)
+	previous.AddVeth("v1-brg", "v1-bgp", "", false, nil)
+	previous.AddVeth("v2-bgp", "v2-brg", "", false, nil)
+	previous.AddVeth("v2-brg", "v2-bgp", "", false, nil)
+	previous.AddVRF("vrf10", "10", []string{"v1-bgp", "v2-bgp"})
+
+	config := netplan.NewConfig()
+	config.AddVeth("v1-bgp", "v1-brg", "", false, nil)
+	config.AddVeth("v1-brg", "v1-bgp", "", false, nil)
+	config.AddVRF("vrf10", "10", []string{"v1-bgp"})
+
+	stale := staleLinks(*previous, *config)
+	if len(stale) != 1 || !slices.Contains([]string{"v2-bgp", "v2-brg"}, stale[0]) {
+		t.Errorf("expected one end of the removed veth pair to be stale, got %v", stale)
+	}
+
+	config.AddVRF("vrf20", "20", []string{"v1-bgp"})
+	delete(config.Network.Vrfs, "vrf10")
+	stale = staleLinks(*previous, *config)
+	if !slices.Contains(stale, "vrf10") || slices.Contains(stale, "v1-bgp") || slices.Contains(stale, "v1-brg") {
+		t.Errorf("expected replaced VRF to be stale, got %v", stale)
+	}
+
+	if stale = staleLinks(*previous, *previous); len(stale) != 0 {
+		t.Errorf("expected no stale interfaces, got %v", stale)
+	}
+}
@@ -0,0 +1,277 @@
+package network
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// vethInfoPeer - attribute of the veth link info that describes the peer interface (VETH_INFO_PEER)
+const vethInfoPeer = 1
+
+// netlinkAttribute - a single attribute of a rtnetlink message. Attribute with "Children" is a nested
+// attribute, its "Value" (e.g. a header of the nested message) is followed by the encoded children.
+type netlinkAttribute struct {
+	Type     uint16
+	Value    []byte
+	Children []netlinkAttribute
+}
+
+// rtaAlign rounds "length" up to the alignment of rtnetlink attributes.
+func rtaAlign(length int) int {
+	return (length + unix.RTA_ALIGNTO - 1) &^ (unix.RTA_ALIGNTO - 1)
+}
+
+// encode returns the attribute in the wire format, padded to the attribute alignment.
+func (a netlinkAttribute) encode() []byte {
+	payload := append([]byte{}, a.Value...)
+	for _, child := range a.Children {
+		payload = append(payload, child.encode()...)
+	}
+
+	// Pure nested attributes are flagged, some of them (e.g. IFLA_PROTINFO) are parsed differently
+	// without the flag. Nested attributes with a header must not be flagged.
+	attrType := a.Type
+	if len(a.Children) > 0 && len(a.Value) == 0 {
+		attrType |= unix.NLA_F_NESTED
+	}
+
+	length := unix.SizeofRtAttr + len(payload)
+	encoded := make([]byte, rtaAlign(length))
+	binary.NativeEndian.PutUint16(encoded[0:2], uint16(length))
+	binary.NativeEndian.PutUint16(encoded[2:4], attrType)
+	copy(encoded[unix.SizeofRtAttr:], payload)
+	return encoded
+}
+
+// stringAttribute returns attribute "attrType" with NUL-terminated string "value".
+func stringAttribute(attrType uint16, value string) netlinkAttribute {
+	return netlinkAttribute{Type: attrType, Value: append([]byte(value), 0)}
+}
+
+// uint8Attribute returns attribute "attrType" with 8-bit number "value".
+func uint8Attribute(attrType uint16, value uint8) netlinkAttribute {
+	return netlinkAttribute{Type: attrType, Value: []byte{value}}
+}
+
+// uint32Attribute returns attribute "attrType" with 32-bit number "value" in the host byte order.
+func uint32Attribute(attrType uint16, value uint32) netlinkAttribute {
+	encoded := make([]byte, 4)
+	binary.NativeEndian.PutUint32(encoded, value)
+	return netlinkAttribute{Type: attrType, Value: encoded}
+}
+
+// nestedAttribute returns attribute "attrType" that contains "children" attributes.
+func nestedAttribute(attrType uint16, children ...netlinkAttribute) netlinkAttribute {
+	return netlinkAttribute{Type: attrType, Children: children}
+}
+
+// ifInfoMsg returns "struct ifinfomsg" header of link messages.
+func ifInfoMsg(family uint8, index int, flags uint32, change uint32) []byte {
+	header := make([]byte, unix.SizeofIfInfomsg)
+	header[0] = family
+	binary.NativeEndian.PutUint32(header[4:8], uint32(int32(index)))
+	binary.NativeEndian.PutUint32(header[8:12], flags)
+	binary.NativeEndian.PutUint32(header[12:16], change)
+	return header
+}
+
+// ifAddrMsg returns "struct ifaddrmsg" header of address messages.
+func ifAddrMsg(prefix netip.Prefix, index int) []byte {
+	header := make([]byte, unix.SizeofIfAddrmsg)
+	header[0] = unix.AF_INET
+	if prefix.Addr().Is6() {
+		header[0] = unix.AF_INET6
+	}
+	header[1] = uint8(prefix.Bits())
+	binary.NativeEndian.PutUint32(header[4:8], uint32(index))
+	return header
+}
+
+// netlinkMessage returns rtnetlink request "msgType" with "header" and "attributes". The request asks
+// the kernel for acknowledgement.
+func netlinkMessage(msgType uint16, flags uint16, header []byte, attributes ...netlinkAttribute) []byte {
+	body := append([]byte{}, header...)
+	for _, attribute := range attributes {
+		body = append(body, attribute.encode()...)
+	}
+
+	message := make([]byte, unix.SizeofNlMsghdr+len(body))
+	binary.NativeEndian.PutUint32(message[0:4], uint32(len(message)))
+	binary.NativeEndian.PutUint16(message[4:6], msgType)
+	binary.NativeEndian.PutUint16(message[6:8], flags|unix.NLM_F_REQUEST|unix.NLM_F_ACK)
+	binary.NativeEndian.PutUint32(message[8:12], 1)
+	copy(message[unix.SizeofNlMsghdr:], body)
+	return message
+}
+
+// netlinkExecute sends rtnetlink request "message" to the kernel and waits for its acknowledgement.
+func netlinkExecute(message []byte) error {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_ROUTE)
+	if err != nil {
+		return fmt.Errorf("failed to open netlink socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	err = unix.Sendto(fd, message, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK})
+	if err != nil {
+		return fmt.Errorf("failed to send netlink request: %w", err)
+	}
+
+	buffer := make([]byte, 65536)
+	for {
+		n, _, err := unix.Recvfrom(fd, buffer, 0)
+		if err != nil {
+			return fmt.Errorf("failed to receive netlink response: %w", err)
+		}
+
+		responses, err := syscall.ParseNetlinkMessage(buffer[:n])
+		if err != nil {
+			return fmt.Errorf("failed to parse netlink response: %w", err)
+		}
+
+		for _, response := range responses {
+			if response.Header.Type != unix.NLMSG_ERROR {
+				continue
+			}
+			if len(response.Data) < 4 {
+				return errors.New("truncated netlink error response")
+			}
+
+			errno := int32(binary.NativeEndian.Uint32(response.Data[0:4]))
+			if errno == 0 {
+				return nil
+			}
+			return syscall.Errno(-errno)
+		}
+	}
+}
+
+// linkIndex returns index of the network interface "name".
+func linkIndex(name string) (int, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lookup interface '%s': %w", name, err)
+	}
+	return iface.Index, nil
+}
+
+// linkCreate creates network interface "name" of type "kind", with type specific attributes "data".
+// Interface that already exists is left untouched.
+func linkCreate(name string, kind string, data []netlinkAttribute, attributes ...netlinkAttribute) error {
+	linkInfo := []netlinkAttribute{stringAttribute(unix.IFLA_INFO_KIND, kind)}
+	if len(data) > 0 {
+		linkInfo = append(linkInfo, nestedAttribute(unix.IFLA_INFO_DATA, data...))
+	}
+	attributes = append([]netlinkAttribute{
+		stringAttribute(unix.IFLA_IFNAME, name),
+		nestedAttribute(unix.IFLA_LINKINFO, linkInfo...),
+	}, attributes...)
+
+	err := netlinkExecute(netlinkMessage(unix.RTM_NEWLINK, unix.NLM_F_CREATE|unix.NLM_F_EXCL,
+		ifInfoMsg(unix.AF_UNSPEC, 0, 0, 0), attributes...))
+	if err != nil && !errors.Is(err, syscall.EEXIST) {
+		return fmt.Errorf("failed to create %s interface '%s': %w", kind, name, err)
+	}
+	return nil
+}
+
+// linkCreateVeth creates veth pair of interfaces "name" and "peer".
+func linkCreateVeth(name string, peer string) error {
+	return linkCreate(name, "veth", []netlinkAttribute{{
+		Type:     vethInfoPeer,
+		Value:    ifInfoMsg(unix.AF_UNSPEC, 0, 0, 0),
+		Children: []netlinkAttribute{stringAttribute(unix.IFLA_IFNAME, peer)},
+	}})
+}
+
+// linkSet changes attributes of the network interface "name".
+func linkSet(name string, attributes ...netlinkAttribute) error {
+	index, err := linkIndex(name)
+	if err != nil {
+		return err
+	}
+
+	err = netlinkExecute(netlinkMessage(unix.RTM_NEWLINK, 0, ifInfoMsg(unix.AF_UNSPEC, index, 0, 0), attributes...))
+	if err != nil {
+		return fmt.Errorf("failed to configure interface '%s': %w", name, err)
+	}
+	return nil
+}
+
+// linkSetMaster enslaves network interface "name" to the bridge or VRF "master".
+func linkSetMaster(name string, master string) error {
+	masterIndex, err := linkIndex(master)
+	if err != nil {
+		return err
+	}
+	return linkSet(name, uint32Attribute(unix.IFLA_MASTER, uint32(masterIndex)))
+}
+
+// linkSetUp brings the network interface "name" up.
+func linkSetUp(name string) error {
+	index, err := linkIndex(name)
+	if err != nil {
+		return err
+	}
+
+	err = netlinkExecute(netlinkMessage(unix.RTM_NEWLINK, 0, ifInfoMsg(unix.AF_UNSPEC, index, unix.IFF_UP, unix.IFF_UP)))
+	if err != nil {
+		return fmt.Errorf("failed to bring up interface '%s': %w", name, err)
+	}
+	return nil
+}
+
+// linkDelete deletes the network interface "name". Interface that does not exist is ignored.
+func linkDelete(name string) error {
+	index, err := linkIndex(name)
+	if err != nil {
+		return nil
+	}
+
+	err = netlinkExecute(netlinkMessage(unix.RTM_DELLINK, 0, ifInfoMsg(unix.AF_UNSPEC, index, 0, 0)))
+	if err != nil && !errors.Is(err, syscall.ENODEV) {
+		return fmt.Errorf("failed to delete interface '%s': %w", name, err)
+	}
+	return nil
+}
+
+// bridgePortSet changes bridge port attributes (IFLA_BRPORT_*) of the network interface "name" that is
+// enslaved to a bridge.
+func bridgePortSet(name string, attributes ...netlinkAttribute) error {
+	index, err := linkIndex(name)
+	if err != nil {
+		return err
+	}
+
+	err = netlinkExecute(netlinkMessage(unix.RTM_SETLINK, 0, ifInfoMsg(unix.AF_BRIDGE, index, 0, 0),
+		nestedAttribute(unix.IFLA_PROTINFO, attributes...)))
+	if err != nil {
+		return fmt.Errorf("failed to configure bridge port '%s': %w", name, err)
+	}
+	return nil
+}
+
+// addrAdd assigns address "prefix" to the network interface "name". Address that is already assigned
+// is replaced.
+func addrAdd(name string, prefix netip.Prefix) error {
+	index, err := linkIndex(name)
+	if err != nil {
+		return err
+	}
+
+	address := prefix.Addr().AsSlice()
+	err = netlinkExecute(netlinkMessage(unix.RTM_NEWADDR, unix.NLM_F_CREATE|unix.NLM_F_REPLACE, ifAddrMsg(prefix, index),
+		netlinkAttribute{Type: unix.IFA_LOCAL, Value: address},
+		netlinkAttribute{Type: unix.IFA_ADDRESS, Value: address},
+	))
+	if err != nil {
+		return fmt.Errorf("failed to assign address '%s' to interface '%s': %w", prefix, name, err)
+	}
+	return nil
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/canonical/microovn/microovn/api/types"
 	"github.com/canonical/microovn/microovn/bgp"
 	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/external"
 	"github.com/canonical/microovn/microovn/ovn/certificates"
+	"github.com/canonical/microovn/microovn/ovn/chassis"
 	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
 	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
 	"github.com/canonical/microovn/microovn/ovn/environment"
@@ -142,6 +144,8 @@ func EnableService(ctx context.Context, s state.State, service types.SrvName, ex
 		err = joinChassis(ctx, s)
 	case types.SrvBgp:
 		err = bgp.EnableService(ctx, s, extraConfig.BgpConfig)
+	case types.SrvRelay:
+		err = joinRelay(ctx, s)
 	default:
 		err = activateService(ctx, service, true)
 	}
@@ -369,6 +373,16 @@ func leaveChassis(ctx context.Context, s state.State) {
 		logger.Warnf("Failed to gracefully stop OVN Controller: %s", err)
 	}
 
+	err = external.Cleanup(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to remove external networks: %s", err)
+	}
+
+	err = chassis.DisableGateway(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to remove gateway role of the chassis: %s", err)
+	}
+
 	deactivateService(ctx, types.SrvChassis, true)
 }
 
@@ -378,7 +392,32 @@ func joinChassis(ctx context.Context, s state.State) error {
 	if err != nil {
 		return fmt.Errorf("failed to generate TLS certificate for ovn-controller service")
 	}
-	return activateService(ctx, types.SrvChassis, true)
+
+	err = activateService(ctx, types.SrvChassis, true)
+	if err != nil {
+		return err
+	}
+
+	// Failure to set up external networks is not fatal, they are re-applied when MicroOVN restarts
+	err = external.Reconcile(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to set up external networks: %s", err)
+	}
+
+	err = chassis.ReconcileEncap(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to set up encapsulation of the chassis: %s", err)
+	}
+	return nil
+}
+
+func joinRelay(ctx context.Context, s state.State) error {
+	// Generate certificate for OVN Southbound relay
+	err := certificates.GenerateNewServiceCertificate(ctx, s, "ovnsb-relay", certificates.CertificateTypeServer)
+	if err != nil {
+		return fmt.Errorf("failed to generate TLS certificate for ovnsb-relay service")
+	}
+	return activateService(ctx, types.SrvRelay, true)
 }
 
 // DisableAllServices is a function to disable alot of services
@@ -464,6 +503,11 @@ func ActivateEnabledServices(ctx context.Context, s state.State, enable bool) er
 			return err
 		}
 		for _, srv := range services {
+			// Capabilities of other services don't have their own snap service
+			if srv.Service == types.SrvGateway {
+				continue
+			}
+
 			err = activateService(ctx, srv.Service, enable)
 			if err != nil {
 				return err
@@ -472,6 +472,8 @@ func getServiceCertificatePaths(service string) (string, string, error) {
 		certPath, keyPath = paths.PkiOvnSbCertFiles()
 	case "ovn-northd":
 		certPath, keyPath = paths.PkiOvnNorthdCertFiles()
+	case "ovnsb-relay":
+		certPath, keyPath = paths.PkiOvnSbRelayCertFiles()
 	case "ovn-controller":
 		certPath, keyPath = paths.PkiOvnControllerCertFiles()
 	default:
@@ -0,0 +1,50 @@
+// Package chassis manages per-member configuration of the OVN chassis, stored in the cluster database
+// and applied to "external_ids" of the local Open vSwitch.
+package chassis
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// getConfig returns chassis configuration of cluster "member" from the cluster database, keyed by
+// configuration option.
+func getConfig(ctx context.Context, tx *sql.Tx, member string) (map[string]string, error) {
+	items, err := database.GetChassisConfigItems(ctx, tx, database.ChassisConfigItemFilter{Member: &member})
+	if err != nil {
+		return nil, err
+	}
+
+	config := make(map[string]string)
+	for _, item := range items {
+		config[item.Key] = item.Value
+	}
+	return config, nil
+}
+
+// setConfig stores chassis configuration option "key" of cluster "member" in the cluster database. Option
+// with an empty "value" is removed.
+func setConfig(ctx context.Context, tx *sql.Tx, member string, key string, value string) error {
+	if value == "" {
+		err := database.DeleteChassisConfigItem(ctx, tx, member, key)
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	item := database.ChassisConfigItem{Member: member, Key: key, Value: value}
+	exists, err := database.ChassisConfigItemExists(ctx, tx, member, key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return database.UpdateChassisConfigItem(ctx, tx, member, key, item)
+	}
+	_, err = database.CreateChassisConfigItem(ctx, tx, item)
+	return err
+}
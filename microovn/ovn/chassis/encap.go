@@ -0,0 +1,217 @@
+package chassis
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/state"
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+const (
+	// configEncapType - chassis configuration option with the tunnel encapsulation type
+	configEncapType = "encap_type"
+	// configEncapIPs - chassis configuration option with comma-separated tunnel encapsulation IPs
+	configEncapIPs = "encap_ips"
+)
+
+// encapFromExternalIDs returns tunnel encapsulation of "member" set in "externalIDs" of the Open_vSwitch
+// table.
+func encapFromExternalIDs(member string, externalIDs map[string]string) types.ChassisEncap {
+	encap := types.ChassisEncap{Member: member, Type: externalIDs["ovn-encap-type"]}
+	if ips := externalIDs["ovn-encap-ip"]; ips != "" {
+		encap.IPs = strings.Split(ips, ",")
+	}
+	return encap
+}
+
+// checkLocalAddresses ensures that every IP in "ips" is assigned to one of the local interface
+// "addresses".
+func checkLocalAddresses(ips []string, addresses []net.Addr) error {
+	local := make(map[netip.Addr]bool)
+	for _, address := range addresses {
+		prefix, err := netip.ParsePrefix(address.String())
+		if err == nil {
+			local[prefix.Addr()] = true
+		}
+	}
+
+	for _, ip := range ips {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil || !local[addr] {
+			return fmt.Errorf("encapsulation IP '%s' is not assigned to any local interface", ip)
+		}
+	}
+	return nil
+}
+
+// localIPs splits "ips" into those that are assigned to one of the local interface "addresses" and
+// those that are not.
+func localIPs(ips []string, addresses []net.Addr) (local []string, skipped []string) {
+	for _, ip := range ips {
+		if checkLocalAddresses([]string{ip}, addresses) == nil {
+			local = append(local, ip)
+		} else {
+			skipped = append(skipped, ip)
+		}
+	}
+	return local, skipped
+}
+
+// GetEncap returns tunnel encapsulation currently used by the local chassis.
+func GetEncap(ctx context.Context, s state.State) (types.ChassisEncap, error) {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return types.ChassisEncap{}, err
+	}
+	defer ovs.Close()
+
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return types.ChassisEncap{}, fmt.Errorf("failed to lookup chassis encapsulation: %w", err)
+	}
+	return encapFromExternalIDs(s.Name(), openvSwitch.ExternalIDs), nil
+}
+
+// SetEncap changes tunnel encapsulation of the local chassis. Options that are empty in "encap" are left
+// unchanged. New encapsulation is recorded in the cluster database, so that it's re-applied when MicroOVN
+// restarts.
+func SetEncap(ctx context.Context, s state.State, encap types.ChassisEncap) (types.ChassisEncap, error) {
+	err := encap.Validate()
+	if err != nil {
+		return types.ChassisEncap{}, err
+	}
+	if encap.Type == "" && len(encap.IPs) == 0 {
+		return types.ChassisEncap{}, errors.New("no encapsulation type or IP to set")
+	}
+
+	if len(encap.IPs) != 0 {
+		addresses, err := net.InterfaceAddrs()
+		if err != nil {
+			return types.ChassisEncap{}, fmt.Errorf("failed to list addresses of local interfaces: %w", err)
+		}
+
+		err = checkLocalAddresses(encap.IPs, addresses)
+		if err != nil {
+			return types.ChassisEncap{}, err
+		}
+	}
+
+	current, err := GetEncap(ctx, s)
+	if err != nil {
+		return types.ChassisEncap{}, err
+	}
+	if encap.Type == "" {
+		encap.Type = current.Type
+	}
+	if len(encap.IPs) == 0 {
+		encap.IPs = current.IPs
+	}
+	encap.Member = s.Name()
+
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		err := setConfig(ctx, tx, encap.Member, configEncapType, encap.Type)
+		if err != nil {
+			return err
+		}
+		return setConfig(ctx, tx, encap.Member, configEncapIPs, strings.Join(encap.IPs, ","))
+	})
+	if err != nil {
+		return types.ChassisEncap{}, fmt.Errorf("failed to record chassis encapsulation: %w", err)
+	}
+
+	err = applyEncap(ctx, s, encap)
+	if err != nil {
+		return types.ChassisEncap{}, err
+	}
+	return encap, nil
+}
+
+// ReconcileEncap re-applies tunnel encapsulation of the local member, recorded in the cluster database,
+// to the local chassis. Chassis without recorded encapsulation keep the one set when they joined.
+// Recorded IPs that are no longer assigned to any local interface are skipped.
+func ReconcileEncap(ctx context.Context, s state.State) error {
+	var config map[string]string
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		config, err = getConfig(ctx, tx, s.Name())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lookup chassis encapsulation: %w", err)
+	}
+
+	if config[configEncapType] == "" && config[configEncapIPs] == "" {
+		return nil
+	}
+
+	encap := encapFromExternalIDs(s.Name(), map[string]string{
+		"ovn-encap-type": config[configEncapType],
+		"ovn-encap-ip":   config[configEncapIPs],
+	})
+
+	if len(encap.IPs) != 0 {
+		addresses, err := net.InterfaceAddrs()
+		if err != nil {
+			return fmt.Errorf("failed to list addresses of local interfaces: %w", err)
+		}
+
+		var skipped []string
+		encap.IPs, skipped = localIPs(encap.IPs, addresses)
+		if len(skipped) != 0 {
+			logger.Warnf("Skipping encapsulation IPs not assigned to any local interface: %s",
+				strings.Join(skipped, ","))
+		}
+	}
+	return applyEncap(ctx, s, encap)
+}
+
+// applyEncap sets "ovn-encap-type" and "ovn-encap-ip" of the local chassis to options of "encap" that
+// are not empty.
+func applyEncap(ctx context.Context, s state.State, encap types.ChassisEncap) error {
+	externalIDs := make(map[string]string)
+	if encap.Type != "" {
+		externalIDs["ovn-encap-type"] = encap.Type
+	}
+	if len(encap.IPs) != 0 {
+		externalIDs["ovn-encap-ip"] = strings.Join(encap.IPs, ",")
+	}
+
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return fmt.Errorf("failed to lookup chassis encapsulation: %w", err)
+	}
+
+	openvSwitchRow := []ovsdbclient.Condition{ovsdbclient.HasUUID(openvSwitch.UUID)}
+	operations := []ovsdbclient.Operation{
+		ovsdbclient.SetMapKeys(ovsdbclient.TableOpenvSwitch, openvSwitchRow, "external_ids", externalIDs),
+	}
+
+	// Default encapsulation IP must be one of the encapsulation IPs, ovn-controller falls back to the
+	// first of them without it.
+	defaultIP := openvSwitch.ExternalIDs["ovn-encap-ip-default"]
+	if len(encap.IPs) != 0 && defaultIP != "" && !slices.Contains(encap.IPs, defaultIP) {
+		operations = append(operations, ovsdbclient.Mutate(ovsdbclient.TableOpenvSwitch, openvSwitchRow,
+			ovsdbclient.MapDelete("external_ids", "ovn-encap-ip-default")))
+	}
+
+	_, err = ovs.Transact(ctx, operations...)
+	if err != nil {
+		return fmt.Errorf("failed to update chassis encapsulation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package chassis
+
+import (
+	"net"
+	"slices"
+	"testing"
+)
+
+func TestEncapFromExternalIDs(t *testing.T) {
+	encap := encapFromExternalIDs("node1", map[string]string{
+		"ovn-encap-type": "geneve",
+		"ovn-encap-ip":   "10.0.0.1,10.0.1.1",
+	})
+	if encap.Member != "node1" || encap.Type != "geneve" || !slices.Equal(encap.IPs, []string{"10.0.0.1", "10.0.1.1"}) {
+		t.Errorf("unexpected encapsulation: %+v", encap)
+	}
+
+	encap = encapFromExternalIDs("node1", map[string]string{})
+	if encap.Type != "" || encap.IPs != nil {
+		t.Errorf("expected empty encapsulation, got: %+v", encap)
+	}
+}
+
+func TestCheckLocalAddresses(t *testing.T) {
+	addresses := []net.Addr{
+		&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)},
+		&net.IPNet{IP: net.ParseIP("10.0.0.1"), Mask: net.CIDRMask(24, 32)},
+		&net.IPNet{IP: net.ParseIP("fd00::1"), Mask: net.CIDRMask(64, 128)},
+	}
+
+	err := checkLocalAddresses([]string{"10.0.0.1", "fd00::1"}, addresses)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	for _, ips := range [][]string{{"10.0.0.2"}, {"10.0.0.1", "fd00::2"}, {"not-an-ip"}} {
+		err = checkLocalAddresses(ips, addresses)
+		if err == nil {
+			t.Errorf("expected error for IPs %v", ips)
+		}
+	}
+}
+
+func TestLocalIPs(t *testing.T) {
+	addresses := []net.Addr{
+		&net.IPNet{IP: net.ParseIP("10.0.0.1"), Mask: net.CIDRMask(24, 32)},
+		&net.IPNet{IP: net.ParseIP("fd00::1"), Mask: net.CIDRMask(64, 128)},
+	}
+
+	local, skipped := localIPs([]string{"10.0.0.1", "10.0.0.2", "fd00::1", "not-an-ip"}, addresses)
+	if !slices.Equal(local, []string{"10.0.0.1", "fd00::1"}) {
+		t.Errorf("unexpected local IPs: %v", local)
+	}
+	if !slices.Equal(skipped, []string{"10.0.0.2", "not-an-ip"}) {
+		t.Errorf("unexpected skipped IPs: %v", skipped)
+	}
+}
@@ -0,0 +1,210 @@
+package chassis
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/microcluster/v3/state"
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/ovn/ovsdbclient"
+)
+
+const (
+	// configGatewayPriority - chassis configuration option with the gateway priority
+	configGatewayPriority = "gateway_priority"
+	// configAvailabilityZones - chassis configuration option with comma-separated availability zones
+	configAvailabilityZones = "availability_zones"
+)
+
+const (
+	// cmsOptionGateway - "ovn-cms-options" entry that marks the chassis as a gateway
+	cmsOptionGateway = "enable-chassis-as-gw"
+	// cmsOptionPriority - "ovn-cms-options" entry with the gateway priority
+	cmsOptionPriority = "gateway-priority"
+	// cmsOptionAvailabilityZones - "ovn-cms-options" entry with ":" separated availability zones
+	cmsOptionAvailabilityZones = "availability-zones"
+)
+
+// gatewayCmsOptions returns "ovn-cms-options" entries that announce "gateway" to the CMS.
+func gatewayCmsOptions(gateway types.ChassisGateway) []string {
+	options := []string{cmsOptionGateway}
+	if gateway.Priority != "" {
+		options = append(options, fmt.Sprintf("%s=%s", cmsOptionPriority, gateway.Priority))
+	}
+	if len(gateway.AvailabilityZones) != 0 {
+		options = append(options, fmt.Sprintf("%s=%s", cmsOptionAvailabilityZones, strings.Join(gateway.AvailabilityZones, ":")))
+	}
+	return options
+}
+
+// mergeCmsOptions returns comma-separated "ovn-cms-options" that result from replacing gateway entries
+// in "current" options with "gateway" entries. Other entries, like "card-serial-number" set on DPUs, are
+// kept.
+func mergeCmsOptions(current string, gateway []string) string {
+	var options []string
+	for _, option := range strings.Split(current, ",") {
+		name, _, _ := strings.Cut(option, "=")
+		if option == "" || slices.Contains([]string{cmsOptionGateway, cmsOptionPriority, cmsOptionAvailabilityZones}, name) {
+			continue
+		}
+		options = append(options, option)
+	}
+	return strings.Join(append(options, gateway...), ",")
+}
+
+// gatewayFromConfig returns gateway role of "member" described by chassis configuration "config".
+func gatewayFromConfig(member string, config map[string]string) types.ChassisGateway {
+	gateway := types.ChassisGateway{Member: member, Priority: config[configGatewayPriority]}
+	if zones := config[configAvailabilityZones]; zones != "" {
+		gateway.AvailabilityZones = strings.Split(zones, ",")
+	}
+	return gateway
+}
+
+// ListGateways returns gateway roles of all cluster members that have "gateway" capability.
+func ListGateways(ctx context.Context, s state.State) (types.ChassisGateways, error) {
+	gateways := types.ChassisGateways{}
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		service := types.SrvGateway
+		services, err := database.GetServices(ctx, tx, database.ServiceFilter{Service: &service})
+		if err != nil {
+			return err
+		}
+
+		for _, service := range services {
+			config, err := getConfig(ctx, tx, service.Member)
+			if err != nil {
+				return err
+			}
+			gateways = append(gateways, gatewayFromConfig(service.Member, config))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup gateway chassis: %w", err)
+	}
+	return gateways, nil
+}
+
+// EnableGateway records "gateway" capability of the local member in the services table, along with the
+// gateway options, and announces the local chassis as a gateway. Running it again on a gateway member
+// replaces its options.
+func EnableGateway(ctx context.Context, s state.State, gateway types.ChassisGateway) (types.ChassisGateway, error) {
+	gateway.Member = s.Name()
+	err := gateway.Validate()
+	if err != nil {
+		return types.ChassisGateway{}, err
+	}
+
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ServiceExists(ctx, tx, gateway.Member, types.SrvGateway)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			_, err = database.CreateService(ctx, tx, database.Service{Member: gateway.Member, Service: types.SrvGateway})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = setConfig(ctx, tx, gateway.Member, configGatewayPriority, gateway.Priority)
+		if err != nil {
+			return err
+		}
+		return setConfig(ctx, tx, gateway.Member, configAvailabilityZones, strings.Join(gateway.AvailabilityZones, ","))
+	})
+	if err != nil {
+		return types.ChassisGateway{}, fmt.Errorf("failed to record gateway chassis: %w", err)
+	}
+
+	err = applyCmsOptions(ctx, s, gatewayCmsOptions(gateway))
+	if err != nil {
+		return types.ChassisGateway{}, err
+	}
+	return gateway, nil
+}
+
+// DisableGateway removes "gateway" capability of the local member, along with the gateway options, and
+// stops announcing the local chassis as a gateway.
+func DisableGateway(ctx context.Context, s state.State) error {
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		err := database.DeleteService(ctx, tx, s.Name(), types.SrvGateway)
+		if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return err
+		}
+
+		err = setConfig(ctx, tx, s.Name(), configGatewayPriority, "")
+		if err != nil {
+			return err
+		}
+		return setConfig(ctx, tx, s.Name(), configAvailabilityZones, "")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove gateway chassis: %w", err)
+	}
+
+	return applyCmsOptions(ctx, s, nil)
+}
+
+// ReconcileGateway re-applies gateway role of the local member, recorded in the cluster database, to
+// "ovn-cms-options" of the local chassis.
+func ReconcileGateway(ctx context.Context, s state.State) error {
+	var gateway []string
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.ServiceExists(ctx, tx, s.Name(), types.SrvGateway)
+		if err != nil || !exists {
+			return err
+		}
+
+		config, err := getConfig(ctx, tx, s.Name())
+		if err != nil {
+			return err
+		}
+		gateway = gatewayCmsOptions(gatewayFromConfig(s.Name(), config))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lookup gateway chassis: %w", err)
+	}
+
+	return applyCmsOptions(ctx, s, gateway)
+}
+
+// applyCmsOptions replaces gateway entries in "ovn-cms-options" of the local chassis with "gateway" entries.
+func applyCmsOptions(ctx context.Context, s state.State, gateway []string) error {
+	ovs, err := ovsdbclient.ConnectSwitch(ctx, s)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	openvSwitch, err := ovsdbclient.GetOpenvSwitch(ctx, ovs)
+	if err != nil {
+		return fmt.Errorf("failed to lookup ovn-cms-options: %w", err)
+	}
+
+	current := openvSwitch.ExternalIDs["ovn-cms-options"]
+	options := mergeCmsOptions(current, gateway)
+	if options == current {
+		return nil
+	}
+
+	openvSwitchRow := []ovsdbclient.Condition{ovsdbclient.HasUUID(openvSwitch.UUID)}
+	mutations := []ovsdbclient.Mutation{ovsdbclient.MapDelete("external_ids", "ovn-cms-options")}
+	if options != "" {
+		mutations = append(mutations, ovsdbclient.MapInsert("external_ids", map[string]string{"ovn-cms-options": options}))
+	}
+
+	_, err = ovs.Transact(ctx, ovsdbclient.Mutate(ovsdbclient.TableOpenvSwitch, openvSwitchRow, mutations...))
+	if err != nil {
+		return fmt.Errorf("failed to update ovn-cms-options: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package chassis
+
+import (
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestGatewayCmsOptions(t *testing.T) {
+	options := gatewayCmsOptions(types.ChassisGateway{Priority: "10", AvailabilityZones: []string{"az1", "az2"}})
+	expected := "enable-chassis-as-gw,gateway-priority=10,availability-zones=az1:az2"
+	if merged := mergeCmsOptions("", options); merged != expected {
+		t.Errorf("expected '%s', got '%s'", expected, merged)
+	}
+}
+
+func TestMergeCmsOptions(t *testing.T) {
+	tests := []struct {
+		current  string
+		gateway  []string
+		expected string
+	}{
+		{current: "", gateway: nil, expected: ""},
+		{current: "card-serial-number=ABC", gateway: []string{"enable-chassis-as-gw"}, expected: "card-serial-number=ABC,enable-chassis-as-gw"},
+		{current: "enable-chassis-as-gw,gateway-priority=10", gateway: []string{"enable-chassis-as-gw"}, expected: "enable-chassis-as-gw"},
+		{current: "enable-chassis-as-gw,card-serial-number=ABC,availability-zones=az1", gateway: nil, expected: "card-serial-number=ABC"},
+	}
+
+	for _, test := range tests {
+		merged := mergeCmsOptions(test.current, test.gateway)
+		if merged != test.expected {
+			t.Errorf("mergeCmsOptions('%s', %v): expected '%s', got '%s'", test.current, test.gateway, test.expected, merged)
+		}
+	}
+}
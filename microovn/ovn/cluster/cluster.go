@@ -53,14 +53,11 @@ func UpdateOvnListenConfig(ctx context.Context, s state.State) error {
 
 // UpdateOvnControllerRemoteConfig updates the value of "external_ids:remote-ovn" in the
 // Open vSwitch database. This value tells the OVN controller the location of OVN Southbound
-// database endpoints to which it should connect.
+// database endpoints to which it should connect. OVSDB relays are preferred over OVN central
+// services when available.
 func UpdateOvnControllerRemoteConfig(ctx context.Context, s state.State) error {
 	// Reconfigure OVS to use OVN.
-	centralIps, err := environment.CentralIps(ctx, s)
-	if err != nil {
-		return fmt.Errorf("failed to get OVN central IPs: %w", err)
-	}
-	sbConnect, err := environment.ConnectionString(ctx, s, centralIps, 6642)
+	sbConnect, err := environment.ChassisSbConnectionString(ctx, s)
 	if err != nil {
 		return fmt.Errorf("failed to get OVN SB connect string: %w", err)
 	}
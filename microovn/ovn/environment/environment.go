@@ -67,12 +67,10 @@ func remoteAddressesFromConfig(ctx context.Context, s state.State) ([]string, er
 
 }
 
-// defaultRemoteAddresses generates a list of IP addresses that should be used for connecting to ovn-central services
-// by returning addresses of MicroOVN cluster members with service "central" enabled.
-func defaultRemoteAddresses(ctx context.Context, s state.State) ([]string, error) {
+// serviceAddresses returns IP addresses of MicroOVN cluster members with service "serviceName" enabled.
+func serviceAddresses(ctx context.Context, s state.State, serviceName string) ([]string, error) {
 	var addrList []string
 	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
-		serviceName := "central"
 		servers, err := database.GetServices(ctx, tx, database.ServiceFilter{Service: &serviceName})
 		if err != nil {
 			return err
@@ -110,6 +108,17 @@ func defaultRemoteAddresses(ctx context.Context, s state.State) ([]string, error
 	return addrList, err
 }
 
+// defaultRemoteAddresses generates a list of IP addresses that should be used for connecting to ovn-central services
+// by returning addresses of MicroOVN cluster members with service "central" enabled.
+func defaultRemoteAddresses(ctx context.Context, s state.State) ([]string, error) {
+	return serviceAddresses(ctx, s, "central")
+}
+
+// RelayIps returns a list of IP addresses of MicroOVN cluster members with "relay" service enabled.
+func RelayIps(ctx context.Context, s state.State) ([]string, error) {
+	return serviceAddresses(ctx, s, "relay")
+}
+
 // CentralIps returns a list of IP addresses of OVN central services. This function
 // primarily returns addresses configured via the "ovn.central-ips" config option
 // and falls back to IP addresses of MicroOVN nodes with "central" service enabled
@@ -183,6 +192,46 @@ func ConnectionString(ctx context.Context, s state.State, addrList []string, por
 	return strings.Join(addresses, ","), nil
 }
 
+// chassisSbEndpoints returns addresses and port of the OVN Southbound database endpoints that should
+// be used by the chassis of the local member, given addresses of members that run "relay" and "central"
+// services. If the local member runs "relay" service, only the local relay is used, otherwise every
+// relay in the cluster is used. If there are no relays, the chassis connects directly to the OVN
+// central services.
+func chassisSbEndpoints(s state.State, relayIps []string, centralIps []string) ([]string, int) {
+	if len(relayIps) == 0 {
+		return centralIps, 6642
+	}
+
+	localAddr := s.Address().Hostname()
+	for _, relayIP := range relayIps {
+		if relayIP == localAddr {
+			return []string{relayIP}, 6645
+		}
+	}
+	return relayIps, 6645
+}
+
+// ChassisSbConnectionString returns connection string that should be used by ovn-controller
+// to connect to the OVN Southbound database. Chassis prefer OVSDB relays over the central
+// database cluster, see chassisSbEndpoints.
+func ChassisSbConnectionString(ctx context.Context, s state.State) (string, error) {
+	relayIps, err := RelayIps(ctx, s)
+	if err != nil {
+		return "", fmt.Errorf("failed to get OVN relay IPs: %w", err)
+	}
+
+	var centralIps []string
+	if len(relayIps) == 0 {
+		centralIps, err = CentralIps(ctx, s)
+		if err != nil {
+			return "", fmt.Errorf("failed to get OVN central IPs: %w", err)
+		}
+	}
+
+	addresses, port := chassisSbEndpoints(s, relayIps, centralIps)
+	return ConnectionString(ctx, s, addresses, port)
+}
+
 // GenerateEnvironment generates the OVN environment file.
 func GenerateEnvironment(ctx context.Context, s state.State) error {
 	centralIps, err := CentralIps(ctx, s)
@@ -2,6 +2,7 @@ package environment
 
 import (
 	"net/url"
+	"slices"
 	"testing"
 
 	"github.com/canonical/microcluster/v3/state"
@@ -77,3 +78,43 @@ func TestUnexported_initialNbSbHost(t *testing.T) {
 		}
 	}
 }
+
+func TestUnexported_chassisSbEndpoints(t *testing.T) {
+	testCases := []struct {
+		name              string
+		relayIps          []string
+		centralIps        []string
+		expectedAddresses []string
+		expectedPort      int
+	}{
+		{
+			name:              "local relay",
+			relayIps:          []string{remoteNodeIPv4, localNodeIPv4},
+			centralIps:        []string{remoteNodeIPv4},
+			expectedAddresses: []string{localNodeIPv4},
+			expectedPort:      6645,
+		},
+		{
+			name:              "remote relays only",
+			relayIps:          []string{remoteNodeIPv4, "10.0.0.3"},
+			centralIps:        []string{localNodeIPv4},
+			expectedAddresses: []string{remoteNodeIPv4, "10.0.0.3"},
+			expectedPort:      6645,
+		},
+		{
+			name:              "no relays",
+			centralIps:        []string{localNodeIPv4, remoteNodeIPv4},
+			expectedAddresses: []string{localNodeIPv4, remoteNodeIPv4},
+			expectedPort:      6642,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			addresses, port := chassisSbEndpoints(MockState{localNodeIP: localNodeIPv4}, tc.relayIps, tc.centralIps)
+			if !slices.Equal(addresses, tc.expectedAddresses) || port != tc.expectedPort {
+				t.Errorf("expected %v on port %d, got %v on port %d", tc.expectedAddresses, tc.expectedPort, addresses, port)
+			}
+		})
+	}
+}
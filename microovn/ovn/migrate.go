@@ -0,0 +1,174 @@
+package ovn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/config"
+	"github.com/canonical/microovn/microovn/node"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/securitylog"
+)
+
+// backupRemoteDB downloads standalone copy of the database "dbName" from the remote OVSDB server
+// and stores it in the file "dst".
+func backupRemoteDB(ctx context.Context, remote string, dbName string, dst string) error {
+	backup, err := shared.RunCommandContext(
+		ctx,
+		filepath.Join(paths.Wrappers(), "ovsdb-client"),
+		"-t", "60",
+		"backup",
+		remote,
+		dbName,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to download %s database from '%s': %w", dbName, remote, err)
+	}
+
+	err = os.WriteFile(dst, []byte(backup), 0600)
+	if err != nil {
+		return fmt.Errorf("failed to store %s database: %w", dbName, err)
+	}
+
+	return nil
+}
+
+// convertStandaloneDB converts standalone database file to the schema shipped with currently
+// installed OVN packages, if needed.
+func convertStandaloneDB(ctx context.Context, dbPath string, dbSpec *ovnCmd.OvsdbSpec) error {
+	needsConversion, err := shared.RunCommandContext(ctx, "ovsdb-tool", "needs-conversion", dbPath, dbSpec.Schema)
+	if err != nil {
+		return fmt.Errorf("failed to check schema of %s database: %w", dbSpec.FriendlyName, err)
+	}
+
+	if strings.TrimSpace(needsConversion) != "yes" {
+		return nil
+	}
+
+	logger.Infof("Converting %s database to the local schema", dbSpec.FriendlyName)
+	_, err = shared.RunCommandContext(ctx, "ovsdb-tool", "convert", dbPath, dbSpec.Schema)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s database to the local schema: %w", dbSpec.FriendlyName, err)
+	}
+
+	return nil
+}
+
+// MigrateInCentral moves OVN central services from an external OVN central cluster, configured via
+// "ovn.central-ips" option, to this member. Contents of the Northbound database are downloaded from
+// "nbRemote" and used to create a new clustered Northbound database. Southbound database is created
+// empty, its contents are re-populated by ovn-northd and ovn-controllers. A copy of the external
+// Southbound database is downloaded from "sbRemote" and kept in the backup location for reference.
+//
+// Once the database is in place, "ovn.central-ips" option is removed and "central" service is enabled
+// on this member. If enabling the service fails, the "ovn.central-ips" option is restored.
+//
+// NOTE: This function does not update the environment on other cluster members, please call
+// with a method of updating the clusters env files afterwards.
+func MigrateInCentral(ctx context.Context, s state.State, nbRemote string, sbRemote string) error {
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "central_migrate_in", "nb_remote": nbRemote, "sb_remote": sbRemote, "node": s.Name()},
+		"Migrating OVN central from external cluster to node '%s'",
+		s.Name(),
+	)
+
+	centralIps, err := config.GetConfig(ctx, s, "ovn.central-ips")
+	if err != nil {
+		return err
+	}
+	if centralIps == nil {
+		return errors.New("option 'ovn.central-ips' is not set, cluster does not use external OVN central")
+	}
+
+	centralMembers, err := node.FindService(ctx, s, types.SrvCentral)
+	if err != nil {
+		return err
+	}
+	if len(centralMembers) != 0 {
+		return fmt.Errorf("service 'central' is already enabled on member '%s'", centralMembers[0].Name)
+	}
+
+	if shared.PathExists(paths.CentralDBNBPath()) || shared.PathExists(paths.CentralDBSBPath()) {
+		return errors.New("OVN central databases already exist on this member")
+	}
+
+	nbSpec, err := ovnCmd.NewOvsdbSpec(ovnCmd.OvsdbTypeNBLocal)
+	if err != nil {
+		return err
+	}
+
+	err = environment.CreatePaths()
+	if err != nil {
+		return err
+	}
+
+	// Keep a copy of the external Southbound database. It's not used to seed the new database,
+	// but it can be useful if anything goes wrong.
+	logger.Infof("Downloading OVN Southbound database from '%s'", sbRemote)
+	err = backupRemoteDB(ctx, sbRemote, "OVN_Southbound", paths.CentralDBSBBackupPath())
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Downloading OVN Northbound database from '%s'", nbRemote)
+	standalonePath := paths.CentralDBNBPath() + ".standalone"
+	defer func() { _ = os.Remove(standalonePath) }()
+
+	err = backupRemoteDB(ctx, nbRemote, nbSpec.Name, standalonePath)
+	if err != nil {
+		return err
+	}
+
+	err = convertStandaloneDB(ctx, standalonePath, nbSpec)
+	if err != nil {
+		return err
+	}
+
+	_, err = shared.RunCommandContext(
+		ctx,
+		"ovsdb-tool",
+		"create-cluster",
+		paths.CentralDBNBPath(),
+		standalonePath,
+		"ssl:"+net.JoinHostPort(s.Address().Hostname(), "6643"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create %s database cluster: %w", nbSpec.FriendlyName, err)
+	}
+
+	// From now on, OVN central location is derived from members that run "central" service.
+	err = config.DeleteConfig(ctx, s, "ovn.central-ips")
+	if err != nil {
+		_ = os.Remove(paths.CentralDBNBPath())
+		return err
+	}
+
+	err = node.EnableService(ctx, s, types.SrvCentral, &types.ExtraServiceConfig{})
+	if err != nil {
+		logger.Errorf("Failed to enable central service, restoring 'ovn.central-ips': %s", err)
+		restoreErr := config.SetConfig(ctx, s, "ovn.central-ips", centralIps.Value)
+
+		moveErr := os.Rename(paths.CentralDBNBPath(), paths.CentralDBNBBackupPath())
+		if moveErr != nil && !errors.Is(moveErr, os.ErrNotExist) {
+			restoreErr = errors.Join(restoreErr, moveErr)
+		}
+
+		return errors.Join(err, restoreErr)
+	}
+
+	return nil
+}
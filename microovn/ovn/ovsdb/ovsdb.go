@@ -248,6 +248,14 @@ func UpgradeCentralDB(ctx context.Context, s state.State, dbType ovnCmd.OvsdbTyp
 					err,
 					backOffMs,
 				)
+			} else if held, err := IsUpgradeHeld(ctx, s); err != nil || held {
+				// Automatic upgrade is on hold. Keep looping, so that the upgrade resumes once the hold is
+				// released, or the loop ends once the upgrade is triggered explicitly.
+				if err != nil {
+					logger.Warnf("Failed to check if OVN %s DB upgrade is on hold: '%s'", dbSpec.FriendlyName, err)
+				} else {
+					logger.Debugf("OVN %s DB schema upgrade is on hold.", dbSpec.FriendlyName)
+				}
 			} else if upgradeLeader {
 
 				// Leader verifies whether the cluster is ready for schema upgrade
@@ -262,17 +270,7 @@ func UpgradeCentralDB(ctx context.Context, s state.State, dbType ovnCmd.OvsdbTyp
 				} else if upgradeReady {
 
 					// If the cluster is ready, an upgrade is triggered. Otherwise, the loop continues.
-					logger.Infof("Triggering OVN %s schema upgrade.", dbSpec.FriendlyName)
-					_, err = ovnCmd.OvsdbClient(
-						ctx,
-						s,
-						dbSpec,
-						10,
-						60,
-						"convert",
-						dbSpec.SocketURL,
-						dbSpec.Schema,
-					)
+					_, err = convertDB(ctx, s, dbSpec, dbStatus)
 					return err
 				}
 			} else {
@@ -0,0 +1,237 @@
+package ovsdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/canonical/lxd/shared/logger"
+	microTypes "github.com/canonical/microcluster/v3/microcluster/types"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/config"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/securitylog"
+)
+
+// upgradeHoldKey is an internal config key that, when present, prevents automatic
+// schema upgrades of OVN central databases.
+const upgradeHoldKey = "ovsdb.upgrade-hold"
+
+// centralDbTypes lists databases whose schema upgrades are managed by MicroOVN.
+var centralDbTypes = []ovnCmd.OvsdbType{ovnCmd.OvsdbTypeNBLocal, ovnCmd.OvsdbTypeSBLocal}
+
+// IsUpgradeHeld returns "true" if automatic schema upgrades of OVN central databases are on hold.
+func IsUpgradeHeld(ctx context.Context, s state.State) (bool, error) {
+	item, err := config.GetConfig(ctx, s, upgradeHoldKey)
+	if err != nil {
+		return false, err
+	}
+	return item != nil, nil
+}
+
+// SetUpgradeHold puts automatic schema upgrades of OVN central databases on hold, or releases
+// the hold, cluster-wide. Explicitly triggered upgrades are not affected by the hold.
+func SetUpgradeHold(ctx context.Context, s state.State, hold bool) error {
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "ovsdb_upgrade_hold", "hold": hold, "node": s.Name()},
+		"Setting hold of automatic OVSDB schema upgrades to '%t'",
+		hold,
+	)
+
+	if hold {
+		return config.SetConfig(ctx, s, upgradeHoldKey, "true")
+	}
+	return config.DeleteConfig(ctx, s, upgradeHoldKey)
+}
+
+// MemberSchemaVersions returns expected schema version of the database from each member of the
+// cluster, including the local member.
+func MemberSchemaVersions(ctx context.Context, s state.State, dbSpec *ovnCmd.OvsdbSpec) (types.OvsdbSchemaReport, error) {
+	localExpectedVersion, err := ExpectedOvsdbSchemaVersion(ctx, s, dbSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	report := types.OvsdbSchemaReport{
+		types.OvsdbSchemaVersionResult{
+			SchemaVersion: localExpectedVersion,
+			Host:          s.Address().Hostname(),
+			Error:         types.OvsdbSchemaFetchErrorNone,
+		},
+	}
+
+	// Get clients for each member in the cluster
+	clusterClient, err := s.Connect().Cluster(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a client for every cluster member: %w", err)
+	}
+
+	// Fetch expected schema versions from each cluster member.
+	_ = clusterClient.Query(ctx, true, func(ctx context.Context, c microTypes.Client) error {
+		clientURL := c.URL()
+		logger.Debugf("Fetching expected OVN %s schema version from '%s'", dbSpec.FriendlyName, clientURL.String())
+		nodeStatus := types.OvsdbSchemaVersionResult{Host: clientURL.Hostname()}
+
+		result, responseSuccess := microovnClient.GetExpectedOvsdbSchemaVersion(ctx, c, dbSpec)
+		nodeStatus.Error = responseSuccess
+		nodeStatus.SchemaVersion = result
+
+		report = append(report, nodeStatus)
+		return nil
+	})
+
+	return report, nil
+}
+
+// UpgradeStatus returns schema upgrade status of OVN central databases. For each database it
+// lists cluster members that block the upgrade, either because they do not expect the same
+// schema version as this member, or because they could not be contacted.
+//
+// This function must be executed on a member that runs "central" services.
+func UpgradeStatus(ctx context.Context, s state.State) (types.OvsdbUpgradeStatus, error) {
+	var status types.OvsdbUpgradeStatus
+	var err error
+
+	status.Hold, err = IsUpgradeHeld(ctx, s)
+	if err != nil {
+		return status, err
+	}
+
+	for _, dbType := range centralDbTypes {
+		dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+		if err != nil {
+			return status, err
+		}
+
+		dbStatus := types.OvsdbUpgradeDbStatus{Database: dbSpec.Name, Blockers: types.OvsdbSchemaReport{}}
+		liveStatus, err := getLiveSchemaStatus(ctx, s, dbSpec)
+		if err != nil {
+			dbStatus.Error = err.Error()
+			status.Databases = append(status.Databases, dbStatus)
+			continue
+		}
+		dbStatus.ActiveVersion = liveStatus.LocalVersion
+		dbStatus.TargetVersion = liveStatus.TargetVersion
+		dbStatus.UpgradeRequired = liveStatus.UpgradeRequired
+
+		memberVersions, err := MemberSchemaVersions(ctx, s, dbSpec)
+		if err != nil {
+			dbStatus.Error = err.Error()
+		}
+		for _, member := range memberVersions {
+			if member.Error != types.OvsdbSchemaFetchErrorNone || member.SchemaVersion != liveStatus.TargetVersion {
+				dbStatus.Blockers = append(dbStatus.Blockers, member)
+			}
+		}
+
+		status.Databases = append(status.Databases, dbStatus)
+	}
+
+	return status, nil
+}
+
+// snapshotDB stores a standalone copy of the database in the central database directory and
+// returns path to it.
+func snapshotDB(ctx context.Context, s state.State, dbSpec *ovnCmd.OvsdbSpec, version string) (string, error) {
+	backup, err := ovnCmd.OvsdbClient(ctx, s, dbSpec, 10, 60, "backup", dbSpec.SocketURL, dbSpec.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to take snapshot of %s database: %w", dbSpec.FriendlyName, err)
+	}
+
+	snapshotPath := paths.CentralDBSnapshotPath(dbSpec.ShortName, version)
+	err = os.WriteFile(snapshotPath, []byte(backup), 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to store snapshot of %s database: %w", dbSpec.FriendlyName, err)
+	}
+
+	return snapshotPath, nil
+}
+
+// convertDB takes a snapshot of the database and then converts it to the schema shipped with
+// currently installed OVN packages. Path to the snapshot is returned.
+func convertDB(ctx context.Context, s state.State, dbSpec *ovnCmd.OvsdbSpec, status schemaStatus) (string, error) {
+	snapshotPath, err := snapshotDB(ctx, s, dbSpec, status.LocalVersion)
+	if err != nil {
+		return "", err
+	}
+	logger.Infof("Snapshot of OVN %s DB stored in '%s'", dbSpec.FriendlyName, snapshotPath)
+
+	logger.Infof("Triggering OVN %s schema upgrade.", dbSpec.FriendlyName)
+	_, err = ovnCmd.OvsdbClient(
+		ctx,
+		s,
+		dbSpec,
+		10,
+		60,
+		"convert",
+		dbSpec.SocketURL,
+		dbSpec.Schema,
+	)
+	return snapshotPath, err
+}
+
+// TriggerUpgrade explicitly upgrades schemas of OVN central databases, regardless of whether the
+// automatic upgrades are on hold. Unless "force" is true, a database is upgraded only if every
+// cluster member expects the same schema version. Failures are reported per database in the
+// returned report.
+//
+// This function must be executed on a member that runs "central" services.
+func TriggerUpgrade(ctx context.Context, s state.State, force bool) (types.OvsdbUpgradeReport, error) {
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "ovsdb_upgrade_trigger", "force": force, "node": s.Name()},
+		"Triggering OVSDB schema upgrade from node '%s'",
+		s.Name(),
+	)
+
+	report := types.OvsdbUpgradeReport{}
+	for _, dbType := range centralDbTypes {
+		dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+		if err != nil {
+			return nil, err
+		}
+
+		report = append(report, types.OvsdbUpgradeResult{Database: dbSpec.Name})
+		current := &report[len(report)-1]
+
+		status, err := getLiveSchemaStatus(ctx, s, dbSpec)
+		if err != nil {
+			current.Error = err.Error()
+			continue
+		}
+		current.PreviousVersion = status.LocalVersion
+		current.TargetVersion = status.TargetVersion
+
+		if !status.UpgradeRequired {
+			continue
+		}
+
+		if !force {
+			ready, err := isClusterUpgradeReady(ctx, s, dbSpec, status.TargetVersion)
+			if err != nil {
+				current.Error = err.Error()
+				continue
+			}
+			if !ready {
+				current.Error = "not every cluster member is ready for the upgrade"
+				continue
+			}
+		}
+
+		current.Snapshot, err = convertDB(ctx, s, dbSpec, status)
+		if err != nil {
+			current.Error = fmt.Sprintf("failed to convert database: %s", err)
+			continue
+		}
+		current.Upgraded = true
+	}
+
+	return report, nil
+}
@@ -0,0 +1,272 @@
+// Package ovsdbclient implements a minimal OVSDB JSON-RPC client (RFC 7047). It allows MicroOVN
+// to run transactions against OVN and Open vSwitch databases directly, without spawning
+// ovn-nbctl/ovs-vsctl processes, and to group multi-step changes into a single atomic
+// transaction.
+package ovsdbclient
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultTimeout is applied to RPC calls whose context does not carry a deadline.
+const defaultTimeout = 30 * time.Second
+
+// rpcRequest is a JSON-RPC request or notification sent to the OVSDB server.
+type rpcRequest struct {
+	Method string `json:"method"`
+	Params []any  `json:"params"`
+	ID     any    `json:"id"`
+}
+
+// rpcResponse is a JSON-RPC response sent to the OVSDB server.
+type rpcResponse struct {
+	Result any             `json:"result"`
+	Error  any             `json:"error"`
+	ID     json.RawMessage `json:"id"`
+}
+
+// rpcMessage is any JSON-RPC message received from the OVSDB server.
+type rpcMessage struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+	ID     json.RawMessage `json:"id"`
+}
+
+// Client is a connection to a single database on an OVSDB server. Calls are serialized, it is
+// safe to use Client from multiple goroutines.
+type Client struct {
+	mu       sync.Mutex
+	conn     net.Conn
+	enc      *json.Encoder
+	dec      *json.Decoder
+	nextID   uint64
+	database string
+}
+
+// Dial connects to the OVSDB server at "endpoint" and returns client for database "database".
+// Endpoint uses the OVS notation, e.g. "unix:/run/db.sock", "tcp:10.0.0.1:6641" or
+// "ssl:10.0.0.1:6641". Argument "tlsConfig" is required only for "ssl" endpoints.
+func Dial(ctx context.Context, endpoint string, database string, tlsConfig *tls.Config) (*Client, error) {
+	protocol, address, found := strings.Cut(endpoint, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid OVSDB endpoint '%s'", endpoint)
+	}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: defaultTimeout}
+	switch protocol {
+	case "unix":
+		conn, err = dialer.DialContext(ctx, "unix", address)
+	case "tcp":
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	case "ssl":
+		if tlsConfig == nil {
+			return nil, fmt.Errorf("TLS configuration is required to connect to '%s'", endpoint)
+		}
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: tlsConfig}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", address)
+	default:
+		return nil, fmt.Errorf("unsupported OVSDB endpoint protocol '%s'", protocol)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to '%s': %w", endpoint, err)
+	}
+
+	return newClient(conn, database), nil
+}
+
+// newClient returns client for database "database" that communicates over established connection "conn".
+func newClient(conn net.Conn, database string) *Client {
+	return &Client{
+		conn:     conn,
+		enc:      json.NewEncoder(conn),
+		dec:      json.NewDecoder(conn),
+		database: database,
+	}
+}
+
+// Close closes connection to the OVSDB server.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Database returns name of the database that this client operates on.
+func (c *Client) Database() string {
+	return c.database
+}
+
+// call sends request with "method" and "params" to the server and waits for the response. Result of
+// the call is decoded into "result". Echo requests received in the meantime are answered and other
+// notifications are ignored.
+func (c *Client) call(ctx context.Context, method string, params []any, result any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	err := c.conn.SetDeadline(deadline)
+	if err != nil {
+		return err
+	}
+
+	c.nextID++
+	id := c.nextID
+	err = c.enc.Encode(rpcRequest{Method: method, Params: params, ID: id})
+	if err != nil {
+		return fmt.Errorf("failed to send '%s' request: %w", method, err)
+	}
+
+	for {
+		var msg rpcMessage
+		err = c.dec.Decode(&msg)
+		if err != nil {
+			return fmt.Errorf("failed to receive '%s' response: %w", method, err)
+		}
+
+		if msg.Method == "echo" {
+			var echoParams any
+			_ = json.Unmarshal(msg.Params, &echoParams)
+			err = c.enc.Encode(rpcResponse{Result: echoParams, ID: msg.ID})
+			if err != nil {
+				return fmt.Errorf("failed to respond to echo request: %w", err)
+			}
+			continue
+		}
+
+		if msg.Method != "" {
+			// Notifications (e.g. monitor updates) are not used by this client
+			continue
+		}
+
+		var responseID uint64
+		if json.Unmarshal(msg.ID, &responseID) != nil || responseID != id {
+			continue
+		}
+
+		if !isNull(msg.Error) {
+			return fmt.Errorf("'%s' request failed: %s", method, string(msg.Error))
+		}
+
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(msg.Result, result)
+	}
+}
+
+// Echo sends echo request to the server. It can be used to verify that the connection is alive.
+func (c *Client) Echo(ctx context.Context) error {
+	return c.call(ctx, "echo", []any{}, nil)
+}
+
+// transact executes "operations" as a single transaction against "database". All operations are
+// either committed together, or none of them is.
+func (c *Client) transact(ctx context.Context, database string, operations []Operation) ([]OperationResult, error) {
+	params := make([]any, 0, len(operations)+1)
+	params = append(params, database)
+	for _, op := range operations {
+		params = append(params, op)
+	}
+
+	var results []OperationResult
+	err := c.call(ctx, "transact", params, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for i, result := range results {
+		if result.Error == "" {
+			continue
+		}
+		if i < len(operations) {
+			errs = append(errs, fmt.Errorf("operation '%s' on table '%s' failed: %s", operations[i].Op, operations[i].Table, result))
+		} else {
+			errs = append(errs, fmt.Errorf("transaction commit failed: %s", result))
+		}
+	}
+	if len(errs) != 0 {
+		return results, errors.Join(errs...)
+	}
+
+	if len(results) < len(operations) {
+		return results, fmt.Errorf("incomplete transaction result, expected %d results, got %d", len(operations), len(results))
+	}
+
+	return results, nil
+}
+
+// Transact executes "operations" as a single transaction. All operations are either committed
+// together, or none of them is. On success, it returns result of each operation, in the same
+// order as the operations were supplied.
+func (c *Client) Transact(ctx context.Context, operations ...Operation) ([]OperationResult, error) {
+	return c.transact(ctx, c.database, operations)
+}
+
+// IsConnected returns "true" if the database is connected, according to the "_Server" database
+// of the OVSDB server. Clustered databases are connected when they are part of the cluster and
+// the cluster has a leader.
+func (c *Client) IsConnected(ctx context.Context) (bool, error) {
+	results, err := c.transact(ctx, "_Server", []Operation{
+		Select("Database", Equal("name", c.database)).WithColumns("connected"),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var databases []struct {
+		Connected bool `ovsdb:"connected"`
+	}
+	err = UnmarshalRows(results[0].Rows, &databases)
+	if err != nil {
+		return false, err
+	}
+
+	return len(databases) == 1 && databases[0].Connected, nil
+}
+
+// Find returns rows from "table" that match all conditions in "where", decoded into typed models.
+func Find[T any](ctx context.Context, c *Client, table string, where ...Condition) ([]T, error) {
+	results, err := c.Transact(ctx, Select(table, where...))
+	if err != nil {
+		return nil, err
+	}
+
+	var models []T
+	err = UnmarshalRows(results[0].Rows, &models)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode rows from table '%s': %w", table, err)
+	}
+
+	return models, nil
+}
+
+// GetOpenvSwitch returns the only row of the Open_vSwitch table in the Open vSwitch database.
+func GetOpenvSwitch(ctx context.Context, c *Client) (OpenvSwitch, error) {
+	rows, err := Find[OpenvSwitch](ctx, c, TableOpenvSwitch)
+	if err != nil {
+		return OpenvSwitch{}, err
+	}
+	if len(rows) != 1 {
+		return OpenvSwitch{}, fmt.Errorf("expected single row in Open_vSwitch table, found %d", len(rows))
+	}
+	return rows[0], nil
+}
+
+// isNull returns "true" if the raw JSON value is missing or null.
+func isNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
@@ -0,0 +1,154 @@
+package ovsdbclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// connectWait is the amount of time to wait for a database to become connected.
+const connectWait = 30 * time.Second
+
+// ClientTLSConfig returns TLS configuration that uses the same client certificate as MicroOVN's
+// wrappers of OVN/OVS command line tools.
+//
+// OVN certificates are not issued for addresses of the database servers, so, just like OVS
+// itself, only the certificate chain of the server is verified against the OVN CA.
+func ClientTLSConfig() (*tls.Config, error) {
+	certFile, keyFile := paths.PkiClientCertFiles()
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+
+	caCert, err := os.ReadFile(paths.PkiCaCertFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CA certificate: %w", err)
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return nil, errors.New("failed to parse CA certificate")
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		// Server name is not verified, the certificate chain is verified in VerifyPeerCertificate
+		InsecureSkipVerify: true, //nolint:gosec
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("server did not present a certificate")
+			}
+
+			certs := make([]*x509.Certificate, 0, len(rawCerts))
+			for _, raw := range rawCerts {
+				cert, err := x509.ParseCertificate(raw)
+				if err != nil {
+					return fmt.Errorf("failed to parse server certificate: %w", err)
+				}
+				certs = append(certs, cert)
+			}
+
+			intermediates := x509.NewCertPool()
+			for _, cert := range certs[1:] {
+				intermediates.AddCert(cert)
+			}
+
+			_, err := certs[0].Verify(x509.VerifyOptions{Roots: caPool, Intermediates: intermediates})
+			return err
+		},
+	}, nil
+}
+
+// waitConnected waits until the client's database is connected.
+func waitConnected(ctx context.Context, c *Client, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		connected, err := c.IsConnected(ctx)
+		if err == nil && connected {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err == nil {
+				err = errors.New("database is not connected")
+			}
+			return fmt.Errorf("%s database did not reach connected state: %w", c.Database(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// ConnectSwitch returns client connected to the local Open vSwitch database.
+func ConnectSwitch(ctx context.Context, _ state.State) (*Client, error) {
+	c, err := Dial(ctx, "unix:"+paths.OvsDatabaseSock(), "Open_vSwitch", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = waitConnected(ctx, c, connectWait)
+	if err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// ConnectNBCluster returns client connected to the OVN Northbound database cluster. Endpoints of
+// the cluster are tried one by one, and the first one that has the database connected is used.
+//
+// Warning: This function will fail if local MicroOVN node is not bootstrapped.
+func ConnectNBCluster(ctx context.Context, s state.State) (*Client, error) {
+	centralIps, err := environment.CentralIps(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get central IPs: %w", err)
+	}
+
+	protocol := environment.NetworkProtocol(ctx, s)
+	var tlsConfig *tls.Config
+	if protocol == "ssl" {
+		tlsConfig, err = ClientTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, ip := range centralIps {
+		endpoint := fmt.Sprintf("%s:%s", protocol, net.JoinHostPort(ip, strconv.Itoa(6641)))
+		c, err := Dial(ctx, endpoint, "OVN_Northbound", tlsConfig)
+		if err != nil {
+			logger.Warnf("Failed to connect to OVN_Northbound database at %s: %v", endpoint, err)
+			continue
+		}
+
+		err = waitConnected(ctx, c, 10*time.Second)
+		if err != nil {
+			logger.Warnf("Failed to connect to OVN_Northbound database at %s: %v", endpoint, err)
+			_ = c.Close()
+			continue
+		}
+
+		return c, nil
+	}
+
+	return nil, errors.New("failed to connect to OVN Northbound database cluster")
+}
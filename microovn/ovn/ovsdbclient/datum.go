@@ -0,0 +1,268 @@
+package ovsdbclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Row is a single database row as returned by the OVSDB server, with values of columns in the
+// OVSDB JSON notation.
+type Row map[string]json.RawMessage
+
+// UUID returns OVSDB representation of a reference to row with UUID "uuid".
+func UUID(uuid string) []any {
+	return []any{"uuid", uuid}
+}
+
+// NamedUUID returns OVSDB representation of a reference to row inserted in the same transaction
+// with "uuid-name" set to "name".
+func NamedUUID(name string) []any {
+	return []any{"named-uuid", name}
+}
+
+// Set returns OVSDB representation of a set containing "elements".
+func Set(elements ...any) []any {
+	if elements == nil {
+		elements = []any{}
+	}
+	return []any{"set", elements}
+}
+
+// StringSet returns OVSDB representation of a set of strings.
+func StringSet(elements ...string) []any {
+	values := make([]any, 0, len(elements))
+	for _, element := range elements {
+		values = append(values, element)
+	}
+	return Set(values...)
+}
+
+// Map returns OVSDB representation of a map with string keys and values. Pairs are sorted by
+// key, so that the representation is stable.
+func Map(values map[string]string) []any {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]any, 0, len(values))
+	for _, key := range keys {
+		pairs = append(pairs, []any{key, values[key]})
+	}
+	return []any{"map", pairs}
+}
+
+// decodeAtom decodes a single OVSDB atom. References to other rows are decoded into strings
+// containing the referenced UUID.
+func decodeAtom(raw json.RawMessage) (any, error) {
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "[") {
+		var pair []json.RawMessage
+		err := json.Unmarshal(raw, &pair)
+		if err != nil {
+			return nil, err
+		}
+
+		var kind string
+		if len(pair) != 2 || json.Unmarshal(pair[0], &kind) != nil {
+			return nil, fmt.Errorf("unexpected OVSDB value %s", trimmed)
+		}
+		if kind != "uuid" && kind != "named-uuid" {
+			return nil, fmt.Errorf("expected OVSDB atom, got '%s'", kind)
+		}
+
+		var uuid string
+		err = json.Unmarshal(pair[1], &uuid)
+		return uuid, err
+	}
+
+	var atom any
+	err := json.Unmarshal(raw, &atom)
+	return atom, err
+}
+
+// atomString decodes a single OVSDB atom into its string representation.
+func atomString(raw json.RawMessage) (string, error) {
+	atom, err := decodeAtom(raw)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := atom.(string)
+	if !ok {
+		value = fmt.Sprint(atom)
+	}
+	return value, nil
+}
+
+// setElements returns elements of an OVSDB set. A single atom is treated as a set with one element.
+func setElements(raw json.RawMessage) ([]json.RawMessage, error) {
+	var wrapper []json.RawMessage
+	if json.Unmarshal(raw, &wrapper) == nil && len(wrapper) == 2 {
+		var kind string
+		if json.Unmarshal(wrapper[0], &kind) == nil && kind == "set" {
+			var elements []json.RawMessage
+			err := json.Unmarshal(wrapper[1], &elements)
+			return elements, err
+		}
+	}
+
+	return []json.RawMessage{raw}, nil
+}
+
+// mapPairs returns key-value pairs of an OVSDB map.
+func mapPairs(raw json.RawMessage) (map[string]string, error) {
+	var wrapper []json.RawMessage
+	err := json.Unmarshal(raw, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	var kind string
+	if len(wrapper) != 2 || json.Unmarshal(wrapper[0], &kind) != nil || kind != "map" {
+		return nil, fmt.Errorf("expected OVSDB map, got %s", string(raw))
+	}
+
+	var pairs [][2]json.RawMessage
+	err = json.Unmarshal(wrapper[1], &pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, err := atomString(pair[0])
+		if err != nil {
+			return nil, err
+		}
+		value, err := atomString(pair[1])
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// decodeColumn decodes OVSDB value "raw" into the struct field "field".
+func decodeColumn(raw json.RawMessage, field reflect.Value) error {
+	switch field.Kind() {
+	case reflect.String:
+		elements, err := setElements(raw)
+		if err != nil || len(elements) == 0 {
+			return err
+		}
+		value, err := atomString(elements[0])
+		if err != nil {
+			return err
+		}
+		field.SetString(value)
+	case reflect.Bool:
+		elements, err := setElements(raw)
+		if err != nil || len(elements) == 0 {
+			return err
+		}
+		var value bool
+		err = json.Unmarshal(elements[0], &value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(value)
+	case reflect.Int, reflect.Int64:
+		elements, err := setElements(raw)
+		if err != nil || len(elements) == 0 {
+			return err
+		}
+		var value int64
+		err = json.Unmarshal(elements[0], &value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(value)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", field.Type())
+		}
+		elements, err := setElements(raw)
+		if err != nil {
+			return err
+		}
+		values := reflect.MakeSlice(field.Type(), 0, len(elements))
+		for _, element := range elements {
+			value, err := atomString(element)
+			if err != nil {
+				return err
+			}
+			values = reflect.Append(values, reflect.ValueOf(value))
+		}
+		field.Set(values)
+	case reflect.Map:
+		if field.Type().Key().Kind() != reflect.String || field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s", field.Type())
+		}
+		values, err := mapPairs(raw)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+// UnmarshalRow decodes "row" into struct pointed to by "model". Struct fields are mapped to
+// columns using the "ovsdb" tag. Columns that are not present in the row are left untouched.
+func UnmarshalRow(row Row, model any) error {
+	value := reflect.ValueOf(model)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return errors.New("model must be a pointer to a struct")
+	}
+
+	value = value.Elem()
+	for i := 0; i < value.NumField(); i++ {
+		column := value.Type().Field(i).Tag.Get("ovsdb")
+		if column == "" {
+			continue
+		}
+
+		raw, ok := row[column]
+		if !ok {
+			continue
+		}
+
+		err := decodeColumn(raw, value.Field(i))
+		if err != nil {
+			return fmt.Errorf("failed to decode column '%s': %w", column, err)
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalRows decodes "rows" into slice of structs pointed to by "models".
+func UnmarshalRows(rows []Row, models any) error {
+	value := reflect.ValueOf(models)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Slice {
+		return errors.New("models must be a pointer to a slice")
+	}
+
+	slice := value.Elem()
+	for _, row := range rows {
+		model := reflect.New(slice.Type().Elem())
+		err := UnmarshalRow(row, model.Interface())
+		if err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, model.Elem()))
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package ovsdbclient
+
+// Names of OVN Northbound database tables used by MicroOVN.
+const (
+	TableLogicalRouter     = "Logical_Router"
+	TableLogicalRouterPort = "Logical_Router_Port"
+	TableLogicalSwitch     = "Logical_Switch"
+	TableLogicalSwitchPort = "Logical_Switch_Port"
+)
+
+// Names of Open vSwitch database tables used by MicroOVN.
+const (
+	TableOpenvSwitch = "Open_vSwitch"
+	TableBridge      = "Bridge"
+	TablePort        = "Port"
+	TableInterface   = "Interface"
+)
+
+// LogicalRouter is a row in the Logical_Router table of the OVN Northbound database.
+type LogicalRouter struct {
+	UUID        string            `ovsdb:"_uuid"`
+	Name        string            `ovsdb:"name"`
+	Ports       []string          `ovsdb:"ports"`
+	Options     map[string]string `ovsdb:"options"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+}
+
+// LogicalRouterPort is a row in the Logical_Router_Port table of the OVN Northbound database.
+type LogicalRouterPort struct {
+	UUID          string            `ovsdb:"_uuid"`
+	Name          string            `ovsdb:"name"`
+	MAC           string            `ovsdb:"mac"`
+	Networks      []string          `ovsdb:"networks"`
+	Options       map[string]string `ovsdb:"options"`
+	Ipv6RaConfigs map[string]string `ovsdb:"ipv6_ra_configs"`
+	ExternalIDs   map[string]string `ovsdb:"external_ids"`
+}
+
+// LogicalSwitch is a row in the Logical_Switch table of the OVN Northbound database.
+type LogicalSwitch struct {
+	UUID        string            `ovsdb:"_uuid"`
+	Name        string            `ovsdb:"name"`
+	Ports       []string          `ovsdb:"ports"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+}
+
+// LogicalSwitchPort is a row in the Logical_Switch_Port table of the OVN Northbound database.
+type LogicalSwitchPort struct {
+	UUID        string            `ovsdb:"_uuid"`
+	Name        string            `ovsdb:"name"`
+	Type        string            `ovsdb:"type"`
+	Addresses   []string          `ovsdb:"addresses"`
+	Options     map[string]string `ovsdb:"options"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+}
+
+// OpenvSwitch is a row in the Open_vSwitch table of the Open vSwitch database.
+type OpenvSwitch struct {
+	UUID        string            `ovsdb:"_uuid"`
+	Bridges     []string          `ovsdb:"bridges"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+}
+
+// Bridge is a row in the Bridge table of the Open vSwitch database.
+type Bridge struct {
+	UUID        string            `ovsdb:"_uuid"`
+	Name        string            `ovsdb:"name"`
+	Ports       []string          `ovsdb:"ports"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+}
+
+// Port is a row in the Port table of the Open vSwitch database.
+type Port struct {
+	UUID        string            `ovsdb:"_uuid"`
+	Name        string            `ovsdb:"name"`
+	Interfaces  []string          `ovsdb:"interfaces"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+}
+
+// Interface is a row in the Interface table of the Open vSwitch database.
+type Interface struct {
+	UUID        string            `ovsdb:"_uuid"`
+	Name        string            `ovsdb:"name"`
+	Type        string            `ovsdb:"type"`
+	ExternalIDs map[string]string `ovsdb:"external_ids"`
+}
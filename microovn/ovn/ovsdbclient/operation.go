@@ -0,0 +1,207 @@
+package ovsdbclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Condition is a single OVSDB condition in form of [<column>, <function>, <value>].
+type Condition [3]any
+
+// Mutation is a single OVSDB mutation in form of [<column>, <mutator>, <value>].
+type Mutation [3]any
+
+// Equal returns condition that matches rows where "column" equals "value".
+func Equal(column string, value any) Condition {
+	return Condition{column, "==", value}
+}
+
+// Includes returns condition that matches rows where set or map "column" includes all
+// elements of "value".
+func Includes(column string, value any) Condition {
+	return Condition{column, "includes", value}
+}
+
+// HasUUID returns condition that matches row with UUID "uuid".
+func HasUUID(uuid string) Condition {
+	return Equal("_uuid", UUID(uuid))
+}
+
+// MapInsert returns mutation that inserts key-value pairs from "values" into map "column". Keys that
+// already exist in the column are left unchanged.
+func MapInsert(column string, values map[string]string) Mutation {
+	return Mutation{column, "insert", Map(values)}
+}
+
+// MapDelete returns mutation that deletes "keys" from map "column".
+func MapDelete(column string, keys ...string) Mutation {
+	elements := make([]any, 0, len(keys))
+	for _, key := range keys {
+		elements = append(elements, key)
+	}
+	return Mutation{column, "delete", Set(elements...)}
+}
+
+// SetInsert returns mutation that inserts "elements" into set "column".
+func SetInsert(column string, elements ...any) Mutation {
+	return Mutation{column, "insert", Set(elements...)}
+}
+
+// SetDelete returns mutation that deletes "elements" from set "column".
+func SetDelete(column string, elements ...any) Mutation {
+	return Mutation{column, "delete", Set(elements...)}
+}
+
+// Increment returns mutation that adds "value" to integer "column".
+func Increment(column string, value int) Mutation {
+	return Mutation{column, "+=", value}
+}
+
+// Operation is a single OVSDB operation that is part of a transaction.
+type Operation struct {
+	Op        string
+	Table     string
+	Row       map[string]any
+	Where     []Condition
+	Columns   []string
+	Mutations []Mutation
+	UUIDName  string
+	Until     string
+	Rows      []map[string]any
+	Timeout   *int
+}
+
+// Insert returns operation that inserts "row" into "table". If "uuidName" is not empty, the new
+// row can be referenced by other operations in the same transaction with NamedUUID(uuidName).
+func Insert(table string, row map[string]any, uuidName string) Operation {
+	return Operation{Op: "insert", Table: table, Row: row, UUIDName: uuidName}
+}
+
+// Select returns operation that selects rows from "table" that match all conditions in "where".
+func Select(table string, where ...Condition) Operation {
+	return Operation{Op: "select", Table: table, Where: where}
+}
+
+// Update returns operation that updates columns from "row" in all rows of "table" that match
+// all conditions in "where".
+func Update(table string, row map[string]any, where ...Condition) Operation {
+	return Operation{Op: "update", Table: table, Row: row, Where: where}
+}
+
+// Mutate returns operation that applies "mutations", in order, to all rows of "table" that match
+// all conditions in "where".
+func Mutate(table string, where []Condition, mutations ...Mutation) Operation {
+	return Operation{Op: "mutate", Table: table, Where: where, Mutations: mutations}
+}
+
+// Delete returns operation that deletes all rows of "table" that match all conditions in "where".
+func Delete(table string, where ...Condition) Operation {
+	return Operation{Op: "delete", Table: table, Where: where}
+}
+
+// SetMapKeys returns operation that sets key-value pairs from "values" in map "column" of all rows
+// of "table" that match all conditions in "where". Values of keys that already exist are replaced.
+func SetMapKeys(table string, where []Condition, column string, values map[string]string) Operation {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	return Mutate(table, where, MapDelete(column, keys...), MapInsert(column, values))
+}
+
+// assertRows returns "wait" operation that aborts the transaction immediately, unless comparison
+// "until" ("==" or "!=") of rows matching "where" against an empty set holds.
+func assertRows(table string, until string, where []Condition) Operation {
+	timeout := 0
+	return Operation{
+		Op:      "wait",
+		Table:   table,
+		Where:   where,
+		Columns: []string{"_uuid"},
+		Until:   until,
+		Rows:    []map[string]any{},
+		Timeout: &timeout,
+	}
+}
+
+// AssertAbsent returns operation that aborts the transaction if "table" contains any row that
+// matches all conditions in "where".
+func AssertAbsent(table string, where ...Condition) Operation {
+	return assertRows(table, "==", where)
+}
+
+// AssertPresent returns operation that aborts the transaction if "table" does not contain any row
+// that matches all conditions in "where".
+func AssertPresent(table string, where ...Condition) Operation {
+	return assertRows(table, "!=", where)
+}
+
+// WithColumns limits columns returned by the "select" operation.
+func (op Operation) WithColumns(columns ...string) Operation {
+	op.Columns = columns
+	return op
+}
+
+// MarshalJSON encodes operation into its JSON representation. Members required by the
+// operation type are always present, even if empty.
+func (op Operation) MarshalJSON() ([]byte, error) {
+	encoded := map[string]any{"op": op.Op}
+	if op.Table != "" {
+		encoded["table"] = op.Table
+	}
+
+	where := op.Where
+	if where == nil {
+		where = []Condition{}
+	}
+
+	switch op.Op {
+	case "insert":
+		encoded["row"] = op.Row
+		if op.UUIDName != "" {
+			encoded["uuid-name"] = op.UUIDName
+		}
+	case "select":
+		encoded["where"] = where
+		if op.Columns != nil {
+			encoded["columns"] = op.Columns
+		}
+	case "update":
+		encoded["where"] = where
+		encoded["row"] = op.Row
+	case "mutate":
+		encoded["where"] = where
+		encoded["mutations"] = op.Mutations
+	case "delete":
+		encoded["where"] = where
+	case "wait":
+		encoded["where"] = where
+		encoded["columns"] = op.Columns
+		encoded["until"] = op.Until
+		encoded["rows"] = op.Rows
+		if op.Timeout != nil {
+			encoded["timeout"] = *op.Timeout
+		}
+	default:
+		return nil, fmt.Errorf("unsupported OVSDB operation '%s'", op.Op)
+	}
+
+	return json.Marshal(encoded)
+}
+
+// OperationResult is a result of a single operation in a transaction.
+type OperationResult struct {
+	Count   int             `json:"count"`
+	UUID    json.RawMessage `json:"uuid"`
+	Rows    []Row           `json:"rows"`
+	Error   string          `json:"error"`
+	Details string          `json:"details"`
+}
+
+// String returns human readable description of the operation error.
+func (r OperationResult) String() string {
+	if r.Details == "" {
+		return r.Error
+	}
+	return fmt.Sprintf("%s: %s", r.Error, r.Details)
+}
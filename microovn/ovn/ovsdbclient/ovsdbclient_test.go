@@ -0,0 +1,143 @@
+package ovsdbclient
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalRow(t *testing.T) {
+	row := Row{
+		"_uuid":        json.RawMessage(`["uuid","5b7c1f8e-6d5d-4a4c-9d3c-0c1c3f2e1a11"]`),
+		"name":         json.RawMessage(`"lr-node1-microovn"`),
+		"ports":        json.RawMessage(`["set",[["uuid","a1"],["uuid","b2"]]]`),
+		"options":      json.RawMessage(`["map",[["chassis","node1"],["dynamic-routing-vrf-id","10"]]]`),
+		"external_ids": json.RawMessage(`["map",[]]`),
+	}
+
+	var router LogicalRouter
+	err := UnmarshalRow(row, &router)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := LogicalRouter{
+		UUID:        "5b7c1f8e-6d5d-4a4c-9d3c-0c1c3f2e1a11",
+		Name:        "lr-node1-microovn",
+		Ports:       []string{"a1", "b2"},
+		Options:     map[string]string{"chassis": "node1", "dynamic-routing-vrf-id": "10"},
+		ExternalIDs: map[string]string{},
+	}
+	if !reflect.DeepEqual(router, expected) {
+		t.Errorf("expected %+v, got %+v", expected, router)
+	}
+}
+
+func TestUnmarshalRowSingleElementSet(t *testing.T) {
+	row := Row{
+		"name":      json.RawMessage(`"lsp-node1-eth1"`),
+		"type":      json.RawMessage(`"router"`),
+		"addresses": json.RawMessage(`"router"`),
+	}
+
+	var port LogicalSwitchPort
+	err := UnmarshalRow(row, &port)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(port.Addresses, []string{"router"}) {
+		t.Errorf("expected addresses [router], got %v", port.Addresses)
+	}
+}
+
+func TestOperationMarshal(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation Operation
+		expected  string
+	}{
+		{
+			name:      "select without conditions",
+			operation: Select("Open_vSwitch"),
+			expected:  `{"op":"select","table":"Open_vSwitch","where":[]}`,
+		},
+		{
+			name: "insert",
+			operation: Insert("Logical_Switch", map[string]any{
+				"name":         "ls1",
+				"external_ids": Map(map[string]string{"b": "2", "a": "1"}),
+			}, "ls"),
+			expected: `{"op":"insert","row":{"external_ids":["map",[["a","1"],["b","2"]]],"name":"ls1"},"table":"Logical_Switch","uuid-name":"ls"}`,
+		},
+		{
+			name:      "set map keys",
+			operation: SetMapKeys("Bridge", []Condition{Equal("name", "br-eth1")}, "external_ids", map[string]string{"k": "v"}),
+			expected:  `{"mutations":[["external_ids","delete",["set",["k"]]],["external_ids","insert",["map",[["k","v"]]]]],"op":"mutate","table":"Bridge","where":[["name","==","br-eth1"]]}`,
+		},
+		{
+			name:      "assert absent",
+			operation: AssertAbsent("Logical_Router", Equal("name", "lr1")),
+			expected:  `{"columns":["_uuid"],"op":"wait","rows":[],"table":"Logical_Router","timeout":0,"until":"==","where":[["name","==","lr1"]]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := json.Marshal(tt.operation)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(encoded) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, string(encoded))
+			}
+		})
+	}
+}
+
+func TestTransact(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer serverConn.Close()
+
+	c := newClient(clientConn, "OVN_Northbound")
+	defer c.Close()
+
+	// Fake server sends echo request before responding to the transaction, and reports
+	// failure of the second operation.
+	go func() {
+		dec := json.NewDecoder(serverConn)
+		enc := json.NewEncoder(serverConn)
+
+		var request rpcMessage
+		if dec.Decode(&request) != nil {
+			return
+		}
+
+		_ = enc.Encode(map[string]any{"method": "echo", "params": []any{}, "id": "echo"})
+		var echoReply rpcMessage
+		if dec.Decode(&echoReply) != nil {
+			return
+		}
+
+		_ = enc.Encode(map[string]any{
+			"id":     request.ID,
+			"error":  nil,
+			"result": []any{map[string]any{"count": 1}, map[string]any{"error": "constraint violation", "details": "duplicate name"}},
+		})
+	}()
+
+	_, err := c.Transact(context.Background(),
+		Delete("Logical_Router", Equal("name", "lr1")),
+		Insert("Logical_Router", map[string]any{"name": "lr1"}, ""),
+	)
+	if err == nil {
+		t.Fatal("expected transaction error")
+	}
+
+	expected := "operation 'insert' on table 'Logical_Router' failed: constraint violation: duplicate name"
+	if err.Error() != expected {
+		t.Errorf("expected error '%s', got '%s'", expected, err.Error())
+	}
+}
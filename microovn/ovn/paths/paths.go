@@ -53,6 +53,14 @@ func CentralDBNBBackupPath() string {
 		"ovnnb_db_backup_"+time.Now().Format(time.DateTime)+".db")
 }
 
+// CentralDBSnapshotPath returns path to where a snapshot of the central database, identified
+// by its short name (e.g. "nb"), should be stored before its schema is upgraded from the
+// specified version
+func CentralDBSnapshotPath(shortName string, version string) string {
+	return filepath.Join(CentralDBDir(),
+		"ovn"+shortName+"_db_pre_upgrade_"+version+"_"+time.Now().Format(time.DateTime)+".db")
+}
+
 // SwitchDBDir returns path to the directory where OpenvSwitch stores its database
 func SwitchDBDir() string {
 	return filepath.Join(dataDir, "switch", "db")
@@ -93,6 +101,11 @@ func OvnSBControlSock() string {
 	return filepath.Join(OvnRuntimeDir(), "ovnsb_db.ctl")
 }
 
+// OvnSBRelayControlSock returns path to the local control socket for OVN Southbound relay service
+func OvnSBRelayControlSock() string {
+	return filepath.Join(OvnRuntimeDir(), "ovnsb_relay.ctl")
+}
+
 // OvsDatabaseSock returns path to the local unix socket used by OpenvSwitch database
 func OvsDatabaseSock() string {
 	return filepath.Join(SwitchRuntimeDir(), "db.sock")
@@ -123,6 +136,11 @@ func PkiOvnSbCertFiles() (string, string) {
 	return getServiceCertFiles("ovnsb")
 }
 
+// PkiOvnSbRelayCertFiles returns paths to certificate and private key used by OVN Southbound relay service
+func PkiOvnSbRelayCertFiles() (string, string) {
+	return getServiceCertFiles("ovnsb-relay")
+}
+
 // PkiOvnNorthdCertFiles returns paths to certificate and private key used by OVN Northd service
 func PkiOvnNorthdCertFiles() (string, string) {
 	return getServiceCertFiles("ovn-northd")
@@ -152,6 +170,9 @@ func OvsdbSwitchSchema() string {
 // Wrappers returns path to a directory with snap's command wrappers
 func Wrappers() string { return filepath.Join(snapRoot, "commands") }
 
+// BirdBinary returns path to the Bird routing daemon executable
+func BirdBinary() string { return filepath.Join(snapRoot, "usr", "sbin", "bird") }
+
 // BirdConfigDir returns path to a directory that Bird routing daemon uses to store configuration
 func BirdConfigDir() string {
 	return filepath.Join(dataDir, "bird")
@@ -167,6 +188,36 @@ func BirdConfigFile() string {
 	return filepath.Join(BirdConfigDir(), "bird.conf")
 }
 
+// FrrBgpdBinary returns path to the executable of FRR's BGP daemon
+func FrrBgpdBinary() string { return filepath.Join(snapRoot, "usr", "lib", "frr", "bgpd") }
+
+// FrrConfigDir returns path to a directory that FRR routing daemon uses to store configuration
+func FrrConfigDir() string {
+	return filepath.Join(dataDir, "frr")
+}
+
+// FrrDefaultConfig returns path to FRR's default config file
+func FrrDefaultConfig() string {
+	return filepath.Join(snapRoot, "etc", "frr", "frr.conf")
+}
+
+// FrrConfigFile returns path to current FRR's integrated config file
+func FrrConfigFile() string {
+	return filepath.Join(FrrConfigDir(), "frr.conf")
+}
+
+// NetworkConfigDir returns path to a directory where MicroOVN records network interfaces that it
+// created without netplan
+func NetworkConfigDir() string {
+	return filepath.Join(dataDir, "network")
+}
+
+// NetworkManifestFile returns path to the manifest of network interfaces and netplan configuration
+// files that are owned by MicroOVN
+func NetworkManifestFile() string {
+	return filepath.Join(dataDir, "network-manifest.yaml")
+}
+
 // getServiceCertFiles returns path to certificate and key of give service in format
 // "<base_dir>/<service_name>-{cert,privkey}.pem"
 func getServiceCertFiles(service string) (string, string) {
@@ -189,6 +240,8 @@ func RequiredDirs() []string {
 		PkiDir(),
 		EnvDir(),
 		BirdConfigDir(),
+		FrrConfigDir(),
+		NetworkConfigDir(),
 	}
 }
 
@@ -199,5 +252,6 @@ func BackupDirs() []string {
 		dataDir,
 		LogsDir(),
 		BirdConfigDir(),
+		FrrConfigDir(),
 	}
 }
@@ -0,0 +1,362 @@
+// Package raft provides functions for inspecting and repairing membership of
+// the clustered OVN Northbound and Southbound databases.
+package raft
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/node"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/securitylog"
+)
+
+// monitorInterval is the amount of time between two consecutive background
+// membership checks.
+const monitorInterval = 5 * time.Minute
+
+// deadThreshold is the amount of time after which a server, that the RAFT leader did not hear
+// from, is considered dead even if it belongs to a member with "central" service.
+const deadThreshold = time.Hour
+
+// ErrExternalCentral is returned when OVN central services are not managed by MicroOVN
+// and their RAFT membership can not be reconciled with the MicroOVN cluster.
+var ErrExternalCentral = errors.New("OVN central is configured externally via 'ovn.central-ips'")
+
+// serverLineRegex matches a single entry from the "Servers:" section of the
+// "cluster/status" output. For example:
+//
+//	4f1a (4f1a at ssl:10.0.0.1:6643) (self) next_index=2 match_index=9
+var serverLineRegex = regexp.MustCompile(`^\s+([0-9a-f]+) \(([0-9a-f]+) at ([^)]+)\)(.*)$`)
+
+// lastMsgRegex matches the time since the last message from a server, that is reported only
+// by the RAFT leader. For example "last msg 123 ms ago".
+var lastMsgRegex = regexp.MustCompile(`last msg (\d+) ms ago`)
+
+// server represents a RAFT server as reported by the "cluster/status" command.
+type server struct {
+	ID      string
+	Address string
+	Self    bool
+	// LastMsg is the time since the leader last heard from the server, zero if it's unknown.
+	LastMsg time.Duration
+}
+
+// Host returns IP address or hostname portion of the server's RAFT address.
+func (srv server) Host() string {
+	_, hostPort, found := strings.Cut(srv.Address, ":")
+	if !found {
+		return srv.Address
+	}
+
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return hostPort
+	}
+	return host
+}
+
+// database ties together OVSDB spec of a central database and a control socket
+// of the process that serves it.
+type database struct {
+	spec          *ovnCmd.OvsdbSpec
+	controlSocket string
+}
+
+// centralDatabases returns list of clustered OVN central databases.
+func centralDatabases() ([]database, error) {
+	nbSpec, err := ovnCmd.NewOvsdbSpec(ovnCmd.OvsdbTypeNBLocal)
+	if err != nil {
+		return nil, err
+	}
+
+	sbSpec, err := ovnCmd.NewOvsdbSpec(ovnCmd.OvsdbTypeSBLocal)
+	if err != nil {
+		return nil, err
+	}
+
+	return []database{
+		{spec: nbSpec, controlSocket: paths.OvnNBControlSock()},
+		{spec: sbSpec, controlSocket: paths.OvnSBControlSock()},
+	}, nil
+}
+
+// parseClusterServers extracts list of RAFT servers from the output of the
+// "cluster/status" command.
+func parseClusterServers(output string) ([]server, error) {
+	var servers []server
+	inServers := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "Servers:" {
+			inServers = true
+			continue
+		}
+		if !inServers {
+			continue
+		}
+
+		match := serverLineRegex.FindStringSubmatch(line)
+		if match == nil {
+			break
+		}
+		srv := server{
+			ID:      match[1],
+			Address: match[3],
+			Self:    strings.Contains(match[4], "(self)"),
+		}
+		if lastMsg := lastMsgRegex.FindStringSubmatch(match[4]); lastMsg != nil {
+			ms, err := strconv.ParseInt(lastMsg[1], 10, 64)
+			if err == nil {
+				srv.LastMsg = time.Duration(ms) * time.Millisecond
+			}
+		}
+		servers = append(servers, srv)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !inServers {
+		return nil, errors.New("'Servers' section not found in cluster status")
+	}
+
+	return servers, nil
+}
+
+// centralMembers returns a map of hosts to names of MicroOVN cluster members
+// that have "central" service enabled.
+func centralMembers(ctx context.Context, s state.State) (map[string]string, error) {
+	members, err := node.FindService(ctx, s, types.SrvCentral)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make(map[string]string, len(members))
+	for _, member := range members {
+		host, _, err := net.SplitHostPort(member.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse address of member '%s': %w", member.Name, err)
+		}
+		hosts[host] = member.Name
+	}
+
+	return hosts, nil
+}
+
+// inspect compares RAFT servers of the database against MicroOVN cluster members
+// that have "central" service enabled. Servers that do not belong to any such member
+// are marked as stale. When executed on the RAFT leader, servers that the leader did
+// not hear from for longer than deadThreshold are marked as stale as well.
+func inspect(ctx context.Context, s state.State, db database, members map[string]string) (types.RaftMembershipResult, error) {
+	result := types.RaftMembershipResult{Database: db.spec.Name}
+
+	output, err := ovnCmd.AppCtl(ctx, s, db.controlSocket, "cluster/status", db.spec.Name)
+	if err != nil {
+		return result, fmt.Errorf("failed to get cluster status of %s database: %w", db.spec.FriendlyName, err)
+	}
+
+	servers, err := parseClusterServers(output)
+	if err != nil {
+		return result, fmt.Errorf("failed to parse cluster status of %s database: %w", db.spec.FriendlyName, err)
+	}
+
+	for _, srv := range servers {
+		member, known := members[srv.Host()]
+		dead := srv.LastMsg > deadThreshold
+		result.Servers = append(result.Servers, types.RaftServer{
+			ServerID: srv.ID,
+			Address:  srv.Address,
+			Member:   member,
+			Dead:     dead,
+			Stale:    (!known || dead) && !srv.Self,
+		})
+	}
+
+	return result, nil
+}
+
+// kick removes stale server from the RAFT cluster of the database.
+func kick(ctx context.Context, s state.State, db database, srv *types.RaftServer) {
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "raft_kick", "database": db.spec.Name, "server": srv.ServerID, "node": s.Name()},
+		"Kicking stale server '%s' (%s) from %s database cluster",
+		srv.ServerID, srv.Address, db.spec.FriendlyName,
+	)
+
+	_, err := ovnCmd.AppCtl(ctx, s, db.controlSocket, "cluster/kick", db.spec.Name, srv.ServerID)
+	if err != nil {
+		srv.Error = err.Error()
+		logger.Errorf("Failed to kick server '%s' from %s database cluster: %s", srv.ServerID, db.spec.FriendlyName, err)
+		return
+	}
+	srv.Kicked = true
+}
+
+// CheckMembership compares RAFT servers of OVN Northbound and Southbound databases against
+// MicroOVN cluster members that have "central" service enabled. If "repair" is true, servers
+// that do not belong to any such member, or that are dead, are kicked from the RAFT cluster.
+// Dead servers can be detected only on the RAFT leader.
+//
+// This function must be executed on a member that runs "central" services.
+func CheckMembership(ctx context.Context, s state.State, repair bool) (types.RaftMembershipReport, error) {
+	externalCentral, err := environment.IsExternalCentralConfigured(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if externalCentral {
+		return nil, ErrExternalCentral
+	}
+
+	members, err := centralMembers(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	databases, err := centralDatabases()
+	if err != nil {
+		return nil, err
+	}
+
+	report := types.RaftMembershipReport{}
+	for _, db := range databases {
+		result, err := inspect(ctx, s, db, members)
+		if err != nil {
+			logger.Errorf("%s", err)
+			result.Error = err.Error()
+		}
+
+		if repair {
+			for i := range result.Servers {
+				if result.Servers[i].Stale {
+					kick(ctx, s, db, &result.Servers[i])
+				}
+			}
+		}
+
+		report = append(report, result)
+	}
+
+	return report, nil
+}
+
+// isDesignatedMember returns "true" if the local server of the Northbound database is the RAFT
+// leader. Only this member performs background membership repairs, as the leader is always
+// reachable and it's the only server that knows when other servers last responded.
+func isDesignatedMember(ctx context.Context, s state.State) (bool, error) {
+	databases, err := centralDatabases()
+	if err != nil {
+		return false, err
+	}
+
+	output, err := ovnCmd.AppCtl(ctx, s, databases[0].controlSocket, "cluster/status", databases[0].spec.Name)
+	if err != nil {
+		return false, fmt.Errorf("failed to get cluster status of %s database: %w", databases[0].spec.FriendlyName, err)
+	}
+
+	leader, err := parseClusterLeader(output)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse cluster status of %s database: %w", databases[0].spec.FriendlyName, err)
+	}
+
+	return leader == "self", nil
+}
+
+// MonitorMembership periodically checks RAFT membership of OVN central databases and kicks
+// servers that do not belong to any member with "central" service enabled, or that are dead.
+// Checks are performed only while "central" service is active on the local member. To avoid racing
+// with members that are in the process of joining or leaving the cluster, a server is kicked
+// only after it was found stale by two consecutive checks.
+//
+// This function blocks until the context is cancelled, so it should be executed in a goroutine.
+func MonitorMembership(ctx context.Context, s state.State) {
+	ticker := time.NewTicker(monitorInterval)
+	defer ticker.Stop()
+
+	// Stale servers found by the previous check, keyed by "<database>/<server ID>"
+	previouslyStale := map[string]bool{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		centralActive, err := node.HasServiceActive(ctx, s, types.SrvCentral)
+		if err != nil {
+			logger.Warnf("Failed to query local services: %s", err)
+			continue
+		}
+		if !centralActive {
+			previouslyStale = map[string]bool{}
+			continue
+		}
+
+		designated, err := isDesignatedMember(ctx, s)
+		if err != nil {
+			logger.Warnf("Failed to determine member responsible for RAFT membership checks: %s", err)
+			continue
+		}
+		if !designated {
+			previouslyStale = map[string]bool{}
+			continue
+		}
+
+		report, err := CheckMembership(ctx, s, false)
+		if err != nil {
+			if !errors.Is(err, ErrExternalCentral) {
+				logger.Warnf("Failed to check RAFT membership of OVN central databases: %s", err)
+			}
+			continue
+		}
+
+		databases, err := centralDatabases()
+		if err != nil {
+			logger.Errorf("%s", err)
+			continue
+		}
+
+		currentlyStale := map[string]bool{}
+		for i, result := range report {
+			for _, srv := range result.Servers {
+				if !srv.Stale {
+					continue
+				}
+
+				key := result.Database + "/" + srv.ServerID
+				if previouslyStale[key] {
+					kick(ctx, s, databases[i], &srv)
+					if srv.Kicked {
+						continue
+					}
+				} else {
+					logger.Warnf(
+						"Server '%s' (%s) in %s database cluster is dead or does not belong to any central member",
+						srv.ServerID, srv.Address, result.Database,
+					)
+				}
+				currentlyStale[key] = true
+			}
+		}
+		previouslyStale = currentlyStale
+	}
+}
@@ -0,0 +1,82 @@
+package raft
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const clusterStatusOutput = `4f1a
+Name: OVN_Northbound
+Cluster ID: 7d3a (7d3a8fdc-2a6e-4b4b-9a0e-0c8a0f3f2f0e)
+Server ID: 4f1a (4f1a1f6e-8e3e-4c52-9d46-3f3b3e0c9a11)
+Address: ssl:10.0.0.1:6643
+Status: cluster member
+Role: leader
+Term: 3
+Leader: self
+Vote: self
+
+Log: [2, 10]
+Entries not yet committed: 0
+Entries not yet applied: 0
+Connections: ->0ab3 ->91c2 <-0ab3
+Disconnections: 1
+Servers:
+    4f1a (4f1a at ssl:10.0.0.1:6643) (self) next_index=2 match_index=9
+    0ab3 (0ab3 at ssl:10.0.0.2:6643) next_index=10 match_index=9 last msg 123 ms ago
+    91c2 (91c2 at ssl:[fd00::3]:6643) next_index=10 match_index=0 last msg 71234 ms ago
+`
+
+func TestParseClusterServers(t *testing.T) {
+	servers, err := parseClusterServers(clusterStatusOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []server{
+		{ID: "4f1a", Address: "ssl:10.0.0.1:6643", Self: true},
+		{ID: "0ab3", Address: "ssl:10.0.0.2:6643", LastMsg: 123 * time.Millisecond},
+		{ID: "91c2", Address: "ssl:[fd00::3]:6643", LastMsg: 71234 * time.Millisecond},
+	}
+	if !reflect.DeepEqual(servers, expected) {
+		t.Errorf("expected %v, got %v", expected, servers)
+	}
+
+	expectedHosts := []string{"10.0.0.1", "10.0.0.2", "fd00::3"}
+	for i, srv := range servers {
+		if srv.Host() != expectedHosts[i] {
+			t.Errorf("expected host %s, got %s", expectedHosts[i], srv.Host())
+		}
+	}
+}
+
+func TestParseClusterServersMissingSection(t *testing.T) {
+	_, err := parseClusterServers("Name: OVN_Northbound\nStatus: joining cluster\n")
+	if err == nil {
+		t.Error("expected error when 'Servers' section is missing")
+	}
+}
+
+func TestParseClusterLeader(t *testing.T) {
+	leader, err := parseClusterLeader(clusterStatusOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if leader != "self" {
+		t.Errorf("expected leader 'self', got '%s'", leader)
+	}
+
+	leader, err = parseClusterLeader("Name: OVN_Southbound\nRole: follower\nLeader: unknown\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if leader != "unknown" {
+		t.Errorf("expected leader 'unknown', got '%s'", leader)
+	}
+
+	_, err = parseClusterLeader("Name: OVN_Southbound\n")
+	if err == nil {
+		t.Error("expected error when 'Leader' field is missing")
+	}
+}
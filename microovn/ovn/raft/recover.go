@@ -0,0 +1,295 @@
+package raft
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v3/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microdb "github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/node"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/securitylog"
+	"github.com/canonical/microovn/microovn/snap"
+)
+
+// centralSnapServices lists snap services that make up "central" service, in the order
+// in which they should be stopped.
+var centralSnapServices = []string{"ovn-northd", "ovn-ovsdb-server-nb", "ovn-ovsdb-server-sb"}
+
+// recoverySpec describes how to re-create a single central database during recovery.
+type recoverySpec struct {
+	db         database
+	path       string
+	backupPath string
+	raftPort   string
+}
+
+// parseClusterLeader extracts the value of the "Leader" field from the output of
+// the "cluster/status" command. The value is "unknown" when the server does not
+// know about any leader, e.g. when the cluster lost its quorum.
+func parseClusterLeader(output string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		leader, found := strings.CutPrefix(scanner.Text(), "Leader: ")
+		if found {
+			return strings.TrimSpace(leader), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", errors.New("'Leader' field not found in cluster status")
+}
+
+// hasLeader returns "true" if the local server of the database knows about a RAFT cluster leader.
+func hasLeader(ctx context.Context, s state.State, db database) (bool, error) {
+	output, err := ovnCmd.AppCtl(ctx, s, db.controlSocket, "cluster/status", db.spec.Name)
+	if err != nil {
+		return false, fmt.Errorf("failed to get cluster status of %s database: %w", db.spec.FriendlyName, err)
+	}
+
+	leader, err := parseClusterLeader(output)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse cluster status of %s database: %w", db.spec.FriendlyName, err)
+	}
+
+	return leader != "unknown", nil
+}
+
+// stopCentral stops all snap services that make up "central" service.
+func stopCentral(ctx context.Context, disable bool) {
+	for _, service := range centralSnapServices {
+		err := snap.Stop(ctx, service, disable)
+		if err != nil {
+			logger.Warnf("Failed to stop %s: %s", service, err)
+		}
+	}
+}
+
+// startCentral starts all snap services that make up "central" service.
+func startCentral(ctx context.Context) error {
+	for i := len(centralSnapServices) - 1; i >= 0; i-- {
+		err := snap.Start(ctx, centralSnapServices[i], true)
+		if err != nil {
+			return fmt.Errorf("failed to start %s: %w", centralSnapServices[i], err)
+		}
+	}
+	return nil
+}
+
+// restoreBackup replaces database file with its backup.
+func restoreBackup(spec recoverySpec) error {
+	_ = os.Remove(spec.path)
+	err := os.Rename(spec.backupPath, spec.path)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s database from backup: %w", spec.db.spec.FriendlyName, err)
+	}
+	return nil
+}
+
+// recreateCluster converts clustered database to standalone and creates a new single-member
+// cluster from it. Original database file is kept in the backup location.
+func recreateCluster(ctx context.Context, spec recoverySpec, localAddr string) error {
+	err := os.Rename(spec.path, spec.backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to back up %s database: %w", spec.db.spec.FriendlyName, err)
+	}
+
+	standalonePath := spec.path + ".standalone"
+	defer func() { _ = os.Remove(standalonePath) }()
+
+	_, err = shared.RunCommandContext(ctx, "ovsdb-tool", "cluster-to-standalone", standalonePath, spec.backupPath)
+	if err != nil {
+		err = fmt.Errorf("failed to convert %s database to standalone: %w", spec.db.spec.FriendlyName, err)
+		return errors.Join(err, restoreBackup(spec))
+	}
+
+	_, err = shared.RunCommandContext(
+		ctx,
+		"ovsdb-tool",
+		"create-cluster",
+		spec.path,
+		standalonePath,
+		"ssl:"+net.JoinHostPort(localAddr, spec.raftPort),
+	)
+	if err != nil {
+		err = fmt.Errorf("failed to create %s database cluster: %w", spec.db.spec.FriendlyName, err)
+		return errors.Join(err, restoreBackup(spec))
+	}
+
+	return nil
+}
+
+// RecoverCluster restores OVN central databases after a permanent loss of quorum. It converts
+// local Northbound and Southbound databases to standalone databases and creates new
+// single-member RAFT clusters from them. Service "central" is then removed from every other
+// member in the MicroOVN cluster. Names of these members are returned to the caller.
+//
+// Unless "force" is true, this function refuses to proceed if any of the databases still
+// has a RAFT leader.
+//
+// Note: Environment files on cluster members need to be regenerated after the recovery.
+func RecoverCluster(ctx context.Context, s state.State, force bool) ([]string, error) {
+	securitylog.Log(
+		securitylog.CatAuthz,
+		securitylog.EventAdminActivity,
+		logger.Ctx{"action": "database_recover", "node": s.Name(), "force": force},
+		"Recovering OVN central databases from node '%s'",
+		s.Name(),
+	)
+
+	hasCentral, err := node.HasServiceActive(ctx, s, types.SrvCentral)
+	if err != nil {
+		return nil, err
+	}
+	if !hasCentral {
+		return nil, fmt.Errorf("member '%s' does not run 'central' service", s.Name())
+	}
+
+	externalCentral, err := environment.IsExternalCentralConfigured(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if externalCentral {
+		return nil, ErrExternalCentral
+	}
+
+	databases, err := centralDatabases()
+	if err != nil {
+		return nil, err
+	}
+
+	if !force {
+		for _, db := range databases {
+			leader, err := hasLeader(ctx, s, db)
+			if err != nil {
+				return nil, err
+			}
+			if leader {
+				return nil, fmt.Errorf("%s database cluster has a leader and does not require recovery", db.spec.FriendlyName)
+			}
+		}
+	}
+
+	centralMembers, err := node.FindService(ctx, s, types.SrvCentral)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := []recoverySpec{
+		{db: databases[0], path: paths.CentralDBNBPath(), backupPath: paths.CentralDBNBBackupPath(), raftPort: "6643"},
+		{db: databases[1], path: paths.CentralDBSBPath(), backupPath: paths.CentralDBSBBackupPath(), raftPort: "6644"},
+	}
+
+	logger.Info("Stopping OVN central services before recovery")
+	stopCentral(ctx, false)
+
+	for i, spec := range specs {
+		logger.Infof("Re-creating %s database cluster", spec.db.spec.FriendlyName)
+		err = recreateCluster(ctx, spec, s.Address().Hostname())
+		if err != nil {
+			// Put back databases that were already re-created, so that the member is left
+			// in the same state as before the recovery attempt.
+			for _, done := range specs[:i] {
+				err = errors.Join(err, restoreBackup(done))
+			}
+			return nil, errors.Join(err, startCentral(ctx))
+		}
+	}
+
+	var removedMembers []string
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		for _, member := range centralMembers {
+			if member.Name == s.Name() {
+				continue
+			}
+
+			err := microdb.DeleteService(ctx, tx, member.Name, types.SrvCentral)
+			if err != nil {
+				return err
+			}
+			removedMembers = append(removedMembers, member.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		// Put back the original databases, so that the member is left in the same state as before
+		// the recovery attempt, consistent with the services table.
+		err = fmt.Errorf("failed to remove 'central' service from lost members: %w", err)
+		for _, spec := range specs {
+			err = errors.Join(err, restoreBackup(spec))
+		}
+		return nil, errors.Join(err, startCentral(ctx))
+	}
+
+	err = environment.GenerateEnvironment(ctx, s)
+	if err != nil {
+		return removedMembers, fmt.Errorf("failed to regenerate environment file: %w", err)
+	}
+
+	logger.Info("Starting OVN central services after recovery")
+	err = startCentral(ctx)
+	if err != nil {
+		return removedMembers, err
+	}
+
+	for _, db := range databases {
+		err = ovnCmd.WaitForDBState(ctx, s, db.spec, ovnCmd.OvsdbConnected, ovnCmd.DefaultDBConnectWait)
+		if err != nil {
+			return removedMembers, err
+		}
+	}
+
+	return removedMembers, nil
+}
+
+// AbandonCentral stops "central" services on a member that no longer has "central" service enabled
+// but still has OVN central database files present, e.g. because the databases were recovered from
+// another member while this member was unreachable. The database files are moved to the backup location
+// to prevent the member from re-joining the old RAFT cluster.
+func AbandonCentral(ctx context.Context, s state.State) error {
+	hasCentral, err := node.HasServiceActive(ctx, s, types.SrvCentral)
+	if err != nil {
+		return err
+	}
+	if hasCentral {
+		return nil
+	}
+
+	if !shared.PathExists(paths.CentralDBNBPath()) && !shared.PathExists(paths.CentralDBSBPath()) {
+		return nil
+	}
+
+	logger.Warnf("Member '%s' has OVN central databases present without 'central' service enabled. Stopping central services.", s.Name())
+	stopCentral(ctx, true)
+
+	if shared.PathExists(paths.CentralDBNBPath()) {
+		err = os.Rename(paths.CentralDBNBPath(), paths.CentralDBNBBackupPath())
+		if err != nil {
+			return fmt.Errorf("failed to move Northbound database to backup: %w", err)
+		}
+	}
+
+	if shared.PathExists(paths.CentralDBSBPath()) {
+		err = os.Rename(paths.CentralDBSBPath(), paths.CentralDBSBBackupPath())
+		if err != nil {
+			return fmt.Errorf("failed to move Southbound database to backup: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
 	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
 	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/raft"
 	"github.com/canonical/microovn/microovn/snap"
 )
 
@@ -50,12 +51,26 @@ func refresh(ctx context.Context, s state.State) error {
 		return err
 	}
 
+	hasRelay, err := node.HasServiceActive(ctx, s, types.SrvRelay)
+	if err != nil {
+		return err
+	}
+
 	// Generate the configuration.
 	err = environment.GenerateEnvironment(ctx, s)
 	if err != nil {
 		return fmt.Errorf("failed to generate the daemon configuration: %w", err)
 	}
 
+	// Stop central services left behind on members that were dropped from the central
+	// cluster, e.g. by recovery of the OVN central databases from another member.
+	if !hasCentral {
+		err = raft.AbandonCentral(ctx, s)
+		if err != nil {
+			logger.Warnf("Failed to stop abandoned OVN central services: %s", err)
+		}
+	}
+
 	// Restart OVN Northd service to account for NB/SB cluster changes.
 	if hasCentral {
 		err = snap.Restart(ctx, "ovn-northd")
@@ -64,6 +79,14 @@ func refresh(ctx context.Context, s state.State) error {
 		}
 	}
 
+	// Restart OVSDB relay to account for SB cluster changes.
+	if hasRelay {
+		err = snap.Restart(ctx, "relay")
+		if err != nil {
+			return fmt.Errorf("failed to restart OVN relay: %w", err)
+		}
+	}
+
 	// Enable OVN chassis.
 	if hasSwitch {
 		err = ovnCluster.UpdateOvnControllerRemoteConfig(ctx, s)
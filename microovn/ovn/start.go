@@ -10,12 +10,17 @@ import (
 
 	"github.com/canonical/microcluster/v3/state"
 
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/bgp"
+	"github.com/canonical/microovn/microovn/external"
 	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn/chassis"
 	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
 	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
 	"github.com/canonical/microovn/microovn/ovn/environment"
 	"github.com/canonical/microovn/microovn/ovn/ovsdb"
 	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/ovn/raft"
 	"github.com/canonical/microovn/microovn/securitylog"
 )
 
@@ -62,6 +67,11 @@ func Start(ctx context.Context, s state.State) error {
 		if err != nil {
 			logger.Warnf("Failed to update OVN listening configs. There might be connectivity issues.")
 		}
+	} else {
+		err = raft.AbandonCentral(ctx, s)
+		if err != nil {
+			logger.Warnf("Failed to stop abandoned OVN central services: %s", err)
+		}
 	}
 	// Reconfigure OVS to use OVN.
 	err = ovnCluster.UpdateOvnControllerRemoteConfig(ctx, s)
@@ -69,6 +79,40 @@ func Start(ctx context.Context, s state.State) error {
 		return err
 	}
 
+	chassisActive, err := node.HasServiceActive(ctx, s, types.SrvChassis)
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if chassisActive {
+		err = external.Reconcile(ctx, s)
+		if err != nil {
+			logger.Warnf("Failed to re-apply external networks: %s", err)
+		}
+
+		err = chassis.ReconcileEncap(ctx, s)
+		if err != nil {
+			logger.Warnf("Failed to re-apply encapsulation of the chassis: %s", err)
+		}
+
+		err = chassis.ReconcileGateway(ctx, s)
+		if err != nil {
+			logger.Warnf("Failed to re-apply gateway role of the chassis: %s", err)
+		}
+	}
+
+	bgpActive, err := node.HasServiceActive(ctx, s, types.SrvBgp)
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	if bgpActive {
+		err = bgp.Reconcile(ctx, s)
+		if err != nil {
+			logger.Warnf("Failed to re-apply BGP configuration: %s", err)
+		}
+	}
+
 	// If "central" services are active on this node, start two goroutines that will check if OVN database schemas
 	// are up-to-date. If a schema upgrade is required, they will coordinate with other members in the cluster and
 	// trigger the schema upgrade.
@@ -91,6 +135,11 @@ func Start(ctx context.Context, s state.State) error {
 		}()
 	}
 
+	// Periodically check that RAFT servers of NB and SB databases belong to members with "central"
+	// service and kick any stale servers. The monitor runs regardless of the local services, as
+	// "central" service can be enabled or disabled later, and it skips checks while it's not active.
+	go raft.MonitorMembership(ctx, s)
+
 	_, err = shared.RunCommandContext(ctx, filepath.Join(paths.Wrappers(), "refresh-expiring-certs"))
 	if err != nil {
 		logger.Warnf("Failed to execute refresh-expiring-certs script. '%s'", err)